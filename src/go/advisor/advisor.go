@@ -3,6 +3,7 @@ package advisor
 import (
 	"aws-blended-instances-advisor/api/schema"
 	instPkg "aws-blended-instances-advisor/instances"
+	"context"
 
 	"go.uber.org/zap"
 )
@@ -13,10 +14,15 @@ type Advisor interface {
 	// Advise selects and scores Instances from a group of available
 	// Instances for all Regions, returning the selection and information as an
 	// Advice.
+	//
+	// Advising stops with an error once ctx is done, and onProgress is called
+	// as each Region is completed.
 	Advise(
+		ctx context.Context,
 		instancesInfo instPkg.GlobalInfo,
 		services []schema.Service,
 		options schema.Options,
+		onProgress ProgressHandler,
 		logger *zap.Logger,
 	) (
 		*schema.Advice,
@@ -27,6 +33,7 @@ type Advisor interface {
 	// Instances for one Region, returning the selection and information as a
 	// RegionAdvice.
 	AdviseForRegion(
+		ctx context.Context,
 		info instPkg.RegionInfo,
 		services []schema.Service,
 		options schema.Options,
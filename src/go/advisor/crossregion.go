@@ -0,0 +1,324 @@
+package advisor
+
+import (
+	"aws-blended-instances-advisor/api/schema"
+	awsTypes "aws-blended-instances-advisor/aws/types"
+	instPkg "aws-blended-instances-advisor/instances"
+	"aws-blended-instances-advisor/utils"
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// A regionOffer holds the Instances which would be selected for one
+// instance of a Service in a Region.
+type regionOffer struct {
+	region    awsTypes.Region
+	permanent *instPkg.Instance // nil when no permanent Instance fits
+	transient *instPkg.Instance // nil when no Instance fits
+}
+
+// A regionAllocation counts the instances of a Service placed in a Region.
+type regionAllocation struct {
+	permanent int
+	transient int
+}
+
+// adviseAcrossRegions distributes each Service's instances across the given
+// Regions as one deployment plan, treating each Service's minimum and
+// maximum instances as totals across all Regions.
+//
+// Instances are placed one at a time in the Region where they cost least,
+// by the advisor's weights. Spot revocations within a Region are correlated,
+// so each transient instance already placed in a Region increases the cost
+// of placing another there. Each Service is spread across at least its
+// MinRegions, and only placed in Regions allowed by its Placement.
+// Services with a LoadProfile cannot be advised across regions.
+//
+// The returned Advice contains each Region's share of the plan, and
+// onProgress is called as each Region is completed.
+func (advisor WeightedAdvisor) adviseAcrossRegions(
+	ctx context.Context,
+	instancesInfo instPkg.GlobalInfo,
+	regions []awsTypes.Region,
+	services []schema.Service,
+	options schema.Options,
+	onProgress ProgressHandler,
+	logger *zap.Logger,
+) (
+	*schema.Advice,
+	error,
+) {
+	logger.Info(
+		"advising across regions with weighted advisor",
+		zap.Int("regionCount", len(regions)),
+	)
+
+	for _, svc := range services {
+		if svc.LoadProfile != nil {
+			return nil, fmt.Errorf("service %s has a loadProfile, which cannot be used across regions", svc.Name)
+		}
+	}
+
+	regionServices := make(map[awsTypes.Region][]schema.Service)
+
+	for _, svc := range services {
+		if err := ctx.Err(); err != nil {
+			return nil, utils.PrependToError(err, "advice cancelled")
+		}
+
+		allocations, err := advisor.allocateService(instancesInfo, regions, svc, options, logger)
+		if err != nil {
+			return nil, utils.PrependToError(err, fmt.Sprintf("could not place service %s", svc.Name))
+		}
+
+		for _, region := range regions {
+			allocation, ok := allocations[region]
+			if !ok {
+				continue
+			}
+			regionSvc := svc
+			regionSvc.MinInstances = allocation.permanent
+			regionSvc.MaxInstances = allocation.permanent + allocation.transient
+			regionServices[region] = append(regionServices[region], regionSvc)
+		}
+
+		logger.Info(
+			"placed service across regions",
+			zap.String("serviceName", svc.Name),
+			zap.Int("regionCount", len(allocations)),
+		)
+	}
+
+	plannedRegions := []awsTypes.Region{}
+	for _, region := range regions {
+		if _, ok := regionServices[region]; ok {
+			plannedRegions = append(plannedRegions, region)
+		}
+	}
+
+	advice := make(schema.Advice)
+	for index, region := range plannedRegions {
+		if err := ctx.Err(); err != nil {
+			return nil, utils.PrependToError(err, "advice cancelled")
+		}
+
+		progress := RegionProgress{
+			Region:    region.CodeString(),
+			Completed: index + 1,
+			Total:     len(plannedRegions),
+		}
+
+		regionAdvice, err := advisor.adviseAndScoreRegion(
+			ctx,
+			instancesInfo,
+			region,
+			regionServices[region],
+			options,
+			logger,
+		)
+		if err != nil {
+			progress.Err = err
+			onProgress.report(progress)
+			return nil, err
+		}
+
+		advice[region.CodeString()] = *regionAdvice
+
+		progress.Advice = regionAdvice
+		onProgress.report(progress)
+	}
+
+	return &advice, nil
+}
+
+// allocateService decides how many of a Service's permanent and transient
+// instances are placed in each Region, as described by adviseAcrossRegions.
+func (advisor WeightedAdvisor) allocateService(
+	instancesInfo instPkg.GlobalInfo,
+	regions []awsTypes.Region,
+	svc schema.Service,
+	options schema.Options,
+	logger *zap.Logger,
+) (
+	map[awsTypes.Region]*regionAllocation,
+	error,
+) {
+	offers, err := advisor.findOffers(instancesInfo, placeableRegions(regions, svc), svc, options, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	minRegions := svc.MinRegions
+	if minRegions == 0 {
+		minRegions = 1
+	}
+	if len(offers) < minRegions {
+		return nil, fmt.Errorf(
+			"instances fit in %d allowed regions, fewer than minRegions of %d",
+			len(offers),
+			minRegions,
+		)
+	}
+
+	// Permanent instances are placed first, so must themselves be spread
+	permanentRegions := 0
+	for _, offer := range offers {
+		if offer.permanent != nil {
+			permanentRegions += 1
+		}
+	}
+	if permanentRegions < utils.MinOfInts(minRegions, svc.MinInstances) {
+		return nil, fmt.Errorf(
+			"permanent instances fit in %d allowed regions, fewer than minRegions of %d",
+			permanentRegions,
+			minRegions,
+		)
+	}
+
+	allocations := make(map[awsTypes.Region]*regionAllocation)
+	for placed := 0; placed < svc.MaxInstances; placed += 1 {
+		isPermanent := placed < svc.MinInstances
+
+		var bestOffer *regionOffer
+		bestCost := math.Inf(1)
+		for i := range offers {
+			placedTransient := 0
+			if allocation, used := allocations[offers[i].region]; used {
+				if len(allocations) < minRegions {
+					continue // Spread over minRegions before placing more in one region
+				}
+				placedTransient = allocation.transient
+			}
+
+			inst := offers[i].transient
+			if isPermanent {
+				inst = offers[i].permanent
+			}
+			if inst == nil {
+				continue
+			}
+
+			cost := advisor.placementCost(inst, instancesInfo.GlobalAggregates, placedTransient)
+			if cost < bestCost {
+				bestOffer, bestCost = &offers[i], cost
+			}
+		}
+
+		if bestOffer == nil {
+			return nil, fmt.Errorf("no region has an instance which fits (placed %d of %d)", placed, svc.MaxInstances)
+		}
+
+		allocation, ok := allocations[bestOffer.region]
+		if !ok {
+			allocation = &regionAllocation{}
+			allocations[bestOffer.region] = allocation
+		}
+		if isPermanent {
+			allocation.permanent += 1
+		} else {
+			allocation.transient += 1
+		}
+	}
+
+	return allocations, nil
+}
+
+// findOffers returns the Instances which would be selected for a Service in
+// each of the given Regions, omitting Regions in which no Instance fits.
+func (advisor WeightedAdvisor) findOffers(
+	instancesInfo instPkg.GlobalInfo,
+	regions []awsTypes.Region,
+	svc schema.Service,
+	options schema.Options,
+	logger *zap.Logger,
+) (
+	[]regionOffer,
+	error,
+) {
+	offers := []regionOffer{}
+	for _, region := range regions {
+		info, ok := instancesInfo.RegionInfoMap[region]
+		if !ok {
+			return nil, fmt.Errorf("region not in map: %s", region.CodeString())
+		}
+
+		permanentInstances, transientInstances := candidateInstances(info, options, logger)
+		allInstances := append(copyInstances(permanentInstances), transientInstances...)
+
+		offer := regionOffer{region: region}
+		offer.permanent, _ = advisor.selectInstanceForService(permanentInstances, info.PermanentAggregates, svc, options)
+		offer.transient, _ = advisor.selectInstanceForService(allInstances, info.RegionAggregates, svc, options)
+		if offer.transient == nil {
+			logger.Info(
+				"no instance fits service in region",
+				zap.String("serviceName", svc.Name),
+				zap.String("region", region.CodeString()),
+			)
+			continue
+		}
+		offers = append(offers, offer)
+	}
+	return offers, nil
+}
+
+// placementCost returns the cost of placing an Instance in a Region which
+// already has placedTransient transient instances of the same Service, using
+// the advisor's price and availability weights. Lower costs are better.
+func (advisor WeightedAdvisor) placementCost(
+	inst *instPkg.Instance,
+	globalAgg instPkg.Aggregates,
+	placedTransient int,
+) float64 {
+	price := minMaxScale(inst.PricePerHour, globalAgg.MinPricePerHour, globalAgg.MaxPricePerHour)
+	revocation := minMaxScale(
+		inst.RevocationProbability,
+		globalAgg.MinRevocationProbability,
+		globalAgg.MaxRevocationProbability,
+	)
+	return advisor.weights.PriceWeight*price +
+		advisor.weights.RevocationProbabilityWeight*revocation*float64(1+placedTransient)
+}
+
+// placeableRegions returns the Regions in which a Service's Placement allows
+// its instances to be placed.
+func placeableRegions(regions []awsTypes.Region, svc schema.Service) []awsTypes.Region {
+	if svc.Placement == nil {
+		return regions
+	}
+
+	allowed, _ := awsTypes.NewRegions(svc.Placement.Regions)
+	latencyFrom, _ := awsTypes.NewRegion(svc.Placement.LatencyFrom)
+	maxLatency := time.Duration(svc.Placement.MaxLatencyMs * float64(time.Millisecond))
+
+	placeable := []awsTypes.Region{}
+	for _, region := range regions {
+		if len(allowed) > 0 && !containsRegion(allowed, region) {
+			continue
+		}
+		if maxLatency > 0 && awsTypes.EstimatedLatency(latencyFrom, region) > maxLatency {
+			continue
+		}
+		placeable = append(placeable, region)
+	}
+	return placeable
+}
+
+func containsRegion(regions []awsTypes.Region, region awsTypes.Region) bool {
+	for _, r := range regions {
+		if r == region {
+			return true
+		}
+	}
+	return false
+}
+
+func minMaxScale(value float64, min float64, max float64) float64 {
+	if max == min {
+		return 0
+	}
+	return (value - min) / (max - min)
+}
@@ -0,0 +1,224 @@
+package advisor
+
+import (
+	"aws-blended-instances-advisor/api/schema"
+	awsTypes "aws-blended-instances-advisor/aws/types"
+	instPkg "aws-blended-instances-advisor/instances"
+	"aws-blended-instances-advisor/utils"
+	"reflect"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+// createRegionsInfo creates a GlobalInfo in which each Region offers one
+// on-demand Instance at permanentPrice and one spot Instance with
+// transientRevocation, each with 4GB of memory and 2 vCPUs.
+func createRegionsInfo(permanentPrice map[awsTypes.Region]float64, transientRevocation map[awsTypes.Region]float64) instPkg.GlobalInfo {
+	permanent := make(map[awsTypes.Region][]*instPkg.Instance)
+	transient := make(map[awsTypes.Region][]*instPkg.Instance)
+	regions := []awsTypes.Region{}
+
+	for region, price := range permanentPrice {
+		permanent[region] = []*instPkg.Instance{
+			{Id: region.CodeString() + "-od", Name: "m5.large", MemoryGb: 4, Vcpu: 2, Region: region, PricePerHour: price},
+		}
+		transient[region] = []*instPkg.Instance{
+			{
+				Id: region.CodeString() + "-spot", Name: "m5.large", MemoryGb: 4, Vcpu: 2, Region: region,
+				PricePerHour: price / 2, RevocationProbability: transientRevocation[region],
+			},
+		}
+		regions = append(regions, region)
+	}
+	return instPkg.CreateGlobalInfo(permanent, transient, regions)
+}
+
+func countAllocations(allocations map[awsTypes.Region]*regionAllocation) map[awsTypes.Region][2]int {
+	counts := make(map[awsTypes.Region][2]int)
+	for region, allocation := range allocations {
+		counts[region] = [2]int{allocation.permanent, allocation.transient}
+	}
+	return counts
+}
+
+func TestAllocateServiceHonoursMinRegions(t *testing.T) {
+	regions := []awsTypes.Region{awsTypes.UsEast1, awsTypes.EuWest1}
+	info := createRegionsInfo(
+		map[awsTypes.Region]float64{awsTypes.UsEast1: 0.1, awsTypes.EuWest1: 0.5},
+		map[awsTypes.Region]float64{awsTypes.UsEast1: 0.1, awsTypes.EuWest1: 0.1},
+	)
+	advisor := NewWeightedAdvisor(schema.AdvisorWeights{Price: 1}).(WeightedAdvisor)
+
+	tests := map[string]struct {
+		minRegions int
+		expected   map[awsTypes.Region][2]int
+	}{
+		"cheapest region": {minRegions: 0, expected: map[awsTypes.Region][2]int{awsTypes.UsEast1: {2, 0}}},
+		"spread over two": {minRegions: 2, expected: map[awsTypes.Region][2]int{awsTypes.UsEast1: {1, 0}, awsTypes.EuWest1: {1, 0}}},
+	}
+
+	for name, test := range tests {
+		svc := schema.Service{Name: "web", MinMemory: 2, MaxVcpu: 2, MinInstances: 2, MaxInstances: 2, MinRegions: test.minRegions}
+		allocations, err := advisor.allocateService(info, regions, svc, schema.Options{}, zap.NewNop())
+		if err != nil {
+			t.Fatalf("Unexpected error for \"%s\": %s", name, err.Error())
+		}
+		if counts := countAllocations(allocations); !reflect.DeepEqual(counts, test.expected) {
+			t.Fatalf("Wrong allocations for \"%s\". Wanted: %v, got: %v", name, test.expected, counts)
+		}
+	}
+}
+
+func TestAllocateServiceTooFewRegions(t *testing.T) {
+	regions := []awsTypes.Region{awsTypes.UsEast1, awsTypes.EuWest1}
+	info := createRegionsInfo(
+		map[awsTypes.Region]float64{awsTypes.UsEast1: 0.1, awsTypes.EuWest1: 0.1},
+		map[awsTypes.Region]float64{},
+	)
+	advisor := NewWeightedAdvisor(schema.AdvisorWeights{Price: 1}).(WeightedAdvisor)
+
+	tests := map[string]schema.Service{
+		"more regions than given": {
+			Name: "web", MinMemory: 2, MaxVcpu: 2, MinInstances: 3, MaxInstances: 3, MinRegions: 3,
+		},
+		"placement allows one region": {
+			Name: "web", MinMemory: 2, MaxVcpu: 2, MinInstances: 2, MaxInstances: 2, MinRegions: 2,
+			Placement: &schema.Placement{Regions: []string{"eu-west-1"}},
+		},
+	}
+
+	for name, svc := range tests {
+		_, err := advisor.allocateService(info, regions, svc, schema.Options{}, zap.NewNop())
+		if err == nil {
+			t.Fatalf("Expected error for \"%s\"", name)
+		}
+	}
+}
+
+func TestAllocateServiceTooFewPermanentRegions(t *testing.T) {
+	regions := []awsTypes.Region{awsTypes.UsEast1, awsTypes.EuWest1}
+	info := createRegionsInfo(
+		map[awsTypes.Region]float64{awsTypes.UsEast1: 0.1, awsTypes.EuWest1: 0.1},
+		map[awsTypes.Region]float64{awsTypes.UsEast1: 0.1, awsTypes.EuWest1: 0.1},
+	)
+	// Only the spot instance in eu-west-1 has the 4 vCPUs reserved by the service
+	info.RegionInfoMap[awsTypes.UsEast1].PermanentInstances[0].Vcpu = 4
+	info.RegionInfoMap[awsTypes.UsEast1].TransientInstances[0].Vcpu = 4
+	info.RegionInfoMap[awsTypes.EuWest1].TransientInstances[0].Vcpu = 4
+	advisor := NewWeightedAdvisor(schema.AdvisorWeights{Price: 1}).(WeightedAdvisor)
+
+	tests := map[string]struct {
+		svc      schema.Service
+		isValid  bool
+		expected map[awsTypes.Region][2]int
+	}{
+		"permanent over two regions": {
+			svc:     schema.Service{Name: "web", MinMemory: 2, MinVcpu: 4, MaxVcpu: 4, MinInstances: 2, MaxInstances: 2, MinRegions: 2},
+			isValid: false,
+		},
+		"one permanent instance": {
+			svc:      schema.Service{Name: "web", MinMemory: 2, MinVcpu: 4, MaxVcpu: 4, MinInstances: 1, MaxInstances: 2, MinRegions: 2},
+			isValid:  true,
+			expected: map[awsTypes.Region][2]int{awsTypes.UsEast1: {1, 0}, awsTypes.EuWest1: {0, 1}},
+		},
+	}
+
+	for name, test := range tests {
+		allocations, err := advisor.allocateService(info, regions, test.svc, schema.Options{}, zap.NewNop())
+		if (err == nil) != test.isValid {
+			t.Fatalf("Wrong result for \"%s\". Wanted valid: %t, got error: %v", name, test.isValid, err)
+		}
+		if err != nil {
+			if !strings.Contains(err.Error(), "fewer than minRegions") {
+				t.Fatalf("Wrong error for \"%s\": %s", name, err.Error())
+			}
+			continue
+		}
+		if counts := countAllocations(allocations); !reflect.DeepEqual(counts, test.expected) {
+			t.Fatalf("Wrong allocations for \"%s\". Wanted: %v, got: %v", name, test.expected, counts)
+		}
+	}
+}
+
+func TestPlaceableRegions(t *testing.T) {
+	regions := []awsTypes.Region{awsTypes.UsEast1, awsTypes.EuWest1, awsTypes.EuWest2, awsTypes.EuCentral1}
+
+	tests := map[string]struct {
+		placement *schema.Placement
+		expected  []awsTypes.Region
+	}{
+		"no placement": {
+			placement: nil,
+			expected:  regions,
+		},
+		"residency": {
+			placement: &schema.Placement{Regions: []string{"eu-west-1", "eu-central-1"}},
+			expected:  []awsTypes.Region{awsTypes.EuWest1, awsTypes.EuCentral1},
+		},
+		"latency": {
+			placement: &schema.Placement{LatencyFrom: "eu-west-2", MaxLatencyMs: 20},
+			expected:  []awsTypes.Region{awsTypes.EuWest1, awsTypes.EuWest2, awsTypes.EuCentral1},
+		},
+		"residency and latency": {
+			placement: &schema.Placement{Regions: []string{"us-east-1", "eu-west-1"}, LatencyFrom: "eu-west-2", MaxLatencyMs: 20},
+			expected:  []awsTypes.Region{awsTypes.EuWest1},
+		},
+	}
+
+	for name, test := range tests {
+		svc := schema.Service{Name: "web", Placement: test.placement}
+		if placeable := placeableRegions(regions, svc); !reflect.DeepEqual(placeable, test.expected) {
+			t.Fatalf("Wrong regions for \"%s\". Wanted: %v, got: %v", name, test.expected, placeable)
+		}
+	}
+}
+
+func TestAllocateServiceSpreadsTransientInstances(t *testing.T) {
+	regions := []awsTypes.Region{awsTypes.UsEast1, awsTypes.EuWest1}
+	info := createRegionsInfo(
+		map[awsTypes.Region]float64{awsTypes.UsEast1: 0.4, awsTypes.EuWest1: 0.4},
+		map[awsTypes.Region]float64{awsTypes.UsEast1: 0.2, awsTypes.EuWest1: 0.2},
+	)
+	advisor := NewWeightedAdvisor(schema.AdvisorWeights{Price: 2, Availability: 1}).(WeightedAdvisor)
+	svc := schema.Service{Name: "web", MinMemory: 2, MaxVcpu: 2, MinInstances: 0, MaxInstances: 5}
+
+	allocations, err := advisor.allocateService(info, regions, svc, schema.Options{}, zap.NewNop())
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+
+	// Without the penalty for placed spot instances, every instance would
+	// be placed in the first region
+	expected := map[awsTypes.Region][2]int{awsTypes.UsEast1: {0, 3}, awsTypes.EuWest1: {0, 2}}
+	if counts := countAllocations(allocations); !reflect.DeepEqual(counts, expected) {
+		t.Fatalf("Wrong allocations. Wanted: %v, got: %v", expected, counts)
+	}
+}
+
+func TestPlacementCostGrowsWithPlacedTransient(t *testing.T) {
+	advisor := NewWeightedAdvisor(schema.AdvisorWeights{Price: 1, Availability: 1}).(WeightedAdvisor)
+	agg := instPkg.Aggregates{MinPricePerHour: 0, MaxPricePerHour: 1, MinRevocationProbability: 0, MaxRevocationProbability: 0.2}
+
+	spot := &instPkg.Instance{PricePerHour: 0.5, RevocationProbability: 0.1}
+	onDemand := &instPkg.Instance{PricePerHour: 0.5}
+
+	tests := []struct {
+		inst            *instPkg.Instance
+		placedTransient int
+		expected        float64
+	}{
+		{spot, 0, 1},
+		{spot, 1, 1.5},
+		{spot, 4, 3},
+		{onDemand, 4, 0.5},
+	}
+
+	for _, test := range tests {
+		cost := advisor.placementCost(test.inst, agg, test.placedTransient)
+		if !utils.FloatsEqual(cost, test.expected) {
+			t.Fatalf("Wrong cost with %d placed. Wanted: %f, got: %f", test.placedTransient, test.expected, cost)
+		}
+	}
+}
@@ -0,0 +1,19 @@
+package advisor
+
+import (
+	"aws-blended-instances-advisor/metrics"
+)
+
+var (
+	regionAdviceDuration = metrics.NewHistogramVec(
+		"advisor_region_duration_seconds",
+		"Time taken by an advisor to advise for a single region.",
+		metrics.DefaultBuckets,
+		"region", "advisor",
+	)
+	candidatePoolSize = metrics.NewGaugeVec(
+		"advisor_candidate_pool_size",
+		"Number of candidate instances available for the most recent advice in a region.",
+		"region", "market",
+	)
+)
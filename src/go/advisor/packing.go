@@ -0,0 +1,211 @@
+package advisor
+
+import (
+	"aws-blended-instances-advisor/api/schema"
+	instPkg "aws-blended-instances-advisor/instances"
+	"aws-blended-instances-advisor/utils"
+	"context"
+	"math"
+	"sort"
+
+	"go.uber.org/zap"
+)
+
+// A bin is a purchased Instance onto which Services' instances are packed.
+type bin struct {
+	inst     *instPkg.Instance
+	onDemand bool    // Whether inst was purchased on-demand rather than spot
+	memoryGb float64 // Reserved by assigned Services
+	vcpu     int     // Reserved by assigned Services
+	services map[string]bool
+}
+
+// A packingItem is one instance of a Service which needs a bin.
+type packingItem struct {
+	svc       schema.Service
+	permanent bool
+}
+
+// packServices selects Instances for each instance of each Service, placing
+// instances on already purchased Instances with enough unreserved memory and
+// vCPUs before purchasing more.
+//
+// Each instance reserves its Service's MinMemory and ReservedVcpu on the
+// Instance it is placed on, and a Service is never placed twice on one
+// Instance. Permanent instances are only placed on on-demand Instances. The
+// bin an instance is placed on is chosen by the Options' PackingStrategy.
+func (advisor WeightedAdvisor) packServices(
+	ctx context.Context,
+	info instPkg.RegionInfo,
+	permanentInstances []*instPkg.Instance,
+	allInstances []*instPkg.Instance,
+	services []schema.Service,
+	options schema.Options,
+	logger *zap.Logger,
+) (
+	*schema.RegionAdvice,
+	error,
+) {
+	items := []packingItem{}
+	for _, svc := range services {
+		for i := 0; i < svc.MaxInstances; i += 1 {
+			items = append(items, packingItem{svc: svc, permanent: i < svc.MinInstances})
+		}
+	}
+	if options.Packing != schema.BestFit {
+		sort.SliceStable(items, func(i, j int) bool {
+			return items[i].isLargerThan(items[j])
+		})
+	}
+
+	logger.Info(
+		"packing services onto shared instances",
+		zap.String("strategy", string(options.Packing)),
+		zap.Int("itemCount", len(items)),
+	)
+
+	onDemand := make(map[*instPkg.Instance]bool)
+	for _, inst := range permanentInstances {
+		onDemand[inst] = true
+	}
+
+	advice := &schema.RegionAdvice{}
+	bins := []*bin{}
+
+	for _, item := range items {
+		if err := ctx.Err(); err != nil {
+			return nil, utils.PrependToError(err, "advice cancelled")
+		}
+
+		target := findBin(bins, item, options.Packing)
+		if target == nil {
+			selectedInstance, err := advisor.selectInstanceForItem(info, permanentInstances, allInstances, item, options)
+			if err != nil {
+				return nil, err
+			}
+			if !item.permanent && options.AvoidRepeatedInstanceTypes {
+				allInstances = removeInstancesWithName(allInstances, selectedInstance.Name)
+			}
+
+			target = &bin{
+				inst:     purchaseInstance(selectedInstance),
+				onDemand: onDemand[selectedInstance],
+				services: make(map[string]bool),
+			}
+			bins = append(bins, target)
+			logger.Info(
+				"purchased instance for service",
+				zap.String("serviceName", item.svc.Name),
+				zap.Bool("permanent", item.permanent),
+				zap.Any("instance", target.inst),
+			)
+		} else {
+			logger.Info(
+				"packed service onto shared instance",
+				zap.String("serviceName", item.svc.Name),
+				zap.Bool("permanent", item.permanent),
+				zap.String("instanceId", target.inst.Id),
+			)
+		}
+
+		target.add(item.svc)
+		advice.AddAssignment(item.svc.Name, target.inst.ToApiSchemaInstance())
+	}
+
+	return advice, nil
+}
+
+// selectInstanceForItem selects a new Instance to purchase for a packingItem.
+func (advisor WeightedAdvisor) selectInstanceForItem(
+	info instPkg.RegionInfo,
+	permanentInstances []*instPkg.Instance,
+	allInstances []*instPkg.Instance,
+	item packingItem,
+	options schema.Options,
+) (*instPkg.Instance, error) {
+	if item.permanent {
+		return advisor.selectInstanceForService(permanentInstances, info.PermanentAggregates, item.svc, options)
+	}
+	return advisor.selectInstanceForService(allInstances, info.RegionAggregates, item.svc, options)
+}
+
+// findBin returns the bin a packingItem is placed on using a
+// PackingStrategy, or nil if no bin fits it.
+func findBin(bins []*bin, item packingItem, strategy schema.PackingStrategy) *bin {
+	var best *bin
+	bestSlack := math.Inf(1)
+	for _, b := range bins {
+		if !b.fits(item.svc) || (item.permanent && !b.onDemand) {
+			continue
+		}
+		if strategy != schema.BestFit {
+			return b
+		}
+		if slack := b.slackAfter(item.svc); slack < bestSlack {
+			best, bestSlack = b, slack
+		}
+	}
+	return best
+}
+
+func (b *bin) fits(svc schema.Service) bool {
+	return !b.services[svc.Name] &&
+		b.inst.MemoryGb-b.memoryGb >= svc.MinMemory &&
+		b.inst.Vcpu-b.vcpu >= svc.ReservedVcpu()
+}
+
+// slackAfter returns the fractions of a bin's memory and vCPUs which would
+// be left unreserved after a Service is placed on it, summed.
+func (b *bin) slackAfter(svc schema.Service) float64 {
+	memory := fraction(b.inst.MemoryGb-b.memoryGb-svc.MinMemory, b.inst.MemoryGb)
+	vcpu := fraction(float64(b.inst.Vcpu-b.vcpu-svc.ReservedVcpu()), float64(b.inst.Vcpu))
+	return memory + vcpu
+}
+
+func (b *bin) add(svc schema.Service) {
+	b.memoryGb += svc.MinMemory
+	b.vcpu += svc.ReservedVcpu()
+	b.services[svc.Name] = true
+}
+
+// isLargerThan orders packingItems by the memory and then the vCPUs
+// their Services reserve.
+func (item packingItem) isLargerThan(other packingItem) bool {
+	if item.svc.MinMemory != other.svc.MinMemory {
+		return item.svc.MinMemory > other.svc.MinMemory
+	}
+	return item.svc.ReservedVcpu() > other.svc.ReservedVcpu()
+}
+
+// calculateUtilisation returns the memory and vCPUs reserved on each
+// Instance of a RegionAdvice by the Services assigned to it.
+func calculateUtilisation(
+	advice *schema.RegionAdvice,
+	services []schema.Service,
+) map[string]schema.InstanceUtilisation {
+	servicesByName := make(map[string]schema.Service)
+	for _, svc := range services {
+		servicesByName[svc.Name] = svc
+	}
+
+	utilisation := make(map[string]schema.InstanceUtilisation)
+	for id, inst := range advice.Instances {
+		used := schema.InstanceUtilisation{}
+		for _, name := range advice.Assignments.InstancesToServices[id] {
+			svc := servicesByName[name]
+			used.MemoryGb += svc.MinMemory
+			used.Vcpu += svc.ReservedVcpu()
+		}
+		used.MemoryFraction = fraction(used.MemoryGb, inst.MemoryGb)
+		used.VcpuFraction = fraction(float64(used.Vcpu), float64(inst.Vcpu))
+		utilisation[id] = used
+	}
+	return utilisation
+}
+
+func fraction(value float64, total float64) float64 {
+	if total == 0 {
+		return 0
+	}
+	return value / total
+}
@@ -0,0 +1,215 @@
+package advisor
+
+import (
+	"aws-blended-instances-advisor/api/schema"
+	instPkg "aws-blended-instances-advisor/instances"
+	"context"
+	"reflect"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+func createBin(id string, memoryGb float64, vcpu int, spot bool, reservedMemoryGb float64, reservedVcpu int, services ...string) *bin {
+	inst := &instPkg.Instance{Id: id, MemoryGb: memoryGb, Vcpu: vcpu, PricePerHour: 0.1}
+	if spot {
+		inst.RevocationProbability = 0.1
+	}
+	b := &bin{inst: inst, onDemand: !spot, memoryGb: reservedMemoryGb, vcpu: reservedVcpu, services: make(map[string]bool)}
+	for _, name := range services {
+		b.services[name] = true
+	}
+	return b
+}
+
+func TestFindBin(t *testing.T) {
+	bins := []*bin{
+		createBin("roomy", 16, 4, false, 2, 1),
+		createBin("tight", 16, 4, false, 12, 2),
+		createBin("spot", 16, 4, true, 13, 2),
+		createBin("busy", 16, 4, false, 2, 3, "api"),
+		createBin("zero tier spot", 16, 4, true, 13, 2),
+	}
+	bins[4].inst.RevocationProbability = 0 // Still spot, though never expected to be revoked
+	svc := schema.Service{Name: "web", MinMemory: 2, MaxVcpu: 2}
+
+	tests := map[string]struct {
+		item     packingItem
+		strategy schema.PackingStrategy
+		expected string
+	}{
+		"first fit":                {packingItem{svc: svc, permanent: true}, schema.FirstFitDecreasing, "roomy"},
+		"default is first fit":     {packingItem{svc: svc, permanent: true}, "", "roomy"},
+		"best fit":                 {packingItem{svc: svc, permanent: true}, schema.BestFit, "tight"},
+		"best fit allows spot":     {packingItem{svc: svc, permanent: false}, schema.BestFit, "spot"},
+		"vcpu reserved":            {packingItem{svc: schema.Service{Name: "web", MinMemory: 2, MaxVcpu: 4, MinVcpu: 3}}, schema.BestFit, "roomy"},
+		"same service not twice":   {packingItem{svc: schema.Service{Name: "api", MinMemory: 2, MaxVcpu: 1}, permanent: true}, schema.BestFit, "tight"},
+		"nothing fits":             {packingItem{svc: schema.Service{Name: "web", MinMemory: 15, MaxVcpu: 1}}, schema.BestFit, ""},
+		"nothing has enough vcpus": {packingItem{svc: schema.Service{Name: "web", MinMemory: 1, MaxVcpu: 4, MinVcpu: 4}}, schema.FirstFitDecreasing, ""},
+	}
+
+	for name, test := range tests {
+		found := findBin(bins, test.item, test.strategy)
+		id := ""
+		if found != nil {
+			id = found.inst.Id
+		}
+		if id != test.expected {
+			t.Fatalf("Wrong bin for \"%s\". Wanted: \"%s\", got: \"%s\"", name, test.expected, id)
+		}
+	}
+}
+
+func TestPackServices(t *testing.T) {
+	info := instPkg.CreateRegionInfo(
+		[]*instPkg.Instance{{Id: "od", Name: "m5.xlarge", MemoryGb: 16, Vcpu: 4, PricePerHour: 0.2}},
+		[]*instPkg.Instance{{Id: "spot", Name: "m5.xlarge", MemoryGb: 16, Vcpu: 4, PricePerHour: 0.1, RevocationProbability: 0.1}},
+	)
+	advisor := NewWeightedAdvisor(schema.AdvisorWeights{Price: 1}).(WeightedAdvisor)
+
+	tests := map[string]struct {
+		services      []schema.Service
+		packing       schema.PackingStrategy
+		instanceCount int
+	}{
+		"memory and vcpus fit": {
+			services: []schema.Service{
+				{Name: "web", MinMemory: 4, MaxVcpu: 4, MinVcpu: 2, MinInstances: 1, MaxInstances: 1},
+				{Name: "cache", MinMemory: 4, MaxVcpu: 4, MinVcpu: 2, MinInstances: 1, MaxInstances: 1},
+			},
+			instanceCount: 1,
+		},
+		"vcpus do not fit": {
+			services: []schema.Service{
+				{Name: "web", MinMemory: 4, MaxVcpu: 4, MinVcpu: 3, MinInstances: 1, MaxInstances: 1},
+				{Name: "cache", MinMemory: 4, MaxVcpu: 4, MinVcpu: 2, MinInstances: 1, MaxInstances: 1},
+			},
+			instanceCount: 2,
+		},
+		"copies of a service are separate": {
+			services: []schema.Service{
+				{Name: "web", MinMemory: 1, MaxVcpu: 1, MinInstances: 3, MaxInstances: 3},
+			},
+			instanceCount: 3,
+		},
+	}
+
+	for name, test := range tests {
+		options := schema.Options{ShareInstancesBetweenServices: true, Packing: test.packing}
+		advice, err := advisor.AdviseForRegion(context.Background(), info, test.services, options, zap.NewNop())
+		if err != nil {
+			t.Fatalf("Unexpected error for \"%s\": %s", name, err.Error())
+		}
+
+		if len(advice.Instances) != test.instanceCount {
+			t.Fatalf("Wrong instance count for \"%s\". Wanted: %d, got: %d", name, test.instanceCount, len(advice.Instances))
+		}
+		for id, inst := range advice.Instances {
+			used := advice.Utilisation[id]
+			if used.Vcpu > inst.Vcpu || used.MemoryGb > inst.MemoryGb {
+				t.Fatalf("Instance over-reserved for \"%s\": %+v on %+v", name, used, inst)
+			}
+			if inst.IsSpot() && len(advice.Assignments.InstancesToServices[id]) > 0 {
+				for _, svcName := range advice.Assignments.InstancesToServices[id] {
+					for _, svc := range test.services {
+						if svc.Name == svcName && svc.MinInstances == svc.MaxInstances {
+							t.Fatalf("Permanent instance of %s placed on spot instance for \"%s\"", svcName, name)
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestPackServicesKeepsPermanentOffSpot(t *testing.T) {
+	info := instPkg.CreateRegionInfo(
+		[]*instPkg.Instance{{Id: "od", Name: "m5.xlarge", MemoryGb: 16, Vcpu: 4, PricePerHour: 0.2}},
+		[]*instPkg.Instance{{Id: "spot", Name: "c5.xlarge", MemoryGb: 16, Vcpu: 4, PricePerHour: 0.1}},
+	)
+	advisor := NewWeightedAdvisor(schema.AdvisorWeights{Price: 1}).(WeightedAdvisor)
+	services := []schema.Service{
+		{Name: "cache", MinMemory: 8, MaxVcpu: 1, MinInstances: 0, MaxInstances: 1},
+		{Name: "db", MinMemory: 4, MaxVcpu: 1, MinInstances: 1, MaxInstances: 1},
+	}
+
+	options := schema.Options{ShareInstancesBetweenServices: true}
+	advice, err := advisor.AdviseForRegion(context.Background(), info, services, options, zap.NewNop())
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+
+	for _, inst := range advice.GetAssignedInstancesForService("cache") {
+		if inst.Name != "c5.xlarge" {
+			t.Fatalf("Expected cache on the cheaper spot instance, got: %+v", inst)
+		}
+	}
+	for _, inst := range advice.GetAssignedInstancesForService("db") {
+		if inst.Name != "m5.xlarge" {
+			t.Fatalf("Permanent instance of db placed on spot instance with no revocation probability: %+v", inst)
+		}
+	}
+}
+
+func TestPackServicesStrategies(t *testing.T) {
+	info := instPkg.CreateRegionInfo(
+		[]*instPkg.Instance{{Id: "od", Name: "m5.xlarge", MemoryGb: 16, Vcpu: 4, PricePerHour: 0.2}},
+		[]*instPkg.Instance{},
+	)
+	advisor := NewWeightedAdvisor(schema.AdvisorWeights{Price: 1}).(WeightedAdvisor)
+	services := []schema.Service{
+		{Name: "cache", MinMemory: 2, MaxVcpu: 1, MinInstances: 1, MaxInstances: 1},
+		{Name: "db", MinMemory: 6, MaxVcpu: 1, MinInstances: 1, MaxInstances: 1},
+		{Name: "web", MinMemory: 8, MaxVcpu: 1, MinInstances: 1, MaxInstances: 1},
+		{Name: "api", MinMemory: 10, MaxVcpu: 1, MinInstances: 1, MaxInstances: 1},
+	}
+
+	tests := map[schema.PackingStrategy][]string{
+		// Largest first: api and db fill one instance, web and cache share another
+		schema.FirstFitDecreasing: {"api", "db"},
+		// Request order: cache, db and web fill one instance, leaving api alone
+		schema.BestFit: {"api"},
+	}
+
+	for strategy, expected := range tests {
+		options := schema.Options{ShareInstancesBetweenServices: true, Packing: strategy}
+		advice, err := advisor.AdviseForRegion(context.Background(), info, services, options, zap.NewNop())
+		if err != nil {
+			t.Fatalf("Unexpected error for %s: %s", strategy, err.Error())
+		}
+
+		apiInstance := advice.Assignments.ServicesToInstances["api"][0]
+		if got := advice.Assignments.InstancesToServices[apiInstance]; !reflect.DeepEqual(got, expected) {
+			t.Fatalf("Wrong services sharing with api for %s. Wanted: %v, got: %v", strategy, expected, got)
+		}
+		if len(advice.Instances) != 2 {
+			t.Fatalf("Wrong instance count for %s. Wanted: 2, got: %d", strategy, len(advice.Instances))
+		}
+	}
+}
+
+func TestCalculateUtilisation(t *testing.T) {
+	services := []schema.Service{
+		{Name: "web", MinMemory: 4, MinVcpu: 2},
+		{Name: "cache", MinMemory: 2},
+	}
+	advice := &schema.RegionAdvice{}
+	shared := &schema.Instance{Id: "shared", MemoryGb: 16, Vcpu: 4}
+	advice.AddAssignment("web", shared)
+	advice.AddAssignment("cache", shared)
+	advice.AddAssignment("cache", &schema.Instance{Id: "small", MemoryGb: 4, Vcpu: 2})
+
+	utilisation := calculateUtilisation(advice, services)
+
+	expected := map[string]schema.InstanceUtilisation{
+		"shared": {MemoryGb: 6, Vcpu: 3, MemoryFraction: 0.375, VcpuFraction: 0.75},
+		"small":  {MemoryGb: 2, Vcpu: 1, MemoryFraction: 0.5, VcpuFraction: 0.5},
+	}
+	for id, want := range expected {
+		got := utilisation[id]
+		if !floatsEqual(got.MemoryGb, want.MemoryGb) || got.Vcpu != want.Vcpu ||
+			!floatsEqual(got.MemoryFraction, want.MemoryFraction) || !floatsEqual(got.VcpuFraction, want.VcpuFraction) {
+			t.Fatalf("Wrong utilisation for %s. Wanted: %+v, got: %+v", id, want, got)
+		}
+	}
+}
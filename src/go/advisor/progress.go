@@ -0,0 +1,27 @@
+package advisor
+
+import (
+	"aws-blended-instances-advisor/api/schema"
+)
+
+// A RegionProgress describes the outcome of advising for a single Region,
+// along with how far through the requested Regions an Advisor is.
+type RegionProgress struct {
+	Region    string
+	Advice    *schema.RegionAdvice
+	Err       error
+	Completed int
+	Total     int
+}
+
+// A ProgressHandler is called by an Advisor each time it finishes advising
+// for a Region, whether successfully or not.
+//
+// A nil ProgressHandler may be given to an Advisor when progress is not needed.
+type ProgressHandler func(progress RegionProgress)
+
+func (handler ProgressHandler) report(progress RegionProgress) {
+	if handler != nil {
+		handler(progress)
+	}
+}
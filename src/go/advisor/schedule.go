@@ -0,0 +1,138 @@
+package advisor
+
+import (
+	"aws-blended-instances-advisor/api/schema"
+	"fmt"
+)
+
+// The time zone of ScheduledActions' recurrences, in which LoadProfiles' buckets start
+const SCHEDULE_TIME_ZONE = "Etc/UTC"
+
+// Day names used in the names of weekly ScheduledActions, from Monday
+var WEEKDAY_NAMES = []string{"mon", "tue", "wed", "thu", "fri", "sat", "sun"}
+
+// servicesWithLoadProfiles returns copies of Services with their
+// MinInstances and MaxInstances taken from their LoadProfiles.
+func servicesWithLoadProfiles(services []schema.Service) []schema.Service {
+	profiled := make([]schema.Service, len(services))
+	for i := range services {
+		profiled[i] = *services[i].WithLoadProfile()
+	}
+	return profiled
+}
+
+// scheduleRegionAdvice adds a ServiceSchedule to a RegionAdvice for each
+// Service with a LoadProfile, and projects the cost of running instances
+// only when they are scheduled.
+//
+// Each Service's first MinInstances assigned instances are its baseline,
+// and its remaining instances are scheduled in order of assignment.
+// Instances shared between Services run whenever any of them needs it.
+func scheduleRegionAdvice(advice *schema.RegionAdvice, services []schema.Service) {
+	var period schema.ProfilePeriod
+	for _, svc := range services {
+		if svc.LoadProfile == nil {
+			continue
+		}
+		if advice.Schedules == nil {
+			advice.Schedules = make(map[string]schema.ServiceSchedule)
+		}
+		advice.Schedules[svc.Name] = scheduleService(svc)
+
+		if period != schema.WeeklyProfile {
+			period = svc.LoadProfile.Period
+		}
+	}
+	if period == "" {
+		return
+	}
+
+	hours := period.Hours()
+	running := make(map[string][]bool) // Instance ID to whether it runs in each hour
+	for _, svc := range services {
+		for index, id := range advice.Assignments.ServicesToInstances[svc.Name] {
+			if _, ok := running[id]; !ok {
+				running[id] = make([]bool, hours)
+			}
+			for hour := 0; hour < hours; hour += 1 {
+				if svc.LoadProfile == nil || index < svc.MinInstances {
+					running[id][hour] = true
+					continue
+				}
+				scheduled := advice.Schedules[svc.Name].Scheduled
+				if index-svc.MinInstances < scheduled[hour%len(scheduled)] {
+					running[id][hour] = true
+				}
+			}
+		}
+	}
+
+	projection := &schema.CostProjection{Period: period}
+	for hour := 0; hour < hours; hour += 1 {
+		pricePerHour := 0.0
+		for id, runs := range running {
+			if runs[hour] {
+				pricePerHour += advice.Instances[id].PricePerHour
+			}
+		}
+		projection.PricePerPeriod += pricePerHour
+		if pricePerHour > projection.PeakPricePerHour {
+			projection.PeakPricePerHour = pricePerHour
+		}
+	}
+	projection.AveragePricePerHour = projection.PricePerPeriod / float64(hours)
+	advice.Projection = projection
+}
+
+// scheduleService creates the ServiceSchedule for a Service with a
+// LoadProfile, whose MinInstances is the profile's baseline.
+func scheduleService(svc schema.Service) schema.ServiceSchedule {
+	profile := svc.LoadProfile
+	schedule := schema.ServiceSchedule{
+		Period:    profile.Period,
+		Baseline:  svc.MinInstances,
+		Scheduled: make([]int, len(profile.Instances)),
+		Actions:   []schema.ScheduledAction{},
+	}
+	for bucket, count := range profile.Instances {
+		schedule.Scheduled[bucket] = count - svc.MinInstances
+	}
+
+	peak := svc.MaxInstances - svc.MinInstances
+	for bucket, count := range schedule.Scheduled {
+		previous := schedule.Scheduled[(bucket+len(schedule.Scheduled)-1)%len(schedule.Scheduled)]
+		if count == previous {
+			continue
+		}
+		schedule.Actions = append(schedule.Actions, schema.ScheduledAction{
+			Name:            fmt.Sprintf("%s-%s", svc.Name, bucketName(profile.Period, bucket)),
+			Recurrence:      bucketRecurrence(profile.Period, bucket),
+			TimeZone:        SCHEDULE_TIME_ZONE,
+			MinSize:         count,
+			MaxSize:         peak,
+			DesiredCapacity: count,
+		})
+	}
+	return schedule
+}
+
+// bucketName returns a name for the start of an hourly bucket, e.g.
+// "0900" or "mon-0900".
+func bucketName(period schema.ProfilePeriod, bucket int) string {
+	hour := fmt.Sprintf("%02d00", bucket%schema.HOURS_PER_DAY)
+	if period == schema.WeeklyProfile {
+		return WEEKDAY_NAMES[bucket/schema.HOURS_PER_DAY] + "-" + hour
+	}
+	return hour
+}
+
+// bucketRecurrence returns a cron expression for the start of an hourly
+// bucket, which recurs every period.
+func bucketRecurrence(period schema.ProfilePeriod, bucket int) string {
+	hour := bucket % schema.HOURS_PER_DAY
+	if period == schema.WeeklyProfile {
+		weekday := (bucket/schema.HOURS_PER_DAY + 1) % 7 // Cron weeks start on Sunday
+		return fmt.Sprintf("0 %d * * %d", hour, weekday)
+	}
+	return fmt.Sprintf("0 %d * * *", hour)
+}
@@ -0,0 +1,172 @@
+package advisor
+
+import (
+	"aws-blended-instances-advisor/api/schema"
+	instPkg "aws-blended-instances-advisor/instances"
+	"context"
+	"math"
+	"reflect"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+func createDailyProfile(counts map[int]int, otherwise int) *schema.LoadProfile {
+	profile := &schema.LoadProfile{Period: schema.DailyProfile, Instances: make([]int, 24)}
+	for hour := range profile.Instances {
+		profile.Instances[hour] = otherwise
+		if count, ok := counts[hour]; ok {
+			profile.Instances[hour] = count
+		}
+	}
+	return profile
+}
+
+func floatsEqual(a float64, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+func TestScheduleService(t *testing.T) {
+	svc := schema.Service{
+		Name:        "web",
+		LoadProfile: createDailyProfile(map[int]int{9: 4, 10: 4, 11: 6}, 2),
+	}
+	profiled := *svc.WithLoadProfile()
+	if profiled.MinInstances != 2 || profiled.MaxInstances != 6 {
+		t.Fatalf("Wrong bounds. Wanted: 2 and 6, got: %d and %d", profiled.MinInstances, profiled.MaxInstances)
+	}
+
+	schedule := scheduleService(profiled)
+	if schedule.Baseline != 2 {
+		t.Fatalf("Wrong baseline. Wanted: 2, got: %d", schedule.Baseline)
+	}
+	if schedule.Scheduled[8] != 0 || schedule.Scheduled[9] != 2 || schedule.Scheduled[11] != 4 || schedule.Scheduled[12] != 0 {
+		t.Fatalf("Wrong scheduled instances: %v", schedule.Scheduled)
+	}
+
+	expected := []schema.ScheduledAction{
+		{Name: "web-0900", Recurrence: "0 9 * * *", TimeZone: SCHEDULE_TIME_ZONE, MinSize: 2, MaxSize: 4, DesiredCapacity: 2},
+		{Name: "web-1100", Recurrence: "0 11 * * *", TimeZone: SCHEDULE_TIME_ZONE, MinSize: 4, MaxSize: 4, DesiredCapacity: 4},
+		{Name: "web-1200", Recurrence: "0 12 * * *", TimeZone: SCHEDULE_TIME_ZONE, MinSize: 0, MaxSize: 4, DesiredCapacity: 0},
+	}
+	if !reflect.DeepEqual(schedule.Actions, expected) {
+		t.Fatalf("Wrong actions.\nWanted: %+v\nGot: %+v", expected, schedule.Actions)
+	}
+	for _, action := range schedule.Actions {
+		if action.MinSize > action.DesiredCapacity || action.DesiredCapacity > action.MaxSize {
+			t.Fatalf("Action breaks minSize <= desiredCapacity <= maxSize: %+v", action)
+		}
+	}
+}
+
+func TestScheduleServiceConstantProfile(t *testing.T) {
+	svc := schema.Service{Name: "web", LoadProfile: createDailyProfile(nil, 3)}
+	schedule := scheduleService(*svc.WithLoadProfile())
+	if schedule.Baseline != 3 || len(schedule.Actions) != 0 {
+		t.Fatalf("Expected baseline of 3 and no actions, got: %+v", schedule)
+	}
+}
+
+func TestBucketRecurrence(t *testing.T) {
+	tests := []struct {
+		period     schema.ProfilePeriod
+		bucket     int
+		name       string
+		recurrence string
+	}{
+		{schema.DailyProfile, 0, "0000", "0 0 * * *"},
+		{schema.DailyProfile, 23, "2300", "0 23 * * *"},
+		{schema.WeeklyProfile, 9, "mon-0900", "0 9 * * 1"},
+		{schema.WeeklyProfile, 5*24 + 18, "sat-1800", "0 18 * * 6"},
+		{schema.WeeklyProfile, 6*24 + 1, "sun-0100", "0 1 * * 0"},
+	}
+
+	for _, test := range tests {
+		if name := bucketName(test.period, test.bucket); name != test.name {
+			t.Errorf("Wrong name for bucket %d. Wanted: %s, got: %s", test.bucket, test.name, name)
+		}
+		if recurrence := bucketRecurrence(test.period, test.bucket); recurrence != test.recurrence {
+			t.Errorf("Wrong recurrence for bucket %d. Wanted: %s, got: %s", test.bucket, test.recurrence, recurrence)
+		}
+	}
+}
+
+func TestScheduleRegionAdvice(t *testing.T) {
+	services := servicesWithLoadProfiles([]schema.Service{
+		{Name: "web", LoadProfile: createDailyProfile(map[int]int{12: 2}, 1)},
+		{Name: "db", MinInstances: 1, MaxInstances: 1},
+	})
+
+	advice := &schema.RegionAdvice{}
+	advice.AddAssignment("web", &schema.Instance{Id: "web-0", PricePerHour: 1})
+	advice.AddAssignment("web", &schema.Instance{Id: "web-1", PricePerHour: 2})
+	advice.AddAssignment("db", &schema.Instance{Id: "db-0", PricePerHour: 4})
+
+	scheduleRegionAdvice(advice, services)
+
+	if _, ok := advice.Schedules["db"]; ok {
+		t.Fatalf("Expected no schedule for service without profile")
+	}
+	if advice.Schedules["web"].Baseline != 1 {
+		t.Fatalf("Wrong baseline. Wanted: 1, got: %d", advice.Schedules["web"].Baseline)
+	}
+
+	projection := advice.Projection
+	if projection == nil || projection.Period != schema.DailyProfile {
+		t.Fatalf("Expected daily projection, got: %+v", projection)
+	}
+	expectedPerPeriod := 24*(1+4) + 2.0
+	if !floatsEqual(projection.PricePerPeriod, expectedPerPeriod) {
+		t.Fatalf("Wrong price per period. Wanted: %f, got: %f", expectedPerPeriod, projection.PricePerPeriod)
+	}
+	if !floatsEqual(projection.PeakPricePerHour, 7) {
+		t.Fatalf("Wrong peak price. Wanted: 7, got: %f", projection.PeakPricePerHour)
+	}
+	if !floatsEqual(projection.AveragePricePerHour, expectedPerPeriod/24) {
+		t.Fatalf("Wrong average price. Wanted: %f, got: %f", expectedPerPeriod/24, projection.AveragePricePerHour)
+	}
+}
+
+func TestScheduleRegionAdviceSharedInstance(t *testing.T) {
+	workerProfile := &schema.LoadProfile{Period: schema.WeeklyProfile, Instances: make([]int, 168)}
+	workerProfile.Instances[24] = 1
+	services := servicesWithLoadProfiles([]schema.Service{
+		{Name: "web", LoadProfile: createDailyProfile(map[int]int{0: 0}, 1)},
+		{Name: "worker", LoadProfile: workerProfile},
+	})
+
+	shared := &schema.Instance{Id: "shared", PricePerHour: 1}
+	advice := &schema.RegionAdvice{}
+	advice.AddAssignment("web", shared)
+	advice.AddAssignment("worker", shared)
+
+	scheduleRegionAdvice(advice, services)
+
+	projection := advice.Projection
+	if projection.Period != schema.WeeklyProfile {
+		t.Fatalf("Expected weekly projection with a weekly profile, got: %s", projection.Period)
+	}
+	// Runs for web in 23 hours of every day, and for worker at hour 0 on Tuesday
+	expectedPerPeriod := 7*23 + 1.0
+	if !floatsEqual(projection.PricePerPeriod, expectedPerPeriod) {
+		t.Fatalf("Wrong price per period. Wanted: %f, got: %f", expectedPerPeriod, projection.PricePerPeriod)
+	}
+}
+
+func TestAdviseAcrossRegionsRejectsLoadProfiles(t *testing.T) {
+	advisor := NewWeightedAdvisor(schema.AdvisorWeights{Price: 1}).(WeightedAdvisor)
+	services := []schema.Service{{Name: "web", LoadProfile: createDailyProfile(nil, 1)}}
+
+	_, err := advisor.adviseAcrossRegions(
+		context.Background(),
+		instPkg.GlobalInfo{},
+		nil,
+		services,
+		schema.Options{CrossRegion: true},
+		nil,
+		zap.NewNop(),
+	)
+	if err == nil {
+		t.Fatalf("Expected error for load profile across regions")
+	}
+}
@@ -4,10 +4,14 @@ import (
 	"aws-blended-instances-advisor/api/schema"
 	awsTypes "aws-blended-instances-advisor/aws/types"
 	instPkg "aws-blended-instances-advisor/instances"
+	"aws-blended-instances-advisor/instances/filter"
 	instSearch "aws-blended-instances-advisor/instances/search"
 	instSort "aws-blended-instances-advisor/instances/sort"
+	"aws-blended-instances-advisor/ranking"
 	"aws-blended-instances-advisor/utils"
+	"context"
 	"fmt"
+	"time"
 
 	"go.uber.org/zap"
 )
@@ -29,10 +33,15 @@ func NewWeightedAdvisor(weights schema.AdvisorWeights) Advisor {
 // Advicse selects and scores Instances from a group of available
 // Instances for all Regions, returning the selection and information as an
 // Advice.
+//
+// Advising stops with an error once ctx is done, and onProgress is called
+// as each Region is completed.
 func (advisor WeightedAdvisor) Advise(
+	ctx context.Context,
 	instancesInfo instPkg.GlobalInfo,
 	services []schema.Service,
 	options schema.Options,
+	onProgress ProgressHandler,
 	logger *zap.Logger,
 ) (
 	*schema.Advice,
@@ -43,30 +52,49 @@ func (advisor WeightedAdvisor) Advise(
 		zap.Any("weights", advisor.weights),
 	)
 
-	advice := make(schema.Advice)
+	services = servicesWithLoadProfiles(services)
 
-	awsRegions, err := awsTypes.NewRegions(options.Regions)
+	awsRegions, err := candidateRegions(instancesInfo, options)
 	if err != nil {
 		return nil, utils.PrependToError(err, "could not parse regions")
 	}
 
-	for _, region := range awsRegions {
+	if options.CrossRegion {
+		advice, err := advisor.adviseAcrossRegions(ctx, instancesInfo, awsRegions, services, options, onProgress, logger)
+		if err != nil {
+			return nil, err
+		}
+		ranking.Rank(advice, options.RankBy)
+		return advice, nil
+	}
+
+	advice := make(schema.Advice)
+
+	for index, region := range awsRegions {
+		if err := ctx.Err(); err != nil {
+			return nil, utils.PrependToError(err, "advice cancelled")
+		}
+
 		logger.Info("advising for region", zap.String("region", region.CodeString()))
 
-		info, ok := instancesInfo.RegionInfoMap[region]
-		if !ok {
-			return nil, fmt.Errorf("region not in map: %s", region.CodeString())
+		progress := RegionProgress{
+			Region:    region.CodeString(),
+			Completed: index + 1,
+			Total:     len(awsRegions),
 		}
 
-		regionAdvice, err := advisor.AdviseForRegion(info, services, options, logger)
+		regionAdvice, err := advisor.adviseAndScoreRegion(ctx, instancesInfo, region, services, options, logger)
 		if err != nil {
+			progress.Err = err
+			onProgress.report(progress)
 			return nil, err
 		}
 
-		regionAdvice.Score = advisor.ScoreRegionAdvice(regionAdvice, instancesInfo.GlobalAggregates, services, logger)
-
 		advice[region.CodeString()] = *regionAdvice
 
+		progress.Advice = regionAdvice
+		onProgress.report(progress)
+
 		logger.Info(
 			"advice created for region",
 			zap.String("region", region.CodeString()),
@@ -74,13 +102,69 @@ func (advisor WeightedAdvisor) Advise(
 		)
 	}
 
+	ranking.Rank(&advice, options.RankBy)
 	return &advice, nil
 }
 
+// candidateRegions returns the Regions advice is requested for. When only
+// geographies are requested, Regions without instance information are
+// skipped rather than treated as errors.
+func candidateRegions(instancesInfo instPkg.GlobalInfo, options schema.Options) ([]awsTypes.Region, error) {
+	regions, err := options.GetRegionsAsAwsRegions()
+	if err != nil || len(options.Regions) > 0 {
+		return regions, err
+	}
+
+	available := []awsTypes.Region{}
+	for _, region := range regions {
+		if _, ok := instancesInfo.RegionInfoMap[region]; ok {
+			available = append(available, region)
+		}
+	}
+	return available, nil
+}
+
+// adviseAndScoreRegion advises for one Region of a GlobalInfo, scoring the
+// resulting RegionAdvice against the global aggregates.
+func (advisor WeightedAdvisor) adviseAndScoreRegion(
+	ctx context.Context,
+	instancesInfo instPkg.GlobalInfo,
+	region awsTypes.Region,
+	services []schema.Service,
+	options schema.Options,
+	logger *zap.Logger,
+) (
+	*schema.RegionAdvice,
+	error,
+) {
+	info, ok := instancesInfo.RegionInfoMap[region]
+	if !ok {
+		return nil, fmt.Errorf("region not in map: %s", region.CodeString())
+	}
+
+	candidatePoolSize.Set(float64(len(info.PermanentInstances)), region.CodeString(), "onDemand")
+	candidatePoolSize.Set(float64(len(info.TransientInstances)), region.CodeString(), "spot")
+
+	start := time.Now()
+	regionAdvice, err := advisor.AdviseForRegion(ctx, info, services, options, logger)
+	regionAdviceDuration.Observe(time.Since(start).Seconds(), region.CodeString(), string(schema.Weighted))
+	if err != nil {
+		return nil, err
+	}
+
+	regionAdvice.Score = advisor.ScoreRegionAdvice(regionAdvice, instancesInfo.GlobalAggregates, services, logger)
+	scheduleRegionAdvice(regionAdvice, services)
+	return regionAdvice, nil
+}
+
 // AdviseForRegion selects and scores Instances from a group of available
 // Instances for one Region, returning the selection and information as a
 // RegionAdvice.
+//
+// When Instances are shared between Services, each Service's instances are
+// packed onto purchased Instances as described by packServices.
 func (advisor WeightedAdvisor) AdviseForRegion(
+	ctx context.Context,
 	info instPkg.RegionInfo,
 	services []schema.Service,
 	options schema.Options,
@@ -94,33 +178,30 @@ func (advisor WeightedAdvisor) AdviseForRegion(
 		zap.Any("weights", advisor.weights),
 	)
 
-	permanentInstances := copyInstances(info.PermanentInstances)
-	transientInstances := copyInstances(info.TransientInstances)
-	logger.Info(
-		"copy of instances made",
-		zap.Int("permanentInstancesCount", len(permanentInstances)),
-		zap.Int("transientInstancesCount", len(transientInstances)),
-	)
-
-	if !options.ConsiderFreeInstances {
-		permanentInstances = removeFreeInstances(permanentInstances)
-		transientInstances = removeFreeInstances(transientInstances)
-		logger.Info(
-			"removed free instances",
-			zap.Int("remainingPermanentInstances", len(permanentInstances)),
-			zap.Int("remainingTransientInstances", len(transientInstances)),
-		)
-	}
+	permanentInstances, transientInstances := candidateInstances(info, options, logger)
 
-	allInstances := append(permanentInstances, transientInstances...)
+	allInstances := append(copyInstances(permanentInstances), transientInstances...)
 	logger.Debug(
 		"joined transient and permanent instances",
 		zap.Int("totalInstanceCount", len(allInstances)),
 	)
 
+	if options.ShareInstancesBetweenServices {
+		advice, err := advisor.packServices(ctx, info, permanentInstances, allInstances, services, options, logger)
+		if err != nil {
+			return nil, err
+		}
+		advice.Utilisation = calculateUtilisation(advice, services)
+		return advice, nil
+	}
+
 	advice := &schema.RegionAdvice{}
 
 	for _, svc := range services {
+		if err := ctx.Err(); err != nil {
+			return nil, utils.PrependToError(err, "advice cancelled")
+		}
+
 		permanentCount := svc.MinInstances
 		transientCount := svc.MaxInstances - svc.MinInstances
 
@@ -146,6 +227,7 @@ func (advisor WeightedAdvisor) AdviseForRegion(
 			if err != nil {
 				return nil, err
 			}
+			selectedInstance = purchaseInstance(selectedInstance)
 
 			advice.AddAssignment(svc.Name, selectedInstance.ToApiSchemaInstance())
 			logger.Info(
@@ -153,16 +235,6 @@ func (advisor WeightedAdvisor) AdviseForRegion(
 				zap.String("serviceName", svc.Name),
 				zap.Any("instance", selectedInstance),
 			)
-
-			if options.ShareInstancesBetweenServices {
-				sharedInstance := createSharedInstance(selectedInstance, svc)
-				permanentInstances = append(permanentInstances, sharedInstance)
-				logger.Info(
-					"added shared instance to available instances",
-					zap.String("originalInstanceId", selectedInstance.Id),
-					zap.Any("newSharedInstance", sharedInstance),
-				)
-			}
 		}
 
 		for i := 0; i < transientCount; i += 1 {
@@ -180,10 +252,11 @@ func (advisor WeightedAdvisor) AdviseForRegion(
 			if err != nil {
 				return nil, err
 			}
+			selectedInstance = purchaseInstance(selectedInstance)
 
 			advice.AddAssignment(svc.Name, selectedInstance.ToApiSchemaInstance())
 			logger.Info(
-				"selected and assigned permanent instance for service",
+				"selected and assigned transient instance for service",
 				zap.String("serviceName", svc.Name),
 				zap.Any("instance", selectedInstance),
 			)
@@ -196,28 +269,54 @@ func (advisor WeightedAdvisor) AdviseForRegion(
 					zap.String("nameRemoved", selectedInstance.Name),
 				)
 			}
+		}
+	}
 
-			if options.ShareInstancesBetweenServices {
-				sharedInstance := createSharedInstance(selectedInstance, svc)
+	advice.Utilisation = calculateUtilisation(advice, services)
+	return advice, nil
+}
 
-				allInstances = append(allInstances, sharedInstance)
-				if isPermanentInstance(selectedInstance) {
-					permanentInstances = append(
-						permanentInstances,
-						createSharedInstance(selectedInstance, svc),
-					)
-				}
+// candidateInstances returns copies of a RegionInfo's permanent and
+// transient Instances which can be selected with the given Options.
+func candidateInstances(
+	info instPkg.RegionInfo,
+	options schema.Options,
+	logger *zap.Logger,
+) (
+	permanentInstances []*instPkg.Instance,
+	transientInstances []*instPkg.Instance,
+) {
+	permanentInstances = copyInstances(info.PermanentInstances)
+	transientInstances = copyInstances(info.TransientInstances)
+	logger.Info(
+		"copy of instances made",
+		zap.Int("permanentInstancesCount", len(permanentInstances)),
+		zap.Int("transientInstancesCount", len(transientInstances)),
+	)
 
-				logger.Info(
-					"added shared instance to available instances",
-					zap.String("originalInstanceId", selectedInstance.Id),
-					zap.Any("newSharedInstance", sharedInstance),
-				)
-			}
-		}
+	if !options.ConsiderFreeInstances {
+		permanentInstances = removeFreeInstances(permanentInstances)
+		transientInstances = removeFreeInstances(transientInstances)
+		logger.Info(
+			"removed free instances",
+			zap.Int("remainingPermanentInstances", len(permanentInstances)),
+			zap.Int("remainingTransientInstances", len(transientInstances)),
+		)
 	}
 
-	return advice, nil
+	if options.Filter != (schema.InstanceFilter{}) {
+		instanceFilter := newInstanceFilter(options.Filter)
+		permanentInstances = instanceFilter.Apply(permanentInstances)
+		transientInstances = instanceFilter.Apply(transientInstances)
+		logger.Info(
+			"filtered instances",
+			zap.Any("filter", options.Filter),
+			zap.Int("remainingPermanentInstances", len(permanentInstances)),
+			zap.Int("remainingTransientInstances", len(transientInstances)),
+		)
+	}
+
+	return permanentInstances, transientInstances
 }
 
 func copyInstances(instances []*instPkg.Instance) []*instPkg.Instance {
@@ -235,6 +334,18 @@ func removeFreeInstances(instances []*instPkg.Instance) []*instPkg.Instance {
 	return filtered
 }
 
+// newInstanceFilter converts an API schema InstanceFilter into a Filter.
+func newInstanceFilter(f schema.InstanceFilter) filter.Filter {
+	return filter.Filter{
+		OperatingSystem: f.OperatingSystem,
+		Family:          f.Family,
+		MinMemory:       f.MinMemory,
+		MinVcpu:         f.MinVcpu,
+		MaxVcpu:         f.MaxVcpu,
+		MaxPrice:        f.MaxPrice,
+	}
+}
+
 func removeInstancesWithName(
 	instances []*instPkg.Instance,
 	name string,
@@ -248,15 +359,12 @@ func removeInstancesWithName(
 	return filtered
 }
 
-func isPermanentInstance(inst *instPkg.Instance) bool {
-	return inst.PricePerHour == 0
-}
-
-func createSharedInstance(inst *instPkg.Instance, assignedService schema.Service) *instPkg.Instance {
-	instanceToShare := inst.MakeCopy()
-	instanceToShare.MemoryGb = inst.MemoryGb - assignedService.MinMemory
-	instanceToShare.PricePerHour = 0 // Already purchased, so no cost
-	return instanceToShare
+// purchaseInstance returns a copy of an offered Instance with its own ID, so
+// that each purchase of the same offering appears separately in advice.
+func purchaseInstance(inst *instPkg.Instance) *instPkg.Instance {
+	purchased := inst.MakeCopy()
+	purchased.Id = utils.GenerateUuid()
+	return purchased
 }
 
 func (advisor WeightedAdvisor) selectInstanceForService(
@@ -265,6 +373,10 @@ func (advisor WeightedAdvisor) selectInstanceForService(
 	svc schema.Service,
 	options schema.Options,
 ) (*instPkg.Instance, error) {
+	if vcpu := svc.ReservedVcpu(); vcpu > 1 {
+		instances = filter.Filter{MinVcpu: vcpu}.Apply(instances)
+	}
+
 	searchStart, searchEnd := 0, len(instances)
 	var err error
 
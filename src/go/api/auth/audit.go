@@ -0,0 +1,41 @@
+package auth
+
+import (
+	"aws-blended-instances-advisor/api/schema"
+	"context"
+
+	"go.uber.org/zap"
+)
+
+// LogAudit records which API key requested advice, and for what, using the
+// API key name held in ctx. req may be nil for requests without advice.
+func LogAudit(
+	ctx context.Context,
+	remoteAddr string,
+	reqId string,
+	action string,
+	req *schema.AdviseRequest,
+	logger *zap.Logger,
+) {
+	fields := []zap.Field{
+		zap.String("requestId", reqId),
+		zap.String("action", action),
+		zap.String("apiKey", KeyNameFromContext(ctx)),
+		zap.String("remoteAddr", remoteAddr),
+	}
+	if req != nil {
+		serviceNames := []string{}
+		for _, svc := range req.Services {
+			serviceNames = append(serviceNames, svc.Name)
+		}
+		fields = append(
+			fields,
+			zap.Strings("regions", req.Options.GetRegionCodes()),
+			zap.Strings("services", serviceNames),
+			zap.String("advisor", string(req.Advisor.Type)),
+			zap.String("preset", req.Preset),
+		)
+	}
+
+	logger.Info("audit", fields...)
+}
@@ -0,0 +1,210 @@
+package auth
+
+import (
+	"aws-blended-instances-advisor/config"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"math"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	API_KEY_HEADER = "X-Api-Key"
+)
+
+var (
+	// ErrMissingKey is returned when a request does not provide an API key.
+	ErrMissingKey = errors.New("API key not provided")
+
+	// ErrInvalidKey is returned when a request provides an unknown API key.
+	ErrInvalidKey = errors.New("API key is invalid")
+
+	// ErrRateLimited is returned when a key has made too many requests recently.
+	ErrRateLimited = errors.New("rate limit exceeded for API key")
+
+	// ErrQuotaExceeded is returned when a key has used its daily quota.
+	ErrQuotaExceeded = errors.New("daily quota exceeded for API key")
+)
+
+// Reasons a request is rejected, which label metrics in place of error
+// messages so that the number of label values stays fixed.
+const (
+	REASON_MISSING        = "missing"
+	REASON_INVALID        = "invalid"
+	REASON_RATE_LIMITED   = "rate_limited"
+	REASON_QUOTA_EXCEEDED = "quota_exceeded"
+	REASON_OTHER          = "other"
+)
+
+// FailureReason returns the reason an error returned by an Authenticator
+// rejected a request, or REASON_OTHER if it is not a known error.
+func FailureReason(err error) string {
+	switch {
+	case errors.Is(err, ErrMissingKey):
+		return REASON_MISSING
+	case errors.Is(err, ErrInvalidKey):
+		return REASON_INVALID
+	case errors.Is(err, ErrRateLimited):
+		return REASON_RATE_LIMITED
+	case errors.Is(err, ErrQuotaExceeded):
+		return REASON_QUOTA_EXCEEDED
+	default:
+		return REASON_OTHER
+	}
+}
+
+type contextKey struct{}
+
+// An Authenticator checks API keys provided with requests, and enforces each
+// key's rate limit and daily quota.
+//
+// An Authenticator is safe for concurrent use.
+type Authenticator struct {
+	mu      sync.Mutex
+	enabled bool
+	keys    map[string]*key // Hex-encoded hash to key
+	now     func() time.Time
+}
+
+type key struct {
+	name string
+
+	ratePerSecond float64
+	burst         float64
+	tokens        float64
+	lastRefill    time.Time
+
+	dailyQuota int
+	quotaDay   time.Time
+	quotaUsed  int
+}
+
+// New creates an Authenticator from an AuthConfig.
+func New(cfg *config.AuthConfig) *Authenticator {
+	a := &Authenticator{
+		enabled: cfg.Enabled,
+		keys:    make(map[string]*key),
+		now:     time.Now,
+	}
+
+	for _, keyCfg := range cfg.Keys {
+		burst := float64(keyCfg.Burst)
+		if burst == 0 {
+			burst = math.Max(1, math.Ceil(keyCfg.RequestsPerMinute))
+		}
+		a.keys[strings.ToLower(keyCfg.Hash)] = &key{
+			name:          keyCfg.Name,
+			ratePerSecond: keyCfg.RequestsPerMinute / 60,
+			burst:         burst,
+			tokens:        burst,
+			dailyQuota:    keyCfg.DailyQuota,
+		}
+	}
+
+	return a
+}
+
+// Enabled returns true if the Authenticator requires API keys,
+// and false otherwise.
+func (a *Authenticator) Enabled() bool {
+	return a.enabled
+}
+
+// Authenticate checks the API key provided with a request, consuming one
+// request from the key's rate limit and quota.
+//
+// Returns the name of the key used, or an error if the key is missing,
+// invalid, rate limited or has exceeded its quota.
+func (a *Authenticator) Authenticate(r *http.Request) (string, error) {
+	return a.AuthenticateKey(r.Header.Get(API_KEY_HEADER))
+}
+
+// AuthenticateKey checks an API key provided by a client over any transport,
+// consuming one request from the key's rate limit and quota.
+//
+// Returns the name of the key used, or an error if the key is missing,
+// invalid, rate limited or has exceeded its quota.
+func (a *Authenticator) AuthenticateKey(provided string) (string, error) {
+	if provided == "" {
+		return "", ErrMissingKey
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	k, ok := a.keys[HashKey(provided)]
+	if !ok {
+		return "", ErrInvalidKey
+	}
+
+	now := a.now()
+	if !k.takeToken(now) {
+		return k.name, ErrRateLimited
+	}
+	if !k.useQuota(now) {
+		return k.name, ErrQuotaExceeded
+	}
+
+	return k.name, nil
+}
+
+// HashKey returns the hex-encoded SHA-256 hash of an API key, as
+// used in an AuthConfig.
+func HashKey(apiKey string) string {
+	hash := sha256.Sum256([]byte(apiKey))
+	return hex.EncodeToString(hash[:])
+}
+
+// ContextWithKeyName returns a copy of ctx carrying the name of the
+// API key used for a request.
+func ContextWithKeyName(ctx context.Context, keyName string) context.Context {
+	return context.WithValue(ctx, contextKey{}, keyName)
+}
+
+// KeyNameFromContext returns the name of the API key carried by ctx, or
+// an empty string if there is none.
+func KeyNameFromContext(ctx context.Context) string {
+	keyName, _ := ctx.Value(contextKey{}).(string)
+	return keyName
+}
+
+func (k *key) takeToken(now time.Time) bool {
+	if k.ratePerSecond == 0 {
+		return true // Unlimited
+	}
+
+	if !k.lastRefill.IsZero() {
+		elapsed := now.Sub(k.lastRefill).Seconds()
+		k.tokens = math.Min(k.burst, k.tokens+(elapsed*k.ratePerSecond))
+	}
+	k.lastRefill = now
+
+	if k.tokens < 1 {
+		return false
+	}
+	k.tokens -= 1
+	return true
+}
+
+func (k *key) useQuota(now time.Time) bool {
+	if k.dailyQuota == 0 {
+		return true // Unlimited
+	}
+
+	day := now.UTC().Truncate(24 * time.Hour)
+	if !day.Equal(k.quotaDay) {
+		k.quotaDay = day
+		k.quotaUsed = 0
+	}
+
+	if k.quotaUsed >= k.dailyQuota {
+		return false
+	}
+	k.quotaUsed += 1
+	return true
+}
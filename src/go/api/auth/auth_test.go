@@ -0,0 +1,109 @@
+package auth
+
+import (
+	"aws-blended-instances-advisor/config"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+const (
+	TEST_KEY = "TEST_KEY"
+)
+
+func createRequest(apiKey string) *http.Request {
+	r, _ := http.NewRequest("GET", "/advise", nil)
+	if apiKey != "" {
+		r.Header.Set(API_KEY_HEADER, apiKey)
+	}
+	return r
+}
+
+func TestAuthenticate(t *testing.T) {
+	a := New(&config.AuthConfig{
+		Enabled: true,
+		Keys:    []config.ApiKeyConfig{{Name: "test", Hash: HashKey(TEST_KEY)}},
+	})
+
+	tests := map[string]struct {
+		apiKey      string
+		expectedErr error
+	}{
+		"valid key":   {apiKey: TEST_KEY, expectedErr: nil},
+		"missing key": {apiKey: "", expectedErr: ErrMissingKey},
+		"invalid key": {apiKey: "NOT_A_KEY", expectedErr: ErrInvalidKey},
+	}
+
+	for name, test := range tests {
+		keyName, err := a.Authenticate(createRequest(test.apiKey))
+		if err != test.expectedErr {
+			t.Fatalf("Wrong error for \"%s\". Wanted: %v, got: %v", name, test.expectedErr, err)
+		}
+		if err == nil && keyName != "test" {
+			t.Fatalf("Wrong key name for \"%s\". Wanted: test, got: %s", name, keyName)
+		}
+	}
+}
+
+func TestRateLimit(t *testing.T) {
+	a := New(&config.AuthConfig{
+		Enabled: true,
+		Keys:    []config.ApiKeyConfig{{Name: "test", Hash: HashKey(TEST_KEY), RequestsPerMinute: 60, Burst: 2}},
+	})
+	now := time.Date(2000, 1, 1, 13, 0, 0, 0, time.UTC)
+	a.now = func() time.Time { return now }
+
+	for i := 0; i < 2; i++ {
+		if _, err := a.Authenticate(createRequest(TEST_KEY)); err != nil {
+			t.Fatalf("Request %d within burst was rejected: %s", i, err.Error())
+		}
+	}
+	if _, err := a.Authenticate(createRequest(TEST_KEY)); err != ErrRateLimited {
+		t.Fatalf("Request exceeding burst was not rate limited. Got: %v", err)
+	}
+
+	now = now.Add(time.Second)
+	if _, err := a.Authenticate(createRequest(TEST_KEY)); err != nil {
+		t.Fatalf("Request after refill was rejected: %s", err.Error())
+	}
+}
+
+func TestDailyQuota(t *testing.T) {
+	a := New(&config.AuthConfig{
+		Enabled: true,
+		Keys:    []config.ApiKeyConfig{{Name: "test", Hash: HashKey(TEST_KEY), DailyQuota: 1}},
+	})
+	now := time.Date(2000, 1, 1, 13, 0, 0, 0, time.UTC)
+	a.now = func() time.Time { return now }
+
+	if _, err := a.Authenticate(createRequest(TEST_KEY)); err != nil {
+		t.Fatalf("Request within quota was rejected: %s", err.Error())
+	}
+	if _, err := a.Authenticate(createRequest(TEST_KEY)); err != ErrQuotaExceeded {
+		t.Fatalf("Request exceeding quota was not rejected. Got: %v", err)
+	}
+
+	now = now.Add(12 * time.Hour)
+	if _, err := a.Authenticate(createRequest(TEST_KEY)); err != nil {
+		t.Fatalf("Request on the next day was rejected: %s", err.Error())
+	}
+}
+
+func TestFailureReason(t *testing.T) {
+	tests := map[error]string{
+		ErrMissingKey:                            REASON_MISSING,
+		ErrInvalidKey:                            REASON_INVALID,
+		ErrRateLimited:                           REASON_RATE_LIMITED,
+		ErrQuotaExceeded:                         REASON_QUOTA_EXCEEDED,
+		fmt.Errorf("key abc: %w", ErrInvalidKey): REASON_INVALID,
+		errors.New("API key abc is broken"):      REASON_OTHER,
+	}
+
+	for err, expected := range tests {
+		if got := FailureReason(err); got != expected {
+			t.Fatalf("Wrong reason for \"%s\". Wanted: %s, got: %s", err, expected, got)
+		}
+	}
+}
@@ -0,0 +1,239 @@
+package jobs
+
+import (
+	"aws-blended-instances-advisor/advisor"
+	"aws-blended-instances-advisor/api/schema"
+	"aws-blended-instances-advisor/utils"
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// A RunFunc generates Advice for a job, stopping once ctx is done and
+// reporting per-region progress to onProgress.
+type RunFunc func(ctx context.Context, onProgress advisor.ProgressHandler) (*schema.Advice, error)
+
+// A Manager runs asynchronous advice jobs and keeps them in memory so that
+// their progress and results can be polled.
+//
+// Finished jobs are removed once they have been finished for longer than
+// the Manager's retention period.
+type Manager struct {
+	mu        sync.Mutex
+	jobs      map[string]*job
+	retention time.Duration
+	logger    *zap.Logger
+}
+
+type job struct {
+	id         string
+	status     schema.JobStatus
+	regions    []string
+	progress   map[string]schema.RegionJobProgress
+	completed  int
+	advice     *schema.Advice
+	err        string
+	createdAt  time.Time
+	finishedAt time.Time
+	cancel     context.CancelFunc
+}
+
+// NewManager creates a Manager which keeps finished jobs for the
+// given retention period.
+func NewManager(retention time.Duration, logger *zap.Logger) *Manager {
+	return &Manager{
+		jobs:      make(map[string]*job),
+		retention: retention,
+		logger:    logger,
+	}
+}
+
+// Submit starts a new job for the given regions, which is run in the background
+// using run. The job's ID is returned so that it can be polled or cancelled.
+func (m *Manager) Submit(regions []string, run RunFunc) string {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	j := &job{
+		id:        utils.GenerateUuid(),
+		status:    schema.JobPending,
+		regions:   regions,
+		progress:  make(map[string]schema.RegionJobProgress),
+		createdAt: time.Now(),
+		cancel:    cancel,
+	}
+	for _, region := range regions {
+		j.progress[region] = schema.RegionJobProgress{Status: schema.JobPending}
+	}
+
+	m.mu.Lock()
+	m.removeExpiredJobs()
+	m.jobs[j.id] = j
+	m.mu.Unlock()
+
+	m.logger.Info("job submitted", zap.String("jobId", j.id), zap.Strings("regions", regions))
+
+	go m.run(ctx, j, run)
+
+	return j.id
+}
+
+// Get returns the current state of the job with the given ID.
+//
+// Returns an error if no such job exists.
+func (m *Manager) Get(id string) (*schema.JobResponse, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.removeExpiredJobs()
+
+	j, ok := m.jobs[id]
+	if !ok {
+		return nil, fmt.Errorf("job does not exist: %s", id)
+	}
+	return j.toResponse(), nil
+}
+
+// Cancel stops the job with the given ID if it has not already finished.
+//
+// Returns an error if no such job exists.
+func (m *Manager) Cancel(id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	j, ok := m.jobs[id]
+	if !ok {
+		return fmt.Errorf("job does not exist: %s", id)
+	}
+
+	if !j.status.IsFinished() {
+		j.cancel()
+		j.finish(schema.JobCancelled, "job cancelled")
+		m.logger.Info("job cancelled", zap.String("jobId", id))
+	}
+	return nil
+}
+
+// CancelAll stops all jobs which have not already finished.
+func (m *Manager) CancelAll() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for id, j := range m.jobs {
+		if !j.status.IsFinished() {
+			j.cancel()
+			j.finish(schema.JobCancelled, "service shutting down")
+			m.logger.Info("job cancelled", zap.String("jobId", id))
+		}
+	}
+}
+
+func (m *Manager) run(ctx context.Context, j *job, run RunFunc) {
+	defer j.cancel()
+
+	m.mu.Lock()
+	if j.status != schema.JobPending {
+		m.mu.Unlock()
+		return
+	}
+	j.status = schema.JobRunning
+	if len(j.regions) > 0 {
+		j.progress[j.regions[0]] = schema.RegionJobProgress{Status: schema.JobRunning}
+	}
+	m.mu.Unlock()
+
+	advice, err := run(ctx, func(progress advisor.RegionProgress) {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		j.recordProgress(progress)
+	})
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if j.status.IsFinished() {
+		return // Cancelled while running
+	}
+
+	if err != nil {
+		j.finish(schema.JobFailed, err.Error())
+		m.logger.Error("job failed", zap.String("jobId", j.id), zap.Error(err))
+		return
+	}
+
+	j.advice = advice
+	j.finish(schema.JobCompleted, "")
+	m.logger.Info("job completed", zap.String("jobId", j.id))
+}
+
+func (m *Manager) removeExpiredJobs() {
+	now := time.Now()
+	for id, j := range m.jobs {
+		if j.status.IsFinished() && now.Sub(j.finishedAt) > m.retention {
+			delete(m.jobs, id)
+			m.logger.Info("removed expired job", zap.String("jobId", id))
+		}
+	}
+}
+
+func (j *job) recordProgress(progress advisor.RegionProgress) {
+	if j.status.IsFinished() {
+		return
+	}
+
+	j.completed = progress.Completed
+	if progress.Err != nil {
+		j.progress[progress.Region] = schema.RegionJobProgress{
+			Status: schema.JobFailed,
+			Error:  progress.Err.Error(),
+		}
+		return
+	}
+	j.progress[progress.Region] = schema.RegionJobProgress{Status: schema.JobCompleted}
+
+	for _, region := range j.regions {
+		if j.progress[region].Status == schema.JobPending {
+			j.progress[region] = schema.RegionJobProgress{Status: schema.JobRunning}
+			break
+		}
+	}
+}
+
+func (j *job) finish(status schema.JobStatus, message string) {
+	j.status = status
+	j.err = message
+	j.finishedAt = time.Now()
+
+	for region, progress := range j.progress {
+		if !progress.Status.IsFinished() {
+			j.progress[region] = schema.RegionJobProgress{Status: status}
+		}
+	}
+}
+
+func (j *job) toResponse() *schema.JobResponse {
+	regions := make(map[string]schema.RegionJobProgress)
+	for region, progress := range j.progress {
+		regions[region] = progress
+	}
+
+	resp := &schema.JobResponse{
+		Id:     j.id,
+		Status: j.status,
+		Progress: schema.JobProgress{
+			Completed: j.completed,
+			Total:     len(j.regions),
+		},
+		Regions:   regions,
+		Advice:    j.advice,
+		Error:     j.err,
+		CreatedAt: j.createdAt,
+	}
+	if j.status.IsFinished() {
+		finishedAt := j.finishedAt
+		resp.FinishedAt = &finishedAt
+	}
+	return resp
+}
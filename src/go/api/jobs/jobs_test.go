@@ -0,0 +1,175 @@
+package jobs
+
+import (
+	"aws-blended-instances-advisor/advisor"
+	"aws-blended-instances-advisor/api/schema"
+	"aws-blended-instances-advisor/utils"
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+const (
+	TEST_WAIT_TIMEOUT = time.Second
+)
+
+func waitForStatus(m *Manager, id string, status schema.JobStatus) (*schema.JobResponse, error) {
+	deadline := time.Now().Add(TEST_WAIT_TIMEOUT)
+	for time.Now().Before(deadline) {
+		job, err := m.Get(id)
+		if err != nil {
+			return nil, err
+		}
+		if job.Status == status {
+			return job, nil
+		}
+		time.Sleep(time.Millisecond)
+	}
+	return nil, errors.New("timed out waiting for job status")
+}
+
+func TestSubmitCompletes(t *testing.T) {
+	logger, err := utils.CreateMockLogger()
+	if err != nil {
+		t.Fatalf("Failed to create mock logger: %s", err.Error())
+	}
+
+	m := NewManager(time.Hour, logger)
+	regions := []string{"us-east-1", "eu-west-1"}
+
+	id := m.Submit(regions, func(ctx context.Context, onProgress advisor.ProgressHandler) (*schema.Advice, error) {
+		advice := make(schema.Advice)
+		for index, region := range regions {
+			advice[region] = schema.RegionAdvice{Score: 1}
+			onProgress(advisor.RegionProgress{Region: region, Completed: index + 1, Total: len(regions)})
+		}
+		return &advice, nil
+	})
+
+	job, err := waitForStatus(m, id, schema.JobCompleted)
+	if err != nil {
+		t.Fatalf("Job did not complete: %s", err.Error())
+	}
+	if job.Advice == nil || len(*job.Advice) != len(regions) {
+		t.Fatalf("Completed job has wrong advice. Wanted %d regions, got: %+v", len(regions), job.Advice)
+	}
+	if job.Progress.Completed != len(regions) || job.Progress.Total != len(regions) {
+		t.Fatalf("Completed job has wrong progress: %+v", job.Progress)
+	}
+	for _, region := range regions {
+		if job.Regions[region].Status != schema.JobCompleted {
+			t.Fatalf("Region %s not completed: %+v", region, job.Regions[region])
+		}
+	}
+	if job.FinishedAt == nil {
+		t.Fatalf("Completed job has no finish time")
+	}
+}
+
+func TestSubmitFails(t *testing.T) {
+	logger, err := utils.CreateMockLogger()
+	if err != nil {
+		t.Fatalf("Failed to create mock logger: %s", err.Error())
+	}
+
+	m := NewManager(time.Hour, logger)
+
+	id := m.Submit([]string{"us-east-1"}, func(ctx context.Context, onProgress advisor.ProgressHandler) (*schema.Advice, error) {
+		err := errors.New("TEST ERROR")
+		onProgress(advisor.RegionProgress{Region: "us-east-1", Err: err, Completed: 1, Total: 1})
+		return nil, err
+	})
+
+	job, err := waitForStatus(m, id, schema.JobFailed)
+	if err != nil {
+		t.Fatalf("Job did not fail: %s", err.Error())
+	}
+	if job.Error != "TEST ERROR" {
+		t.Fatalf("Failed job has wrong error. Wanted: TEST ERROR, got: %s", job.Error)
+	}
+	if job.Regions["us-east-1"].Error != "TEST ERROR" {
+		t.Fatalf("Failed region has wrong error: %+v", job.Regions["us-east-1"])
+	}
+}
+
+func TestCancel(t *testing.T) {
+	logger, err := utils.CreateMockLogger()
+	if err != nil {
+		t.Fatalf("Failed to create mock logger: %s", err.Error())
+	}
+
+	m := NewManager(time.Hour, logger)
+	started := make(chan bool)
+	stopped := make(chan error)
+
+	id := m.Submit([]string{"us-east-1"}, func(ctx context.Context, onProgress advisor.ProgressHandler) (*schema.Advice, error) {
+		started <- true
+		<-ctx.Done()
+		stopped <- ctx.Err()
+		return nil, ctx.Err()
+	})
+
+	<-started
+	err = m.Cancel(id)
+	if err != nil {
+		t.Fatalf("Failed to cancel job: %s", err.Error())
+	}
+
+	select {
+	case err := <-stopped:
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("Job context has wrong error: %v", err)
+		}
+	case <-time.After(TEST_WAIT_TIMEOUT):
+		t.Fatalf("Job context was not cancelled")
+	}
+
+	job, err := m.Get(id)
+	if err != nil {
+		t.Fatalf("Failed to get cancelled job: %s", err.Error())
+	}
+	if job.Status != schema.JobCancelled {
+		t.Fatalf("Job has wrong status. Wanted: %s, got: %s", schema.JobCancelled, job.Status)
+	}
+}
+
+func TestUnknownJob(t *testing.T) {
+	logger, err := utils.CreateMockLogger()
+	if err != nil {
+		t.Fatalf("Failed to create mock logger: %s", err.Error())
+	}
+
+	m := NewManager(time.Hour, logger)
+
+	_, err = m.Get("NOT_A_JOB")
+	if err == nil {
+		t.Fatalf("Expected error when getting unknown job")
+	}
+	err = m.Cancel("NOT_A_JOB")
+	if err == nil {
+		t.Fatalf("Expected error when cancelling unknown job")
+	}
+}
+
+func TestFinishedJobsExpire(t *testing.T) {
+	logger, err := utils.CreateMockLogger()
+	if err != nil {
+		t.Fatalf("Failed to create mock logger: %s", err.Error())
+	}
+
+	m := NewManager(0, logger)
+
+	id := m.Submit([]string{}, func(ctx context.Context, onProgress advisor.ProgressHandler) (*schema.Advice, error) {
+		return &schema.Advice{}, nil
+	})
+
+	deadline := time.Now().Add(TEST_WAIT_TIMEOUT)
+	for time.Now().Before(deadline) {
+		if _, err := m.Get(id); err != nil {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("Finished job was not removed after retention period")
+}
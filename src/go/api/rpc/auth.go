@@ -0,0 +1,102 @@
+package rpc
+
+import (
+	"aws-blended-instances-advisor/api/auth"
+	"context"
+	"strings"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// UnaryAuthInterceptor rejects unary calls which do not provide a valid API key
+// in their metadata, when authentication is enabled.
+func UnaryAuthInterceptor(authenticator *auth.Authenticator, logger *zap.Logger) grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		ctx, err := authenticate(ctx, authenticator, info.FullMethod, logger)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamAuthInterceptor rejects streaming calls which do not provide a valid
+// API key in their metadata, when authentication is enabled.
+func StreamAuthInterceptor(authenticator *auth.Authenticator, logger *zap.Logger) grpc.StreamServerInterceptor {
+	return func(
+		srv interface{},
+		stream grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		ctx, err := authenticate(stream.Context(), authenticator, info.FullMethod, logger)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &authenticatedStream{ServerStream: stream, ctx: ctx})
+	}
+}
+
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authenticatedStream) Context() context.Context {
+	return s.ctx
+}
+
+func authenticate(
+	ctx context.Context,
+	authenticator *auth.Authenticator,
+	method string,
+	logger *zap.Logger,
+) (context.Context, error) {
+	if !authenticator.Enabled() {
+		return ctx, nil
+	}
+
+	provided := ""
+	md, _ := metadata.FromIncomingContext(ctx)
+	if values := md.Get(strings.ToLower(auth.API_KEY_HEADER)); len(values) > 0 {
+		provided = values[0]
+	}
+
+	keyName, err := authenticator.AuthenticateKey(provided)
+	if err != nil {
+		code := codes.Unauthenticated
+		switch err {
+		case auth.ErrRateLimited, auth.ErrQuotaExceeded:
+			code = codes.ResourceExhausted
+		}
+
+		logger.Warn(
+			"gRPC call rejected by authentication",
+			zap.String("apiKey", keyName),
+			zap.String("method", method),
+			zap.String("remoteAddr", remoteAddr(ctx)),
+			zap.Error(err),
+		)
+		return nil, status.Error(code, err.Error())
+	}
+
+	return auth.ContextWithKeyName(ctx, keyName), nil
+}
+
+func remoteAddr(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return ""
+	}
+	return p.Addr.String()
+}
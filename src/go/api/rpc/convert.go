@@ -0,0 +1,247 @@
+package rpc
+
+import (
+	"aws-blended-instances-advisor/api/rpc/pb"
+	"aws-blended-instances-advisor/api/schema"
+	awsTypes "aws-blended-instances-advisor/aws/types"
+	"aws-blended-instances-advisor/catalogue"
+	"aws-blended-instances-advisor/instances/filter"
+	"time"
+)
+
+func adviseRequestFromPb(req *pb.AdviseRequest) schema.AdviseRequest {
+	services := []schema.Service{}
+	for _, svc := range req.GetServices() {
+		services = append(services, schema.Service{
+			Name:         svc.GetName(),
+			MinMemory:    svc.GetMinMemory(),
+			MaxVcpu:      int(svc.GetMaxVcpu()),
+			MinInstances: int(svc.GetMinInstances()),
+			MaxInstances: int(svc.GetMaxInstances()),
+			MinVcpu:      int(svc.GetMinVcpu()),
+			MinRegions:   int(svc.GetMinRegions()),
+			Placement:    placementFromPb(svc.GetPlacement()),
+			LoadProfile:  loadProfileFromPb(svc.GetLoadProfile()),
+		})
+	}
+
+	var rankBy []schema.RankingCriterion
+	for _, criterion := range req.GetOptions().GetRankBy() {
+		rankBy = append(rankBy, schema.RankingCriterion(criterion))
+	}
+
+	var asOf *time.Time
+	if req.GetAsOf() != nil {
+		t := req.GetAsOf().AsTime()
+		asOf = &t
+	}
+
+	return schema.AdviseRequest{
+		Services: services,
+		Advisor: schema.Advisor{
+			Type: schema.AdvisorType(req.GetAdvisor().GetType()),
+			Weights: schema.AdvisorWeights{
+				Price:        req.GetAdvisor().GetWeights().GetPrice(),
+				Availability: req.GetAdvisor().GetWeights().GetAvailability(),
+				Performance:  req.GetAdvisor().GetWeights().GetPerformance(),
+			},
+		},
+		Options: schema.Options{
+			AvoidRepeatedInstanceTypes:    req.GetOptions().GetAvoidRepeatedInstanceTypes(),
+			ShareInstancesBetweenServices: req.GetOptions().GetShareInstancesBetweenServices(),
+			ConsiderFreeInstances:         req.GetOptions().GetConsiderFreeInstances(),
+			Regions:                       req.GetOptions().GetRegions(),
+			Filter:                        instanceFilterFromPb(req.GetOptions().GetFilter()),
+			CrossRegion:                   req.GetOptions().GetCrossRegion(),
+			Geographies:                   req.GetOptions().GetGeographies(),
+			Packing:                       schema.PackingStrategy(req.GetOptions().GetPacking()),
+			RankBy:                        rankBy,
+		},
+		AsOf:   asOf,
+		Preset: req.GetPreset(),
+	}
+}
+
+func placementFromPb(placement *pb.Placement) *schema.Placement {
+	if placement == nil {
+		return nil
+	}
+	return &schema.Placement{
+		Regions:      placement.GetRegions(),
+		LatencyFrom:  placement.GetLatencyFrom(),
+		MaxLatencyMs: placement.GetMaxLatencyMs(),
+	}
+}
+
+func loadProfileFromPb(profile *pb.LoadProfile) *schema.LoadProfile {
+	if profile == nil {
+		return nil
+	}
+	instances := []int{}
+	for _, count := range profile.GetInstances() {
+		instances = append(instances, int(count))
+	}
+	return &schema.LoadProfile{
+		Period:    schema.ProfilePeriod(profile.GetPeriod()),
+		Instances: instances,
+	}
+}
+
+func instanceFilterFromPb(f *pb.InstanceFilter) schema.InstanceFilter {
+	return schema.InstanceFilter{
+		OperatingSystem: f.GetOs(),
+		Family:          f.GetFamily(),
+		MinMemory:       f.GetMinMemory(),
+		MinVcpu:         int(f.GetMinVcpu()),
+		MaxVcpu:         int(f.GetMaxVcpu()),
+		MaxPrice:        f.GetMaxPrice(),
+	}
+}
+
+func adviceToPb(advice *schema.Advice, ranked []schema.RankedRegion) *pb.Advice {
+	regions := make(map[string]*pb.RegionAdvice)
+	for region, regionAdvice := range *advice {
+		regionAdvice := regionAdvice
+		regions[region] = regionAdviceToPb(&regionAdvice)
+	}
+
+	ranking := []*pb.RankedRegion{}
+	for _, region := range ranked {
+		ranking = append(ranking, &pb.RankedRegion{
+			Region:       region.Region,
+			Rank:         int32(region.Rank),
+			Score:        region.Score,
+			PricePerHour: region.PricePerHour,
+			DecidedBy:    string(region.DecidedBy),
+		})
+	}
+	return &pb.Advice{Regions: regions, Ranking: ranking}
+}
+
+func regionAdviceToPb(advice *schema.RegionAdvice) *pb.RegionAdvice {
+	instances := make(map[string]*pb.Instance)
+	for id, inst := range advice.Instances {
+		instances[id] = instanceToPb(inst)
+	}
+
+	servicesToInstances := make(map[string]*pb.InstanceIds)
+	for svc, ids := range advice.Assignments.ServicesToInstances {
+		servicesToInstances[svc] = &pb.InstanceIds{Ids: ids}
+	}
+
+	instancesToServices := make(map[string]*pb.ServiceNames)
+	for id, names := range advice.Assignments.InstancesToServices {
+		instancesToServices[id] = &pb.ServiceNames{Names: names}
+	}
+
+	return &pb.RegionAdvice{
+		Score:     advice.Score,
+		Instances: instances,
+		Assignments: &pb.Assignments{
+			ServicesToInstances: servicesToInstances,
+			InstancesToServices: instancesToServices,
+		},
+		Rank: int32(advice.Rank),
+	}
+}
+
+func instanceToPb(inst *schema.Instance) *pb.Instance {
+	return &pb.Instance{
+		Id:        inst.Id,
+		Name:      inst.Name,
+		Memory:    inst.MemoryGb,
+		Vcpu:      int32(inst.Vcpu),
+		Region:    inst.Region,
+		Az:        inst.AvailabilityZone,
+		Os:        inst.OperatingSystem,
+		Price:     inst.PricePerHour,
+		RevocProb: inst.RevocationProbability,
+	}
+}
+
+func queryFromPb(req *pb.ListInstancesRequest) (*catalogue.Query, error) {
+	var err error
+	query := catalogue.NewQuery()
+
+	query.Filter.Regions, err = awsTypes.NewRegions(req.GetRegions())
+	if err != nil {
+		return nil, err
+	}
+	query.Filter.Market, err = filter.NewMarket(req.GetMarket())
+	if err != nil {
+		return nil, err
+	}
+
+	query.Filter.OperatingSystem = req.GetOs()
+	query.Filter.Family = req.GetFamily()
+	query.Filter.MinMemory = req.GetMinMemory()
+	query.Filter.MinVcpu = int(req.GetMinVcpu())
+	query.Filter.MaxVcpu = int(req.GetMaxVcpu())
+	query.Filter.MinPrice = req.GetMinPrice()
+	query.Filter.MaxPrice = req.GetMaxPrice()
+	query.SortBy = req.GetSort()
+	query.Descending = req.GetDescending()
+
+	if req.GetPage() != 0 {
+		query.Page = int(req.GetPage())
+	}
+	if req.GetPageSize() != 0 {
+		query.PageSize = int(req.GetPageSize())
+	}
+
+	err = query.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	return &query, nil
+}
+
+func instancesResponseToPb(resp schema.InstancesResponse) *pb.ListInstancesResponse {
+	instances := []*pb.CatalogueInstance{}
+	for _, inst := range resp.Instances {
+		inst := inst
+		instances = append(instances, &pb.CatalogueInstance{
+			Instance: instanceToPb(&inst.Instance),
+			Market:   inst.Market,
+		})
+	}
+
+	return &pb.ListInstancesResponse{
+		Instances: instances,
+		Total:     int32(resp.Total),
+		Page:      int32(resp.Page),
+		PageSize:  int32(resp.PageSize),
+	}
+}
+
+func aggregatesResponseToPb(resp schema.AggregatesResponse) *pb.AggregatesResponse {
+	regions := make(map[string]*pb.RegionAggregates)
+	for region, agg := range resp.Regions {
+		regions[region] = &pb.RegionAggregates{
+			OnDemand: aggregatesToPb(agg.OnDemand),
+			Spot:     aggregatesToPb(agg.Spot),
+			Region:   aggregatesToPb(agg.Region),
+		}
+	}
+
+	return &pb.AggregatesResponse{
+		Global:  aggregatesToPb(resp.Global),
+		Regions: regions,
+	}
+}
+
+func aggregatesToPb(agg schema.Aggregates) *pb.Aggregates {
+	return &pb.Aggregates{
+		Count:         int32(agg.Count),
+		MinVcpu:       int32(agg.MinVcpu),
+		MaxVcpu:       int32(agg.MaxVcpu),
+		MeanVcpu:      agg.MeanVcpu,
+		MinRevocProb:  agg.MinRevocationProbability,
+		MaxRevocProb:  agg.MaxRevocationProbability,
+		MeanRevocProb: agg.MeanRevocationProbability,
+		MinPrice:      agg.MinPricePerHour,
+		MaxPrice:      agg.MaxPricePerHour,
+		MeanPrice:     agg.MeanPricePerHour,
+	}
+}
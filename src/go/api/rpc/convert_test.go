@@ -0,0 +1,114 @@
+package rpc
+
+import (
+	"aws-blended-instances-advisor/api/rpc/pb"
+	"aws-blended-instances-advisor/api/schema"
+	"reflect"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestAdviseRequestFromPb(t *testing.T) {
+	asOf := time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)
+	instances := make([]int32, schema.HOURS_PER_DAY)
+	expectedInstances := make([]int, schema.HOURS_PER_DAY)
+	for i := range instances {
+		instances[i] = int32(i % 3)
+		expectedInstances[i] = i % 3
+	}
+
+	req := &pb.AdviseRequest{
+		Services: []*pb.Service{
+			{
+				Name:        "web",
+				MinMemory:   2,
+				MaxVcpu:     4,
+				MinVcpu:     2,
+				MinRegions:  2,
+				Placement:   &pb.Placement{Regions: []string{"eu-west-1", "eu-west-2"}, LatencyFrom: "eu-west-2", MaxLatencyMs: 20},
+				LoadProfile: &pb.LoadProfile{Period: "daily", Instances: instances},
+			},
+			{Name: "db", MinMemory: 8, MaxVcpu: 2, MinInstances: 1, MaxInstances: 3},
+		},
+		Advisor: &pb.Advisor{
+			Type:    "weighted",
+			Weights: &pb.AdvisorWeights{Price: 3, Availability: 1, Performance: 2},
+		},
+		Options: &pb.Options{
+			AvoidRepeatedInstanceTypes:    true,
+			ShareInstancesBetweenServices: true,
+			ConsiderFreeInstances:         true,
+			Regions:                       []string{"eu-west-1"},
+			Filter: &pb.InstanceFilter{
+				Os:        "Linux",
+				Family:    "m5",
+				MinMemory: 4,
+				MinVcpu:   2,
+				MaxVcpu:   8,
+				MaxPrice:  0.5,
+			},
+			CrossRegion: true,
+			Geographies: []string{"europe"},
+			Packing:     "best-fit",
+			RankBy:      []string{"price", "score"},
+		},
+		AsOf:   timestamppb.New(asOf),
+		Preset: "cost-saver",
+	}
+
+	expected := schema.AdviseRequest{
+		Services: []schema.Service{
+			{
+				Name:        "web",
+				MinMemory:   2,
+				MaxVcpu:     4,
+				MinVcpu:     2,
+				MinRegions:  2,
+				Placement:   &schema.Placement{Regions: []string{"eu-west-1", "eu-west-2"}, LatencyFrom: "eu-west-2", MaxLatencyMs: 20},
+				LoadProfile: &schema.LoadProfile{Period: schema.DailyProfile, Instances: expectedInstances},
+			},
+			{Name: "db", MinMemory: 8, MaxVcpu: 2, MinInstances: 1, MaxInstances: 3},
+		},
+		Advisor: schema.Advisor{
+			Type:    schema.Weighted,
+			Weights: schema.AdvisorWeights{Price: 3, Availability: 1, Performance: 2},
+		},
+		Options: schema.Options{
+			AvoidRepeatedInstanceTypes:    true,
+			ShareInstancesBetweenServices: true,
+			ConsiderFreeInstances:         true,
+			Regions:                       []string{"eu-west-1"},
+			Filter: schema.InstanceFilter{
+				OperatingSystem: "Linux",
+				Family:          "m5",
+				MinMemory:       4,
+				MinVcpu:         2,
+				MaxVcpu:         8,
+				MaxPrice:        0.5,
+			},
+			CrossRegion: true,
+			Geographies: []string{"europe"},
+			Packing:     schema.BestFit,
+			RankBy:      []schema.RankingCriterion{schema.RankByPrice, schema.RankByScore},
+		},
+		AsOf:   &asOf,
+		Preset: "cost-saver",
+	}
+
+	got := adviseRequestFromPb(req)
+	if !reflect.DeepEqual(got, expected) {
+		t.Fatalf("Wrong request converted.\nWanted: %+v\nGot:    %+v", expected, got)
+	}
+}
+
+func TestAdviseRequestFromPbUnset(t *testing.T) {
+	got := adviseRequestFromPb(&pb.AdviseRequest{Services: []*pb.Service{{Name: "svc"}}})
+	if got.Services[0].Placement != nil || got.Services[0].LoadProfile != nil {
+		t.Fatalf("Expected no placement or load profile, got: %+v", got.Services[0])
+	}
+	if !reflect.DeepEqual(got.Options.Filter, schema.InstanceFilter{}) || got.Options.RankBy != nil || got.AsOf != nil {
+		t.Fatalf("Expected zero options, got: %+v", got.Options)
+	}
+}
@@ -0,0 +1,2558 @@
+// gRPC interface for the advice service.
+//
+// Messages mirror the JSON schemas in api/schema. Regenerate the Go code in
+// api/rpc/pb with `buf generate proto` from the api/rpc directory.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.30.0
+// 	protoc        (unknown)
+// source: advisor.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Service struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name         string       `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	MinMemory    float64      `protobuf:"fixed64,2,opt,name=min_memory,json=minMemory,proto3" json:"min_memory,omitempty"`
+	MaxVcpu      int32        `protobuf:"varint,3,opt,name=max_vcpu,json=maxVcpu,proto3" json:"max_vcpu,omitempty"`
+	MinInstances int32        `protobuf:"varint,4,opt,name=min_instances,json=minInstances,proto3" json:"min_instances,omitempty"`
+	MaxInstances int32        `protobuf:"varint,5,opt,name=max_instances,json=maxInstances,proto3" json:"max_instances,omitempty"`
+	MinVcpu      int32        `protobuf:"varint,6,opt,name=min_vcpu,json=minVcpu,proto3" json:"min_vcpu,omitempty"`            // Reserved on shared instances (1 when 0)
+	MinRegions   int32        `protobuf:"varint,7,opt,name=min_regions,json=minRegions,proto3" json:"min_regions,omitempty"`   // When advising across regions (1 when 0)
+	Placement    *Placement   `protobuf:"bytes,8,opt,name=placement,proto3" json:"placement,omitempty"`                        // Unset for any region
+	LoadProfile  *LoadProfile `protobuf:"bytes,9,opt,name=load_profile,json=loadProfile,proto3" json:"load_profile,omitempty"` // Replaces min_instances and max_instances when set
+}
+
+func (x *Service) Reset() {
+	*x = Service{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_advisor_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Service) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Service) ProtoMessage() {}
+
+func (x *Service) ProtoReflect() protoreflect.Message {
+	mi := &file_advisor_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Service.ProtoReflect.Descriptor instead.
+func (*Service) Descriptor() ([]byte, []int) {
+	return file_advisor_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Service) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Service) GetMinMemory() float64 {
+	if x != nil {
+		return x.MinMemory
+	}
+	return 0
+}
+
+func (x *Service) GetMaxVcpu() int32 {
+	if x != nil {
+		return x.MaxVcpu
+	}
+	return 0
+}
+
+func (x *Service) GetMinInstances() int32 {
+	if x != nil {
+		return x.MinInstances
+	}
+	return 0
+}
+
+func (x *Service) GetMaxInstances() int32 {
+	if x != nil {
+		return x.MaxInstances
+	}
+	return 0
+}
+
+func (x *Service) GetMinVcpu() int32 {
+	if x != nil {
+		return x.MinVcpu
+	}
+	return 0
+}
+
+func (x *Service) GetMinRegions() int32 {
+	if x != nil {
+		return x.MinRegions
+	}
+	return 0
+}
+
+func (x *Service) GetPlacement() *Placement {
+	if x != nil {
+		return x.Placement
+	}
+	return nil
+}
+
+func (x *Service) GetLoadProfile() *LoadProfile {
+	if x != nil {
+		return x.LoadProfile
+	}
+	return nil
+}
+
+type Placement struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Regions      []string `protobuf:"bytes,1,rep,name=regions,proto3" json:"regions,omitempty"`
+	LatencyFrom  string   `protobuf:"bytes,2,opt,name=latency_from,json=latencyFrom,proto3" json:"latency_from,omitempty"`
+	MaxLatencyMs float64  `protobuf:"fixed64,3,opt,name=max_latency_ms,json=maxLatencyMs,proto3" json:"max_latency_ms,omitempty"`
+}
+
+func (x *Placement) Reset() {
+	*x = Placement{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_advisor_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Placement) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Placement) ProtoMessage() {}
+
+func (x *Placement) ProtoReflect() protoreflect.Message {
+	mi := &file_advisor_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Placement.ProtoReflect.Descriptor instead.
+func (*Placement) Descriptor() ([]byte, []int) {
+	return file_advisor_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Placement) GetRegions() []string {
+	if x != nil {
+		return x.Regions
+	}
+	return nil
+}
+
+func (x *Placement) GetLatencyFrom() string {
+	if x != nil {
+		return x.LatencyFrom
+	}
+	return ""
+}
+
+func (x *Placement) GetMaxLatencyMs() float64 {
+	if x != nil {
+		return x.MaxLatencyMs
+	}
+	return 0
+}
+
+type LoadProfile struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Period    string  `protobuf:"bytes,1,opt,name=period,proto3" json:"period,omitempty"` // "daily" or "weekly"
+	Instances []int32 `protobuf:"varint,2,rep,packed,name=instances,proto3" json:"instances,omitempty"`
+}
+
+func (x *LoadProfile) Reset() {
+	*x = LoadProfile{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_advisor_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LoadProfile) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LoadProfile) ProtoMessage() {}
+
+func (x *LoadProfile) ProtoReflect() protoreflect.Message {
+	mi := &file_advisor_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LoadProfile.ProtoReflect.Descriptor instead.
+func (*LoadProfile) Descriptor() ([]byte, []int) {
+	return file_advisor_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *LoadProfile) GetPeriod() string {
+	if x != nil {
+		return x.Period
+	}
+	return ""
+}
+
+func (x *LoadProfile) GetInstances() []int32 {
+	if x != nil {
+		return x.Instances
+	}
+	return nil
+}
+
+type AdvisorWeights struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Price        float64 `protobuf:"fixed64,1,opt,name=price,proto3" json:"price,omitempty"`
+	Availability float64 `protobuf:"fixed64,2,opt,name=availability,proto3" json:"availability,omitempty"`
+	Performance  float64 `protobuf:"fixed64,3,opt,name=performance,proto3" json:"performance,omitempty"`
+}
+
+func (x *AdvisorWeights) Reset() {
+	*x = AdvisorWeights{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_advisor_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AdvisorWeights) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdvisorWeights) ProtoMessage() {}
+
+func (x *AdvisorWeights) ProtoReflect() protoreflect.Message {
+	mi := &file_advisor_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdvisorWeights.ProtoReflect.Descriptor instead.
+func (*AdvisorWeights) Descriptor() ([]byte, []int) {
+	return file_advisor_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *AdvisorWeights) GetPrice() float64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *AdvisorWeights) GetAvailability() float64 {
+	if x != nil {
+		return x.Availability
+	}
+	return 0
+}
+
+func (x *AdvisorWeights) GetPerformance() float64 {
+	if x != nil {
+		return x.Performance
+	}
+	return 0
+}
+
+type Advisor struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type    string          `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Weights *AdvisorWeights `protobuf:"bytes,2,opt,name=weights,proto3" json:"weights,omitempty"`
+}
+
+func (x *Advisor) Reset() {
+	*x = Advisor{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_advisor_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Advisor) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Advisor) ProtoMessage() {}
+
+func (x *Advisor) ProtoReflect() protoreflect.Message {
+	mi := &file_advisor_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Advisor.ProtoReflect.Descriptor instead.
+func (*Advisor) Descriptor() ([]byte, []int) {
+	return file_advisor_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Advisor) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Advisor) GetWeights() *AdvisorWeights {
+	if x != nil {
+		return x.Weights
+	}
+	return nil
+}
+
+type Options struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	AvoidRepeatedInstanceTypes    bool            `protobuf:"varint,1,opt,name=avoid_repeated_instance_types,json=avoidRepeatedInstanceTypes,proto3" json:"avoid_repeated_instance_types,omitempty"`
+	ShareInstancesBetweenServices bool            `protobuf:"varint,2,opt,name=share_instances_between_services,json=shareInstancesBetweenServices,proto3" json:"share_instances_between_services,omitempty"`
+	ConsiderFreeInstances         bool            `protobuf:"varint,3,opt,name=consider_free_instances,json=considerFreeInstances,proto3" json:"consider_free_instances,omitempty"`
+	Regions                       []string        `protobuf:"bytes,4,rep,name=regions,proto3" json:"regions,omitempty"`
+	Filter                        *InstanceFilter `protobuf:"bytes,5,opt,name=filter,proto3" json:"filter,omitempty"`
+	CrossRegion                   bool            `protobuf:"varint,6,opt,name=cross_region,json=crossRegion,proto3" json:"cross_region,omitempty"`
+	Geographies                   []string        `protobuf:"bytes,7,rep,name=geographies,proto3" json:"geographies,omitempty"`
+	Packing                       string          `protobuf:"bytes,8,opt,name=packing,proto3" json:"packing,omitempty"`
+	RankBy                        []string        `protobuf:"bytes,9,rep,name=rank_by,json=rankBy,proto3" json:"rank_by,omitempty"`
+}
+
+func (x *Options) Reset() {
+	*x = Options{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_advisor_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Options) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Options) ProtoMessage() {}
+
+func (x *Options) ProtoReflect() protoreflect.Message {
+	mi := &file_advisor_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Options.ProtoReflect.Descriptor instead.
+func (*Options) Descriptor() ([]byte, []int) {
+	return file_advisor_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Options) GetAvoidRepeatedInstanceTypes() bool {
+	if x != nil {
+		return x.AvoidRepeatedInstanceTypes
+	}
+	return false
+}
+
+func (x *Options) GetShareInstancesBetweenServices() bool {
+	if x != nil {
+		return x.ShareInstancesBetweenServices
+	}
+	return false
+}
+
+func (x *Options) GetConsiderFreeInstances() bool {
+	if x != nil {
+		return x.ConsiderFreeInstances
+	}
+	return false
+}
+
+func (x *Options) GetRegions() []string {
+	if x != nil {
+		return x.Regions
+	}
+	return nil
+}
+
+func (x *Options) GetFilter() *InstanceFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *Options) GetCrossRegion() bool {
+	if x != nil {
+		return x.CrossRegion
+	}
+	return false
+}
+
+func (x *Options) GetGeographies() []string {
+	if x != nil {
+		return x.Geographies
+	}
+	return nil
+}
+
+func (x *Options) GetPacking() string {
+	if x != nil {
+		return x.Packing
+	}
+	return ""
+}
+
+func (x *Options) GetRankBy() []string {
+	if x != nil {
+		return x.RankBy
+	}
+	return nil
+}
+
+type InstanceFilter struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Os        string  `protobuf:"bytes,1,opt,name=os,proto3" json:"os,omitempty"`
+	Family    string  `protobuf:"bytes,2,opt,name=family,proto3" json:"family,omitempty"`
+	MinMemory float64 `protobuf:"fixed64,3,opt,name=min_memory,json=minMemory,proto3" json:"min_memory,omitempty"`
+	MinVcpu   int32   `protobuf:"varint,4,opt,name=min_vcpu,json=minVcpu,proto3" json:"min_vcpu,omitempty"`
+	MaxVcpu   int32   `protobuf:"varint,5,opt,name=max_vcpu,json=maxVcpu,proto3" json:"max_vcpu,omitempty"`
+	MaxPrice  float64 `protobuf:"fixed64,6,opt,name=max_price,json=maxPrice,proto3" json:"max_price,omitempty"`
+}
+
+func (x *InstanceFilter) Reset() {
+	*x = InstanceFilter{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_advisor_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *InstanceFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InstanceFilter) ProtoMessage() {}
+
+func (x *InstanceFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_advisor_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InstanceFilter.ProtoReflect.Descriptor instead.
+func (*InstanceFilter) Descriptor() ([]byte, []int) {
+	return file_advisor_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *InstanceFilter) GetOs() string {
+	if x != nil {
+		return x.Os
+	}
+	return ""
+}
+
+func (x *InstanceFilter) GetFamily() string {
+	if x != nil {
+		return x.Family
+	}
+	return ""
+}
+
+func (x *InstanceFilter) GetMinMemory() float64 {
+	if x != nil {
+		return x.MinMemory
+	}
+	return 0
+}
+
+func (x *InstanceFilter) GetMinVcpu() int32 {
+	if x != nil {
+		return x.MinVcpu
+	}
+	return 0
+}
+
+func (x *InstanceFilter) GetMaxVcpu() int32 {
+	if x != nil {
+		return x.MaxVcpu
+	}
+	return 0
+}
+
+func (x *InstanceFilter) GetMaxPrice() float64 {
+	if x != nil {
+		return x.MaxPrice
+	}
+	return 0
+}
+
+type AdviseRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Services []*Service `protobuf:"bytes,1,rep,name=services,proto3" json:"services,omitempty"`
+	Advisor  *Advisor   `protobuf:"bytes,2,opt,name=advisor,proto3" json:"advisor,omitempty"`
+	Options  *Options   `protobuf:"bytes,3,opt,name=options,proto3" json:"options,omitempty"`
+	// When set, advice is generated using instances as they were at this time.
+	AsOf *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=as_of,json=asOf,proto3" json:"as_of,omitempty"`
+	// The server's preset whose values are used for fields left unset.
+	Preset string `protobuf:"bytes,5,opt,name=preset,proto3" json:"preset,omitempty"`
+}
+
+func (x *AdviseRequest) Reset() {
+	*x = AdviseRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_advisor_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AdviseRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdviseRequest) ProtoMessage() {}
+
+func (x *AdviseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_advisor_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdviseRequest.ProtoReflect.Descriptor instead.
+func (*AdviseRequest) Descriptor() ([]byte, []int) {
+	return file_advisor_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *AdviseRequest) GetServices() []*Service {
+	if x != nil {
+		return x.Services
+	}
+	return nil
+}
+
+func (x *AdviseRequest) GetAdvisor() *Advisor {
+	if x != nil {
+		return x.Advisor
+	}
+	return nil
+}
+
+func (x *AdviseRequest) GetOptions() *Options {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+func (x *AdviseRequest) GetAsOf() *timestamppb.Timestamp {
+	if x != nil {
+		return x.AsOf
+	}
+	return nil
+}
+
+func (x *AdviseRequest) GetPreset() string {
+	if x != nil {
+		return x.Preset
+	}
+	return ""
+}
+
+type Instance struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id        string  `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name      string  `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Memory    float64 `protobuf:"fixed64,3,opt,name=memory,proto3" json:"memory,omitempty"`
+	Vcpu      int32   `protobuf:"varint,4,opt,name=vcpu,proto3" json:"vcpu,omitempty"`
+	Region    string  `protobuf:"bytes,5,opt,name=region,proto3" json:"region,omitempty"`
+	Az        string  `protobuf:"bytes,6,opt,name=az,proto3" json:"az,omitempty"`
+	Os        string  `protobuf:"bytes,7,opt,name=os,proto3" json:"os,omitempty"`
+	Price     float64 `protobuf:"fixed64,8,opt,name=price,proto3" json:"price,omitempty"`
+	RevocProb float64 `protobuf:"fixed64,9,opt,name=revoc_prob,json=revocProb,proto3" json:"revoc_prob,omitempty"`
+}
+
+func (x *Instance) Reset() {
+	*x = Instance{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_advisor_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Instance) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Instance) ProtoMessage() {}
+
+func (x *Instance) ProtoReflect() protoreflect.Message {
+	mi := &file_advisor_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Instance.ProtoReflect.Descriptor instead.
+func (*Instance) Descriptor() ([]byte, []int) {
+	return file_advisor_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *Instance) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Instance) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Instance) GetMemory() float64 {
+	if x != nil {
+		return x.Memory
+	}
+	return 0
+}
+
+func (x *Instance) GetVcpu() int32 {
+	if x != nil {
+		return x.Vcpu
+	}
+	return 0
+}
+
+func (x *Instance) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+func (x *Instance) GetAz() string {
+	if x != nil {
+		return x.Az
+	}
+	return ""
+}
+
+func (x *Instance) GetOs() string {
+	if x != nil {
+		return x.Os
+	}
+	return ""
+}
+
+func (x *Instance) GetPrice() float64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *Instance) GetRevocProb() float64 {
+	if x != nil {
+		return x.RevocProb
+	}
+	return 0
+}
+
+type InstanceIds struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ids []string `protobuf:"bytes,1,rep,name=ids,proto3" json:"ids,omitempty"`
+}
+
+func (x *InstanceIds) Reset() {
+	*x = InstanceIds{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_advisor_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *InstanceIds) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InstanceIds) ProtoMessage() {}
+
+func (x *InstanceIds) ProtoReflect() protoreflect.Message {
+	mi := &file_advisor_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InstanceIds.ProtoReflect.Descriptor instead.
+func (*InstanceIds) Descriptor() ([]byte, []int) {
+	return file_advisor_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *InstanceIds) GetIds() []string {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+type ServiceNames struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Names []string `protobuf:"bytes,1,rep,name=names,proto3" json:"names,omitempty"`
+}
+
+func (x *ServiceNames) Reset() {
+	*x = ServiceNames{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_advisor_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ServiceNames) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServiceNames) ProtoMessage() {}
+
+func (x *ServiceNames) ProtoReflect() protoreflect.Message {
+	mi := &file_advisor_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServiceNames.ProtoReflect.Descriptor instead.
+func (*ServiceNames) Descriptor() ([]byte, []int) {
+	return file_advisor_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ServiceNames) GetNames() []string {
+	if x != nil {
+		return x.Names
+	}
+	return nil
+}
+
+type Assignments struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ServicesToInstances map[string]*InstanceIds  `protobuf:"bytes,1,rep,name=services_to_instances,json=servicesToInstances,proto3" json:"services_to_instances,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	InstancesToServices map[string]*ServiceNames `protobuf:"bytes,2,rep,name=instances_to_services,json=instancesToServices,proto3" json:"instances_to_services,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *Assignments) Reset() {
+	*x = Assignments{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_advisor_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Assignments) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Assignments) ProtoMessage() {}
+
+func (x *Assignments) ProtoReflect() protoreflect.Message {
+	mi := &file_advisor_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Assignments.ProtoReflect.Descriptor instead.
+func (*Assignments) Descriptor() ([]byte, []int) {
+	return file_advisor_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *Assignments) GetServicesToInstances() map[string]*InstanceIds {
+	if x != nil {
+		return x.ServicesToInstances
+	}
+	return nil
+}
+
+func (x *Assignments) GetInstancesToServices() map[string]*ServiceNames {
+	if x != nil {
+		return x.InstancesToServices
+	}
+	return nil
+}
+
+type RegionAdvice struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Score       float64              `protobuf:"fixed64,1,opt,name=score,proto3" json:"score,omitempty"`
+	Instances   map[string]*Instance `protobuf:"bytes,2,rep,name=instances,proto3" json:"instances,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Assignments *Assignments         `protobuf:"bytes,3,opt,name=assignments,proto3" json:"assignments,omitempty"`
+	Rank        int32                `protobuf:"varint,4,opt,name=rank,proto3" json:"rank,omitempty"` // From 1 for the best region, 0 when unranked
+}
+
+func (x *RegionAdvice) Reset() {
+	*x = RegionAdvice{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_advisor_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RegionAdvice) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegionAdvice) ProtoMessage() {}
+
+func (x *RegionAdvice) ProtoReflect() protoreflect.Message {
+	mi := &file_advisor_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegionAdvice.ProtoReflect.Descriptor instead.
+func (*RegionAdvice) Descriptor() ([]byte, []int) {
+	return file_advisor_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *RegionAdvice) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *RegionAdvice) GetInstances() map[string]*Instance {
+	if x != nil {
+		return x.Instances
+	}
+	return nil
+}
+
+func (x *RegionAdvice) GetAssignments() *Assignments {
+	if x != nil {
+		return x.Assignments
+	}
+	return nil
+}
+
+func (x *RegionAdvice) GetRank() int32 {
+	if x != nil {
+		return x.Rank
+	}
+	return 0
+}
+
+type RankedRegion struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Region       string  `protobuf:"bytes,1,opt,name=region,proto3" json:"region,omitempty"`
+	Rank         int32   `protobuf:"varint,2,opt,name=rank,proto3" json:"rank,omitempty"`
+	Score        float64 `protobuf:"fixed64,3,opt,name=score,proto3" json:"score,omitempty"`
+	PricePerHour float64 `protobuf:"fixed64,4,opt,name=price_per_hour,json=pricePerHour,proto3" json:"price_per_hour,omitempty"`
+	DecidedBy    string  `protobuf:"bytes,5,opt,name=decided_by,json=decidedBy,proto3" json:"decided_by,omitempty"` // The criterion ranking the region above the next, empty for the last
+}
+
+func (x *RankedRegion) Reset() {
+	*x = RankedRegion{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_advisor_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RankedRegion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RankedRegion) ProtoMessage() {}
+
+func (x *RankedRegion) ProtoReflect() protoreflect.Message {
+	mi := &file_advisor_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RankedRegion.ProtoReflect.Descriptor instead.
+func (*RankedRegion) Descriptor() ([]byte, []int) {
+	return file_advisor_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *RankedRegion) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+func (x *RankedRegion) GetRank() int32 {
+	if x != nil {
+		return x.Rank
+	}
+	return 0
+}
+
+func (x *RankedRegion) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *RankedRegion) GetPricePerHour() float64 {
+	if x != nil {
+		return x.PricePerHour
+	}
+	return 0
+}
+
+func (x *RankedRegion) GetDecidedBy() string {
+	if x != nil {
+		return x.DecidedBy
+	}
+	return ""
+}
+
+type Advice struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Regions map[string]*RegionAdvice `protobuf:"bytes,1,rep,name=regions,proto3" json:"regions,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Ranking []*RankedRegion          `protobuf:"bytes,2,rep,name=ranking,proto3" json:"ranking,omitempty"` // The ranked regions, best first
+}
+
+func (x *Advice) Reset() {
+	*x = Advice{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_advisor_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Advice) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Advice) ProtoMessage() {}
+
+func (x *Advice) ProtoReflect() protoreflect.Message {
+	mi := &file_advisor_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Advice.ProtoReflect.Descriptor instead.
+func (*Advice) Descriptor() ([]byte, []int) {
+	return file_advisor_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *Advice) GetRegions() map[string]*RegionAdvice {
+	if x != nil {
+		return x.Regions
+	}
+	return nil
+}
+
+func (x *Advice) GetRanking() []*RankedRegion {
+	if x != nil {
+		return x.Ranking
+	}
+	return nil
+}
+
+type RegionAdviceEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Region    string        `protobuf:"bytes,1,opt,name=region,proto3" json:"region,omitempty"`
+	Advice    *RegionAdvice `protobuf:"bytes,2,opt,name=advice,proto3" json:"advice,omitempty"` // Unset if advice could not be generated
+	Error     string        `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	Completed int32         `protobuf:"varint,4,opt,name=completed,proto3" json:"completed,omitempty"`
+	Total     int32         `protobuf:"varint,5,opt,name=total,proto3" json:"total,omitempty"`
+}
+
+func (x *RegionAdviceEvent) Reset() {
+	*x = RegionAdviceEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_advisor_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RegionAdviceEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegionAdviceEvent) ProtoMessage() {}
+
+func (x *RegionAdviceEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_advisor_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegionAdviceEvent.ProtoReflect.Descriptor instead.
+func (*RegionAdviceEvent) Descriptor() ([]byte, []int) {
+	return file_advisor_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *RegionAdviceEvent) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+func (x *RegionAdviceEvent) GetAdvice() *RegionAdvice {
+	if x != nil {
+		return x.Advice
+	}
+	return nil
+}
+
+func (x *RegionAdviceEvent) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *RegionAdviceEvent) GetCompleted() int32 {
+	if x != nil {
+		return x.Completed
+	}
+	return 0
+}
+
+func (x *RegionAdviceEvent) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+type RegionsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *RegionsRequest) Reset() {
+	*x = RegionsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_advisor_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RegionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegionsRequest) ProtoMessage() {}
+
+func (x *RegionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_advisor_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegionsRequest.ProtoReflect.Descriptor instead.
+func (*RegionsRequest) Descriptor() ([]byte, []int) {
+	return file_advisor_proto_rawDescGZIP(), []int{16}
+}
+
+type RegionsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Regions []string `protobuf:"bytes,1,rep,name=regions,proto3" json:"regions,omitempty"`
+}
+
+func (x *RegionsResponse) Reset() {
+	*x = RegionsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_advisor_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RegionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegionsResponse) ProtoMessage() {}
+
+func (x *RegionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_advisor_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegionsResponse.ProtoReflect.Descriptor instead.
+func (*RegionsResponse) Descriptor() ([]byte, []int) {
+	return file_advisor_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *RegionsResponse) GetRegions() []string {
+	if x != nil {
+		return x.Regions
+	}
+	return nil
+}
+
+type ListInstancesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Regions    []string `protobuf:"bytes,1,rep,name=regions,proto3" json:"regions,omitempty"`
+	Market     string   `protobuf:"bytes,2,opt,name=market,proto3" json:"market,omitempty"`
+	Os         string   `protobuf:"bytes,3,opt,name=os,proto3" json:"os,omitempty"`
+	Family     string   `protobuf:"bytes,4,opt,name=family,proto3" json:"family,omitempty"`
+	MinMemory  float64  `protobuf:"fixed64,5,opt,name=min_memory,json=minMemory,proto3" json:"min_memory,omitempty"`
+	MinVcpu    int32    `protobuf:"varint,6,opt,name=min_vcpu,json=minVcpu,proto3" json:"min_vcpu,omitempty"`
+	MaxVcpu    int32    `protobuf:"varint,7,opt,name=max_vcpu,json=maxVcpu,proto3" json:"max_vcpu,omitempty"`
+	MinPrice   float64  `protobuf:"fixed64,8,opt,name=min_price,json=minPrice,proto3" json:"min_price,omitempty"`
+	MaxPrice   float64  `protobuf:"fixed64,9,opt,name=max_price,json=maxPrice,proto3" json:"max_price,omitempty"`
+	Sort       string   `protobuf:"bytes,10,opt,name=sort,proto3" json:"sort,omitempty"`
+	Descending bool     `protobuf:"varint,11,opt,name=descending,proto3" json:"descending,omitempty"`
+	Page       int32    `protobuf:"varint,12,opt,name=page,proto3" json:"page,omitempty"`                         // Defaults to 1
+	PageSize   int32    `protobuf:"varint,13,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"` // Defaults to 50
+}
+
+func (x *ListInstancesRequest) Reset() {
+	*x = ListInstancesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_advisor_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListInstancesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListInstancesRequest) ProtoMessage() {}
+
+func (x *ListInstancesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_advisor_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListInstancesRequest.ProtoReflect.Descriptor instead.
+func (*ListInstancesRequest) Descriptor() ([]byte, []int) {
+	return file_advisor_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ListInstancesRequest) GetRegions() []string {
+	if x != nil {
+		return x.Regions
+	}
+	return nil
+}
+
+func (x *ListInstancesRequest) GetMarket() string {
+	if x != nil {
+		return x.Market
+	}
+	return ""
+}
+
+func (x *ListInstancesRequest) GetOs() string {
+	if x != nil {
+		return x.Os
+	}
+	return ""
+}
+
+func (x *ListInstancesRequest) GetFamily() string {
+	if x != nil {
+		return x.Family
+	}
+	return ""
+}
+
+func (x *ListInstancesRequest) GetMinMemory() float64 {
+	if x != nil {
+		return x.MinMemory
+	}
+	return 0
+}
+
+func (x *ListInstancesRequest) GetMinVcpu() int32 {
+	if x != nil {
+		return x.MinVcpu
+	}
+	return 0
+}
+
+func (x *ListInstancesRequest) GetMaxVcpu() int32 {
+	if x != nil {
+		return x.MaxVcpu
+	}
+	return 0
+}
+
+func (x *ListInstancesRequest) GetMinPrice() float64 {
+	if x != nil {
+		return x.MinPrice
+	}
+	return 0
+}
+
+func (x *ListInstancesRequest) GetMaxPrice() float64 {
+	if x != nil {
+		return x.MaxPrice
+	}
+	return 0
+}
+
+func (x *ListInstancesRequest) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+func (x *ListInstancesRequest) GetDescending() bool {
+	if x != nil {
+		return x.Descending
+	}
+	return false
+}
+
+func (x *ListInstancesRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListInstancesRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+type CatalogueInstance struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Instance *Instance `protobuf:"bytes,1,opt,name=instance,proto3" json:"instance,omitempty"`
+	Market   string    `protobuf:"bytes,2,opt,name=market,proto3" json:"market,omitempty"`
+}
+
+func (x *CatalogueInstance) Reset() {
+	*x = CatalogueInstance{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_advisor_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CatalogueInstance) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CatalogueInstance) ProtoMessage() {}
+
+func (x *CatalogueInstance) ProtoReflect() protoreflect.Message {
+	mi := &file_advisor_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CatalogueInstance.ProtoReflect.Descriptor instead.
+func (*CatalogueInstance) Descriptor() ([]byte, []int) {
+	return file_advisor_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *CatalogueInstance) GetInstance() *Instance {
+	if x != nil {
+		return x.Instance
+	}
+	return nil
+}
+
+func (x *CatalogueInstance) GetMarket() string {
+	if x != nil {
+		return x.Market
+	}
+	return ""
+}
+
+type ListInstancesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Instances []*CatalogueInstance `protobuf:"bytes,1,rep,name=instances,proto3" json:"instances,omitempty"`
+	Total     int32                `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	Page      int32                `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize  int32                `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+}
+
+func (x *ListInstancesResponse) Reset() {
+	*x = ListInstancesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_advisor_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListInstancesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListInstancesResponse) ProtoMessage() {}
+
+func (x *ListInstancesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_advisor_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListInstancesResponse.ProtoReflect.Descriptor instead.
+func (*ListInstancesResponse) Descriptor() ([]byte, []int) {
+	return file_advisor_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ListInstancesResponse) GetInstances() []*CatalogueInstance {
+	if x != nil {
+		return x.Instances
+	}
+	return nil
+}
+
+func (x *ListInstancesResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ListInstancesResponse) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListInstancesResponse) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+type Aggregates struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Count         int32   `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+	MinVcpu       int32   `protobuf:"varint,2,opt,name=min_vcpu,json=minVcpu,proto3" json:"min_vcpu,omitempty"`
+	MaxVcpu       int32   `protobuf:"varint,3,opt,name=max_vcpu,json=maxVcpu,proto3" json:"max_vcpu,omitempty"`
+	MeanVcpu      float64 `protobuf:"fixed64,4,opt,name=mean_vcpu,json=meanVcpu,proto3" json:"mean_vcpu,omitempty"`
+	MinRevocProb  float64 `protobuf:"fixed64,5,opt,name=min_revoc_prob,json=minRevocProb,proto3" json:"min_revoc_prob,omitempty"`
+	MaxRevocProb  float64 `protobuf:"fixed64,6,opt,name=max_revoc_prob,json=maxRevocProb,proto3" json:"max_revoc_prob,omitempty"`
+	MeanRevocProb float64 `protobuf:"fixed64,7,opt,name=mean_revoc_prob,json=meanRevocProb,proto3" json:"mean_revoc_prob,omitempty"`
+	MinPrice      float64 `protobuf:"fixed64,8,opt,name=min_price,json=minPrice,proto3" json:"min_price,omitempty"`
+	MaxPrice      float64 `protobuf:"fixed64,9,opt,name=max_price,json=maxPrice,proto3" json:"max_price,omitempty"`
+	MeanPrice     float64 `protobuf:"fixed64,10,opt,name=mean_price,json=meanPrice,proto3" json:"mean_price,omitempty"`
+}
+
+func (x *Aggregates) Reset() {
+	*x = Aggregates{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_advisor_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Aggregates) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Aggregates) ProtoMessage() {}
+
+func (x *Aggregates) ProtoReflect() protoreflect.Message {
+	mi := &file_advisor_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Aggregates.ProtoReflect.Descriptor instead.
+func (*Aggregates) Descriptor() ([]byte, []int) {
+	return file_advisor_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *Aggregates) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *Aggregates) GetMinVcpu() int32 {
+	if x != nil {
+		return x.MinVcpu
+	}
+	return 0
+}
+
+func (x *Aggregates) GetMaxVcpu() int32 {
+	if x != nil {
+		return x.MaxVcpu
+	}
+	return 0
+}
+
+func (x *Aggregates) GetMeanVcpu() float64 {
+	if x != nil {
+		return x.MeanVcpu
+	}
+	return 0
+}
+
+func (x *Aggregates) GetMinRevocProb() float64 {
+	if x != nil {
+		return x.MinRevocProb
+	}
+	return 0
+}
+
+func (x *Aggregates) GetMaxRevocProb() float64 {
+	if x != nil {
+		return x.MaxRevocProb
+	}
+	return 0
+}
+
+func (x *Aggregates) GetMeanRevocProb() float64 {
+	if x != nil {
+		return x.MeanRevocProb
+	}
+	return 0
+}
+
+func (x *Aggregates) GetMinPrice() float64 {
+	if x != nil {
+		return x.MinPrice
+	}
+	return 0
+}
+
+func (x *Aggregates) GetMaxPrice() float64 {
+	if x != nil {
+		return x.MaxPrice
+	}
+	return 0
+}
+
+func (x *Aggregates) GetMeanPrice() float64 {
+	if x != nil {
+		return x.MeanPrice
+	}
+	return 0
+}
+
+type RegionAggregates struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	OnDemand *Aggregates `protobuf:"bytes,1,opt,name=on_demand,json=onDemand,proto3" json:"on_demand,omitempty"`
+	Spot     *Aggregates `protobuf:"bytes,2,opt,name=spot,proto3" json:"spot,omitempty"`
+	Region   *Aggregates `protobuf:"bytes,3,opt,name=region,proto3" json:"region,omitempty"`
+}
+
+func (x *RegionAggregates) Reset() {
+	*x = RegionAggregates{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_advisor_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RegionAggregates) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegionAggregates) ProtoMessage() {}
+
+func (x *RegionAggregates) ProtoReflect() protoreflect.Message {
+	mi := &file_advisor_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegionAggregates.ProtoReflect.Descriptor instead.
+func (*RegionAggregates) Descriptor() ([]byte, []int) {
+	return file_advisor_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *RegionAggregates) GetOnDemand() *Aggregates {
+	if x != nil {
+		return x.OnDemand
+	}
+	return nil
+}
+
+func (x *RegionAggregates) GetSpot() *Aggregates {
+	if x != nil {
+		return x.Spot
+	}
+	return nil
+}
+
+func (x *RegionAggregates) GetRegion() *Aggregates {
+	if x != nil {
+		return x.Region
+	}
+	return nil
+}
+
+type AggregatesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *AggregatesRequest) Reset() {
+	*x = AggregatesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_advisor_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AggregatesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AggregatesRequest) ProtoMessage() {}
+
+func (x *AggregatesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_advisor_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AggregatesRequest.ProtoReflect.Descriptor instead.
+func (*AggregatesRequest) Descriptor() ([]byte, []int) {
+	return file_advisor_proto_rawDescGZIP(), []int{23}
+}
+
+type AggregatesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Global  *Aggregates                  `protobuf:"bytes,1,opt,name=global,proto3" json:"global,omitempty"`
+	Regions map[string]*RegionAggregates `protobuf:"bytes,2,rep,name=regions,proto3" json:"regions,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *AggregatesResponse) Reset() {
+	*x = AggregatesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_advisor_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AggregatesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AggregatesResponse) ProtoMessage() {}
+
+func (x *AggregatesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_advisor_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AggregatesResponse.ProtoReflect.Descriptor instead.
+func (*AggregatesResponse) Descriptor() ([]byte, []int) {
+	return file_advisor_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *AggregatesResponse) GetGlobal() *Aggregates {
+	if x != nil {
+		return x.Global
+	}
+	return nil
+}
+
+func (x *AggregatesResponse) GetRegions() map[string]*RegionAggregates {
+	if x != nil {
+		return x.Regions
+	}
+	return nil
+}
+
+var File_advisor_proto protoreflect.FileDescriptor
+
+var file_advisor_proto_rawDesc = []byte{
+	0x0a, 0x0d, 0x61, 0x64, 0x76, 0x69, 0x73, 0x6f, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12,
+	0x0a, 0x61, 0x64, 0x76, 0x69, 0x73, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x1a, 0x1f, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d,
+	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xce, 0x02, 0x0a,
+	0x07, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1d, 0x0a, 0x0a,
+	0x6d, 0x69, 0x6e, 0x5f, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01,
+	0x52, 0x09, 0x6d, 0x69, 0x6e, 0x4d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x12, 0x19, 0x0a, 0x08, 0x6d,
+	0x61, 0x78, 0x5f, 0x76, 0x63, 0x70, 0x75, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x6d,
+	0x61, 0x78, 0x56, 0x63, 0x70, 0x75, 0x12, 0x23, 0x0a, 0x0d, 0x6d, 0x69, 0x6e, 0x5f, 0x69, 0x6e,
+	0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c, 0x6d,
+	0x69, 0x6e, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x6d,
+	0x61, 0x78, 0x5f, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x0c, 0x6d, 0x61, 0x78, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x73,
+	0x12, 0x19, 0x0a, 0x08, 0x6d, 0x69, 0x6e, 0x5f, 0x76, 0x63, 0x70, 0x75, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x07, 0x6d, 0x69, 0x6e, 0x56, 0x63, 0x70, 0x75, 0x12, 0x1f, 0x0a, 0x0b, 0x6d,
+	0x69, 0x6e, 0x5f, 0x72, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x0a, 0x6d, 0x69, 0x6e, 0x52, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x33, 0x0a, 0x09,
+	0x70, 0x6c, 0x61, 0x63, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x15, 0x2e, 0x61, 0x64, 0x76, 0x69, 0x73, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x6c, 0x61,
+	0x63, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x09, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x6d, 0x65, 0x6e,
+	0x74, 0x12, 0x3a, 0x0a, 0x0c, 0x6c, 0x6f, 0x61, 0x64, 0x5f, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c,
+	0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x61, 0x64, 0x76, 0x69, 0x73, 0x6f,
+	0x72, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x6f, 0x61, 0x64, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65,
+	0x52, 0x0b, 0x6c, 0x6f, 0x61, 0x64, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x22, 0x6e, 0x0a,
+	0x09, 0x50, 0x6c, 0x61, 0x63, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x72, 0x65,
+	0x67, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x72, 0x65, 0x67,
+	0x69, 0x6f, 0x6e, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x5f,
+	0x66, 0x72, 0x6f, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x6c, 0x61, 0x74, 0x65,
+	0x6e, 0x63, 0x79, 0x46, 0x72, 0x6f, 0x6d, 0x12, 0x24, 0x0a, 0x0e, 0x6d, 0x61, 0x78, 0x5f, 0x6c,
+	0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x5f, 0x6d, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52,
+	0x0c, 0x6d, 0x61, 0x78, 0x4c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x4d, 0x73, 0x22, 0x43, 0x0a,
+	0x0b, 0x4c, 0x6f, 0x61, 0x64, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x12, 0x16, 0x0a, 0x06,
+	0x70, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x65,
+	0x72, 0x69, 0x6f, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65,
+	0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x05, 0x52, 0x09, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63,
+	0x65, 0x73, 0x22, 0x6c, 0x0a, 0x0e, 0x41, 0x64, 0x76, 0x69, 0x73, 0x6f, 0x72, 0x57, 0x65, 0x69,
+	0x67, 0x68, 0x74, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x72, 0x69, 0x63, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x01, 0x52, 0x05, 0x70, 0x72, 0x69, 0x63, 0x65, 0x12, 0x22, 0x0a, 0x0c, 0x61, 0x76,
+	0x61, 0x69, 0x6c, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01,
+	0x52, 0x0c, 0x61, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x12, 0x20,
+	0x0a, 0x0b, 0x70, 0x65, 0x72, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x01, 0x52, 0x0b, 0x70, 0x65, 0x72, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65,
+	0x22, 0x53, 0x0a, 0x07, 0x41, 0x64, 0x76, 0x69, 0x73, 0x6f, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x74,
+	0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12,
+	0x34, 0x0a, 0x07, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1a, 0x2e, 0x61, 0x64, 0x76, 0x69, 0x73, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x64,
+	0x76, 0x69, 0x73, 0x6f, 0x72, 0x57, 0x65, 0x69, 0x67, 0x68, 0x74, 0x73, 0x52, 0x07, 0x77, 0x65,
+	0x69, 0x67, 0x68, 0x74, 0x73, 0x22, 0x93, 0x03, 0x0a, 0x07, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x12, 0x41, 0x0a, 0x1d, 0x61, 0x76, 0x6f, 0x69, 0x64, 0x5f, 0x72, 0x65, 0x70, 0x65, 0x61,
+	0x74, 0x65, 0x64, 0x5f, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x5f, 0x74, 0x79, 0x70,
+	0x65, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x1a, 0x61, 0x76, 0x6f, 0x69, 0x64, 0x52,
+	0x65, 0x70, 0x65, 0x61, 0x74, 0x65, 0x64, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x54,
+	0x79, 0x70, 0x65, 0x73, 0x12, 0x47, 0x0a, 0x20, 0x73, 0x68, 0x61, 0x72, 0x65, 0x5f, 0x69, 0x6e,
+	0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x5f, 0x62, 0x65, 0x74, 0x77, 0x65, 0x65, 0x6e, 0x5f,
+	0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x1d,
+	0x73, 0x68, 0x61, 0x72, 0x65, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x42, 0x65,
+	0x74, 0x77, 0x65, 0x65, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x12, 0x36, 0x0a,
+	0x17, 0x63, 0x6f, 0x6e, 0x73, 0x69, 0x64, 0x65, 0x72, 0x5f, 0x66, 0x72, 0x65, 0x65, 0x5f, 0x69,
+	0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x15,
+	0x63, 0x6f, 0x6e, 0x73, 0x69, 0x64, 0x65, 0x72, 0x46, 0x72, 0x65, 0x65, 0x49, 0x6e, 0x73, 0x74,
+	0x61, 0x6e, 0x63, 0x65, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x72, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x73,
+	0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x72, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x73, 0x12,
+	0x32, 0x0a, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1a, 0x2e, 0x61, 0x64, 0x76, 0x69, 0x73, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x49, 0x6e, 0x73,
+	0x74, 0x61, 0x6e, 0x63, 0x65, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x52, 0x06, 0x66, 0x69, 0x6c,
+	0x74, 0x65, 0x72, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x72, 0x6f, 0x73, 0x73, 0x5f, 0x72, 0x65, 0x67,
+	0x69, 0x6f, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x63, 0x72, 0x6f, 0x73, 0x73,
+	0x52, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x12, 0x20, 0x0a, 0x0b, 0x67, 0x65, 0x6f, 0x67, 0x72, 0x61,
+	0x70, 0x68, 0x69, 0x65, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x67, 0x65, 0x6f,
+	0x67, 0x72, 0x61, 0x70, 0x68, 0x69, 0x65, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x61, 0x63, 0x6b,
+	0x69, 0x6e, 0x67, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x70, 0x61, 0x63, 0x6b, 0x69,
+	0x6e, 0x67, 0x12, 0x17, 0x0a, 0x07, 0x72, 0x61, 0x6e, 0x6b, 0x5f, 0x62, 0x79, 0x18, 0x09, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x06, 0x72, 0x61, 0x6e, 0x6b, 0x42, 0x79, 0x22, 0xaa, 0x01, 0x0a, 0x0e,
+	0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x0e,
+	0x0a, 0x02, 0x6f, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x6f, 0x73, 0x12, 0x16,
+	0x0a, 0x06, 0x66, 0x61, 0x6d, 0x69, 0x6c, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x66, 0x61, 0x6d, 0x69, 0x6c, 0x79, 0x12, 0x1d, 0x0a, 0x0a, 0x6d, 0x69, 0x6e, 0x5f, 0x6d, 0x65,
+	0x6d, 0x6f, 0x72, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x09, 0x6d, 0x69, 0x6e, 0x4d,
+	0x65, 0x6d, 0x6f, 0x72, 0x79, 0x12, 0x19, 0x0a, 0x08, 0x6d, 0x69, 0x6e, 0x5f, 0x76, 0x63, 0x70,
+	0x75, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x6d, 0x69, 0x6e, 0x56, 0x63, 0x70, 0x75,
+	0x12, 0x19, 0x0a, 0x08, 0x6d, 0x61, 0x78, 0x5f, 0x76, 0x63, 0x70, 0x75, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x07, 0x6d, 0x61, 0x78, 0x56, 0x63, 0x70, 0x75, 0x12, 0x1b, 0x0a, 0x09, 0x6d,
+	0x61, 0x78, 0x5f, 0x70, 0x72, 0x69, 0x63, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x01, 0x52, 0x08,
+	0x6d, 0x61, 0x78, 0x50, 0x72, 0x69, 0x63, 0x65, 0x22, 0xe7, 0x01, 0x0a, 0x0d, 0x41, 0x64, 0x76,
+	0x69, 0x73, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2f, 0x0a, 0x08, 0x73, 0x65,
+	0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x61,
+	0x64, 0x76, 0x69, 0x73, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x52, 0x08, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x12, 0x2d, 0x0a, 0x07, 0x61,
+	0x64, 0x76, 0x69, 0x73, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x61,
+	0x64, 0x76, 0x69, 0x73, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x64, 0x76, 0x69, 0x73, 0x6f,
+	0x72, 0x52, 0x07, 0x61, 0x64, 0x76, 0x69, 0x73, 0x6f, 0x72, 0x12, 0x2d, 0x0a, 0x07, 0x6f, 0x70,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x61, 0x64,
+	0x76, 0x69, 0x73, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x52, 0x07, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x2f, 0x0a, 0x05, 0x61, 0x73, 0x5f,
+	0x6f, 0x66, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73,
+	0x74, 0x61, 0x6d, 0x70, 0x52, 0x04, 0x61, 0x73, 0x4f, 0x66, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x72,
+	0x65, 0x73, 0x65, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x72, 0x65, 0x73,
+	0x65, 0x74, 0x22, 0xc7, 0x01, 0x0a, 0x08, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x12,
+	0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12,
+	0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x01, 0x52, 0x06, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x76,
+	0x63, 0x70, 0x75, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x76, 0x63, 0x70, 0x75, 0x12,
+	0x16, 0x0a, 0x06, 0x72, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x72, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x12, 0x0e, 0x0a, 0x02, 0x61, 0x7a, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x02, 0x61, 0x7a, 0x12, 0x0e, 0x0a, 0x02, 0x6f, 0x73, 0x18, 0x07, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x02, 0x6f, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x72, 0x69, 0x63, 0x65,
+	0x18, 0x08, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x70, 0x72, 0x69, 0x63, 0x65, 0x12, 0x1d, 0x0a,
+	0x0a, 0x72, 0x65, 0x76, 0x6f, 0x63, 0x5f, 0x70, 0x72, 0x6f, 0x62, 0x18, 0x09, 0x20, 0x01, 0x28,
+	0x01, 0x52, 0x09, 0x72, 0x65, 0x76, 0x6f, 0x63, 0x50, 0x72, 0x6f, 0x62, 0x22, 0x1f, 0x0a, 0x0b,
+	0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x49, 0x64, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x69,
+	0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x03, 0x69, 0x64, 0x73, 0x22, 0x24, 0x0a,
+	0x0c, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x12, 0x14, 0x0a,
+	0x05, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x6e, 0x61,
+	0x6d, 0x65, 0x73, 0x22, 0x9c, 0x03, 0x0a, 0x0b, 0x41, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x6d, 0x65,
+	0x6e, 0x74, 0x73, 0x12, 0x64, 0x0a, 0x15, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x5f,
+	0x74, 0x6f, 0x5f, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x30, 0x2e, 0x61, 0x64, 0x76, 0x69, 0x73, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x2e,
+	0x41, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x2e, 0x53, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x73, 0x54, 0x6f, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x52, 0x13, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x54, 0x6f,
+	0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x12, 0x64, 0x0a, 0x15, 0x69, 0x6e, 0x73,
+	0x74, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x5f, 0x74, 0x6f, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x30, 0x2e, 0x61, 0x64, 0x76, 0x69, 0x73,
+	0x6f, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x6d, 0x65, 0x6e, 0x74,
+	0x73, 0x2e, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x54, 0x6f, 0x53, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x13, 0x69, 0x6e, 0x73, 0x74,
+	0x61, 0x6e, 0x63, 0x65, 0x73, 0x54, 0x6f, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x1a,
+	0x5f, 0x0a, 0x18, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x54, 0x6f, 0x49, 0x6e, 0x73,
+	0x74, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b,
+	0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x2d, 0x0a,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x61,
+	0x64, 0x76, 0x69, 0x73, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e,
+	0x63, 0x65, 0x49, 0x64, 0x73, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01,
+	0x1a, 0x60, 0x0a, 0x18, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x54, 0x6f, 0x53,
+	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03,
+	0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x2e,
+	0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e,
+	0x61, 0x64, 0x76, 0x69, 0x73, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02,
+	0x38, 0x01, 0x22, 0x8e, 0x02, 0x0a, 0x0c, 0x52, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x41, 0x64, 0x76,
+	0x69, 0x63, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x01, 0x52, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x12, 0x45, 0x0a, 0x09, 0x69, 0x6e, 0x73,
+	0x74, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x27, 0x2e, 0x61,
+	0x64, 0x76, 0x69, 0x73, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x67, 0x69, 0x6f, 0x6e,
+	0x41, 0x64, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x73,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x09, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x73,
+	0x12, 0x39, 0x0a, 0x0b, 0x61, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x61, 0x64, 0x76, 0x69, 0x73, 0x6f, 0x72, 0x2e,
+	0x76, 0x31, 0x2e, 0x41, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x0b,
+	0x61, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x72,
+	0x61, 0x6e, 0x6b, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x72, 0x61, 0x6e, 0x6b, 0x1a,
+	0x52, 0x0a, 0x0e, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
+	0x6b, 0x65, 0x79, 0x12, 0x2a, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x14, 0x2e, 0x61, 0x64, 0x76, 0x69, 0x73, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x2e,
+	0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a,
+	0x02, 0x38, 0x01, 0x22, 0x95, 0x01, 0x0a, 0x0c, 0x52, 0x61, 0x6e, 0x6b, 0x65, 0x64, 0x52, 0x65,
+	0x67, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04,
+	0x72, 0x61, 0x6e, 0x6b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x72, 0x61, 0x6e, 0x6b,
+	0x12, 0x14, 0x0a, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52,
+	0x05, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x12, 0x24, 0x0a, 0x0e, 0x70, 0x72, 0x69, 0x63, 0x65, 0x5f,
+	0x70, 0x65, 0x72, 0x5f, 0x68, 0x6f, 0x75, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0c,
+	0x70, 0x72, 0x69, 0x63, 0x65, 0x50, 0x65, 0x72, 0x48, 0x6f, 0x75, 0x72, 0x12, 0x1d, 0x0a, 0x0a,
+	0x64, 0x65, 0x63, 0x69, 0x64, 0x65, 0x64, 0x5f, 0x62, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x09, 0x64, 0x65, 0x63, 0x69, 0x64, 0x65, 0x64, 0x42, 0x79, 0x22, 0xcd, 0x01, 0x0a, 0x06,
+	0x41, 0x64, 0x76, 0x69, 0x63, 0x65, 0x12, 0x39, 0x0a, 0x07, 0x72, 0x65, 0x67, 0x69, 0x6f, 0x6e,
+	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x61, 0x64, 0x76, 0x69, 0x73, 0x6f,
+	0x72, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x64, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x52, 0x65, 0x67, 0x69,
+	0x6f, 0x6e, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x72, 0x65, 0x67, 0x69, 0x6f, 0x6e,
+	0x73, 0x12, 0x32, 0x0a, 0x07, 0x72, 0x61, 0x6e, 0x6b, 0x69, 0x6e, 0x67, 0x18, 0x02, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x18, 0x2e, 0x61, 0x64, 0x76, 0x69, 0x73, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x2e,
+	0x52, 0x61, 0x6e, 0x6b, 0x65, 0x64, 0x52, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x52, 0x07, 0x72, 0x61,
+	0x6e, 0x6b, 0x69, 0x6e, 0x67, 0x1a, 0x54, 0x0a, 0x0c, 0x52, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x73,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x2e, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x61, 0x64, 0x76, 0x69, 0x73, 0x6f, 0x72,
+	0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x41, 0x64, 0x76, 0x69, 0x63, 0x65,
+	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xa7, 0x01, 0x0a, 0x11,
+	0x52, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x41, 0x64, 0x76, 0x69, 0x63, 0x65, 0x45, 0x76, 0x65, 0x6e,
+	0x74, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x72, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x12, 0x30, 0x0a, 0x06, 0x61, 0x64, 0x76,
+	0x69, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x61, 0x64, 0x76, 0x69,
+	0x73, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x41, 0x64, 0x76,
+	0x69, 0x63, 0x65, 0x52, 0x06, 0x61, 0x64, 0x76, 0x69, 0x63, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x65,
+	0x72, 0x72, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f,
+	0x72, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x12,
+	0x14, 0x0a, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05,
+	0x74, 0x6f, 0x74, 0x61, 0x6c, 0x22, 0x10, 0x0a, 0x0e, 0x52, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x2b, 0x0a, 0x0f, 0x52, 0x65, 0x67, 0x69, 0x6f,
+	0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x72, 0x65,
+	0x67, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x72, 0x65, 0x67,
+	0x69, 0x6f, 0x6e, 0x73, 0x22, 0xe4, 0x02, 0x0a, 0x14, 0x4c, 0x69, 0x73, 0x74, 0x49, 0x6e, 0x73,
+	0x74, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a,
+	0x07, 0x72, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07,
+	0x72, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x61, 0x72, 0x6b, 0x65,
+	0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6d, 0x61, 0x72, 0x6b, 0x65, 0x74, 0x12,
+	0x0e, 0x0a, 0x02, 0x6f, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x6f, 0x73, 0x12,
+	0x16, 0x0a, 0x06, 0x66, 0x61, 0x6d, 0x69, 0x6c, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x66, 0x61, 0x6d, 0x69, 0x6c, 0x79, 0x12, 0x1d, 0x0a, 0x0a, 0x6d, 0x69, 0x6e, 0x5f, 0x6d,
+	0x65, 0x6d, 0x6f, 0x72, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x52, 0x09, 0x6d, 0x69, 0x6e,
+	0x4d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x12, 0x19, 0x0a, 0x08, 0x6d, 0x69, 0x6e, 0x5f, 0x76, 0x63,
+	0x70, 0x75, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x6d, 0x69, 0x6e, 0x56, 0x63, 0x70,
+	0x75, 0x12, 0x19, 0x0a, 0x08, 0x6d, 0x61, 0x78, 0x5f, 0x76, 0x63, 0x70, 0x75, 0x18, 0x07, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x07, 0x6d, 0x61, 0x78, 0x56, 0x63, 0x70, 0x75, 0x12, 0x1b, 0x0a, 0x09,
+	0x6d, 0x69, 0x6e, 0x5f, 0x70, 0x72, 0x69, 0x63, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x01, 0x52,
+	0x08, 0x6d, 0x69, 0x6e, 0x50, 0x72, 0x69, 0x63, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x6d, 0x61, 0x78,
+	0x5f, 0x70, 0x72, 0x69, 0x63, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x01, 0x52, 0x08, 0x6d, 0x61,
+	0x78, 0x50, 0x72, 0x69, 0x63, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x6f, 0x72, 0x74, 0x18, 0x0a,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x73, 0x6f, 0x72, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x64, 0x65,
+	0x73, 0x63, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a,
+	0x64, 0x65, 0x73, 0x63, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61,
+	0x67, 0x65, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x70, 0x61, 0x67, 0x65, 0x12, 0x1b,
+	0x0a, 0x09, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x0d, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x08, 0x70, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x22, 0x5d, 0x0a, 0x11, 0x43,
+	0x61, 0x74, 0x61, 0x6c, 0x6f, 0x67, 0x75, 0x65, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65,
+	0x12, 0x30, 0x0a, 0x08, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x14, 0x2e, 0x61, 0x64, 0x76, 0x69, 0x73, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x2e,
+	0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x08, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e,
+	0x63, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x61, 0x72, 0x6b, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x6d, 0x61, 0x72, 0x6b, 0x65, 0x74, 0x22, 0x9b, 0x01, 0x0a, 0x15, 0x4c,
+	0x69, 0x73, 0x74, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3b, 0x0a, 0x09, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65,
+	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x61, 0x64, 0x76, 0x69, 0x73, 0x6f,
+	0x72, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x61, 0x74, 0x61, 0x6c, 0x6f, 0x67, 0x75, 0x65, 0x49, 0x6e,
+	0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x09, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65,
+	0x73, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x67, 0x65, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x70, 0x61, 0x67, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x70,
+	0x61, 0x67, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08,
+	0x70, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x22, 0xc2, 0x02, 0x0a, 0x0a, 0x41, 0x67, 0x67,
+	0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x19, 0x0a,
+	0x08, 0x6d, 0x69, 0x6e, 0x5f, 0x76, 0x63, 0x70, 0x75, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x07, 0x6d, 0x69, 0x6e, 0x56, 0x63, 0x70, 0x75, 0x12, 0x19, 0x0a, 0x08, 0x6d, 0x61, 0x78, 0x5f,
+	0x76, 0x63, 0x70, 0x75, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x6d, 0x61, 0x78, 0x56,
+	0x63, 0x70, 0x75, 0x12, 0x1b, 0x0a, 0x09, 0x6d, 0x65, 0x61, 0x6e, 0x5f, 0x76, 0x63, 0x70, 0x75,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x08, 0x6d, 0x65, 0x61, 0x6e, 0x56, 0x63, 0x70, 0x75,
+	0x12, 0x24, 0x0a, 0x0e, 0x6d, 0x69, 0x6e, 0x5f, 0x72, 0x65, 0x76, 0x6f, 0x63, 0x5f, 0x70, 0x72,
+	0x6f, 0x62, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0c, 0x6d, 0x69, 0x6e, 0x52, 0x65, 0x76,
+	0x6f, 0x63, 0x50, 0x72, 0x6f, 0x62, 0x12, 0x24, 0x0a, 0x0e, 0x6d, 0x61, 0x78, 0x5f, 0x72, 0x65,
+	0x76, 0x6f, 0x63, 0x5f, 0x70, 0x72, 0x6f, 0x62, 0x18, 0x06, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0c,
+	0x6d, 0x61, 0x78, 0x52, 0x65, 0x76, 0x6f, 0x63, 0x50, 0x72, 0x6f, 0x62, 0x12, 0x26, 0x0a, 0x0f,
+	0x6d, 0x65, 0x61, 0x6e, 0x5f, 0x72, 0x65, 0x76, 0x6f, 0x63, 0x5f, 0x70, 0x72, 0x6f, 0x62, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0d, 0x6d, 0x65, 0x61, 0x6e, 0x52, 0x65, 0x76, 0x6f, 0x63,
+	0x50, 0x72, 0x6f, 0x62, 0x12, 0x1b, 0x0a, 0x09, 0x6d, 0x69, 0x6e, 0x5f, 0x70, 0x72, 0x69, 0x63,
+	0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x01, 0x52, 0x08, 0x6d, 0x69, 0x6e, 0x50, 0x72, 0x69, 0x63,
+	0x65, 0x12, 0x1b, 0x0a, 0x09, 0x6d, 0x61, 0x78, 0x5f, 0x70, 0x72, 0x69, 0x63, 0x65, 0x18, 0x09,
+	0x20, 0x01, 0x28, 0x01, 0x52, 0x08, 0x6d, 0x61, 0x78, 0x50, 0x72, 0x69, 0x63, 0x65, 0x12, 0x1d,
+	0x0a, 0x0a, 0x6d, 0x65, 0x61, 0x6e, 0x5f, 0x70, 0x72, 0x69, 0x63, 0x65, 0x18, 0x0a, 0x20, 0x01,
+	0x28, 0x01, 0x52, 0x09, 0x6d, 0x65, 0x61, 0x6e, 0x50, 0x72, 0x69, 0x63, 0x65, 0x22, 0xa3, 0x01,
+	0x0a, 0x10, 0x52, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74,
+	0x65, 0x73, 0x12, 0x33, 0x0a, 0x09, 0x6f, 0x6e, 0x5f, 0x64, 0x65, 0x6d, 0x61, 0x6e, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x61, 0x64, 0x76, 0x69, 0x73, 0x6f, 0x72, 0x2e,
+	0x76, 0x31, 0x2e, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x73, 0x52, 0x08, 0x6f,
+	0x6e, 0x44, 0x65, 0x6d, 0x61, 0x6e, 0x64, 0x12, 0x2a, 0x0a, 0x04, 0x73, 0x70, 0x6f, 0x74, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x61, 0x64, 0x76, 0x69, 0x73, 0x6f, 0x72, 0x2e,
+	0x76, 0x31, 0x2e, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x73, 0x52, 0x04, 0x73,
+	0x70, 0x6f, 0x74, 0x12, 0x2e, 0x0a, 0x06, 0x72, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x61, 0x64, 0x76, 0x69, 0x73, 0x6f, 0x72, 0x2e, 0x76, 0x31,
+	0x2e, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x73, 0x52, 0x06, 0x72, 0x65, 0x67,
+	0x69, 0x6f, 0x6e, 0x22, 0x13, 0x0a, 0x11, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0xe5, 0x01, 0x0a, 0x12, 0x41, 0x67, 0x67,
+	0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x2e, 0x0a, 0x06, 0x67, 0x6c, 0x6f, 0x62, 0x61, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x16, 0x2e, 0x61, 0x64, 0x76, 0x69, 0x73, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x67, 0x67,
+	0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x73, 0x52, 0x06, 0x67, 0x6c, 0x6f, 0x62, 0x61, 0x6c, 0x12,
+	0x45, 0x0a, 0x07, 0x72, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x2b, 0x2e, 0x61, 0x64, 0x76, 0x69, 0x73, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x67,
+	0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x2e, 0x52, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x72,
+	0x65, 0x67, 0x69, 0x6f, 0x6e, 0x73, 0x1a, 0x58, 0x0a, 0x0c, 0x52, 0x65, 0x67, 0x69, 0x6f, 0x6e,
+	0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x32, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x61, 0x64, 0x76, 0x69, 0x73, 0x6f,
+	0x72, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x41, 0x67, 0x67, 0x72, 0x65,
+	0x67, 0x61, 0x74, 0x65, 0x73, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01,
+	0x32, 0x82, 0x03, 0x0a, 0x0e, 0x41, 0x64, 0x76, 0x69, 0x73, 0x6f, 0x72, 0x53, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x12, 0x37, 0x0a, 0x06, 0x41, 0x64, 0x76, 0x69, 0x73, 0x65, 0x12, 0x19, 0x2e,
+	0x61, 0x64, 0x76, 0x69, 0x73, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x64, 0x76, 0x69, 0x73,
+	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x12, 0x2e, 0x61, 0x64, 0x76, 0x69, 0x73,
+	0x6f, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x64, 0x76, 0x69, 0x63, 0x65, 0x12, 0x4a, 0x0a, 0x0c,
+	0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x41, 0x64, 0x76, 0x69, 0x63, 0x65, 0x12, 0x19, 0x2e, 0x61,
+	0x64, 0x76, 0x69, 0x73, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x64, 0x76, 0x69, 0x73, 0x65,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x61, 0x64, 0x76, 0x69, 0x73, 0x6f,
+	0x72, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x41, 0x64, 0x76, 0x69, 0x63,
+	0x65, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x30, 0x01, 0x12, 0x45, 0x0a, 0x0a, 0x47, 0x65, 0x74, 0x52,
+	0x65, 0x67, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x1a, 0x2e, 0x61, 0x64, 0x76, 0x69, 0x73, 0x6f, 0x72,
+	0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x61, 0x64, 0x76, 0x69, 0x73, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x2e,
+	0x52, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x54, 0x0a, 0x0d, 0x4c, 0x69, 0x73, 0x74, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x73,
+	0x12, 0x20, 0x2e, 0x61, 0x64, 0x76, 0x69, 0x73, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69,
+	0x73, 0x74, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x21, 0x2e, 0x61, 0x64, 0x76, 0x69, 0x73, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x2e,
+	0x4c, 0x69, 0x73, 0x74, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4e, 0x0a, 0x0d, 0x47, 0x65, 0x74, 0x41, 0x67, 0x67, 0x72,
+	0x65, 0x67, 0x61, 0x74, 0x65, 0x73, 0x12, 0x1d, 0x2e, 0x61, 0x64, 0x76, 0x69, 0x73, 0x6f, 0x72,
+	0x2e, 0x76, 0x31, 0x2e, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x61, 0x64, 0x76, 0x69, 0x73, 0x6f, 0x72, 0x2e,
+	0x76, 0x31, 0x2e, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x2a, 0x5a, 0x28, 0x61, 0x77, 0x73, 0x2d, 0x62, 0x6c, 0x65,
+	0x6e, 0x64, 0x65, 0x64, 0x2d, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x2d, 0x61,
+	0x64, 0x76, 0x69, 0x73, 0x6f, 0x72, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x72, 0x70, 0x63, 0x2f, 0x70,
+	0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_advisor_proto_rawDescOnce sync.Once
+	file_advisor_proto_rawDescData = file_advisor_proto_rawDesc
+)
+
+func file_advisor_proto_rawDescGZIP() []byte {
+	file_advisor_proto_rawDescOnce.Do(func() {
+		file_advisor_proto_rawDescData = protoimpl.X.CompressGZIP(file_advisor_proto_rawDescData)
+	})
+	return file_advisor_proto_rawDescData
+}
+
+var file_advisor_proto_msgTypes = make([]protoimpl.MessageInfo, 30)
+var file_advisor_proto_goTypes = []interface{}{
+	(*Service)(nil),               // 0: advisor.v1.Service
+	(*Placement)(nil),             // 1: advisor.v1.Placement
+	(*LoadProfile)(nil),           // 2: advisor.v1.LoadProfile
+	(*AdvisorWeights)(nil),        // 3: advisor.v1.AdvisorWeights
+	(*Advisor)(nil),               // 4: advisor.v1.Advisor
+	(*Options)(nil),               // 5: advisor.v1.Options
+	(*InstanceFilter)(nil),        // 6: advisor.v1.InstanceFilter
+	(*AdviseRequest)(nil),         // 7: advisor.v1.AdviseRequest
+	(*Instance)(nil),              // 8: advisor.v1.Instance
+	(*InstanceIds)(nil),           // 9: advisor.v1.InstanceIds
+	(*ServiceNames)(nil),          // 10: advisor.v1.ServiceNames
+	(*Assignments)(nil),           // 11: advisor.v1.Assignments
+	(*RegionAdvice)(nil),          // 12: advisor.v1.RegionAdvice
+	(*RankedRegion)(nil),          // 13: advisor.v1.RankedRegion
+	(*Advice)(nil),                // 14: advisor.v1.Advice
+	(*RegionAdviceEvent)(nil),     // 15: advisor.v1.RegionAdviceEvent
+	(*RegionsRequest)(nil),        // 16: advisor.v1.RegionsRequest
+	(*RegionsResponse)(nil),       // 17: advisor.v1.RegionsResponse
+	(*ListInstancesRequest)(nil),  // 18: advisor.v1.ListInstancesRequest
+	(*CatalogueInstance)(nil),     // 19: advisor.v1.CatalogueInstance
+	(*ListInstancesResponse)(nil), // 20: advisor.v1.ListInstancesResponse
+	(*Aggregates)(nil),            // 21: advisor.v1.Aggregates
+	(*RegionAggregates)(nil),      // 22: advisor.v1.RegionAggregates
+	(*AggregatesRequest)(nil),     // 23: advisor.v1.AggregatesRequest
+	(*AggregatesResponse)(nil),    // 24: advisor.v1.AggregatesResponse
+	nil,                           // 25: advisor.v1.Assignments.ServicesToInstancesEntry
+	nil,                           // 26: advisor.v1.Assignments.InstancesToServicesEntry
+	nil,                           // 27: advisor.v1.RegionAdvice.InstancesEntry
+	nil,                           // 28: advisor.v1.Advice.RegionsEntry
+	nil,                           // 29: advisor.v1.AggregatesResponse.RegionsEntry
+	(*timestamppb.Timestamp)(nil), // 30: google.protobuf.Timestamp
+}
+var file_advisor_proto_depIdxs = []int32{
+	1,  // 0: advisor.v1.Service.placement:type_name -> advisor.v1.Placement
+	2,  // 1: advisor.v1.Service.load_profile:type_name -> advisor.v1.LoadProfile
+	3,  // 2: advisor.v1.Advisor.weights:type_name -> advisor.v1.AdvisorWeights
+	6,  // 3: advisor.v1.Options.filter:type_name -> advisor.v1.InstanceFilter
+	0,  // 4: advisor.v1.AdviseRequest.services:type_name -> advisor.v1.Service
+	4,  // 5: advisor.v1.AdviseRequest.advisor:type_name -> advisor.v1.Advisor
+	5,  // 6: advisor.v1.AdviseRequest.options:type_name -> advisor.v1.Options
+	30, // 7: advisor.v1.AdviseRequest.as_of:type_name -> google.protobuf.Timestamp
+	25, // 8: advisor.v1.Assignments.services_to_instances:type_name -> advisor.v1.Assignments.ServicesToInstancesEntry
+	26, // 9: advisor.v1.Assignments.instances_to_services:type_name -> advisor.v1.Assignments.InstancesToServicesEntry
+	27, // 10: advisor.v1.RegionAdvice.instances:type_name -> advisor.v1.RegionAdvice.InstancesEntry
+	11, // 11: advisor.v1.RegionAdvice.assignments:type_name -> advisor.v1.Assignments
+	28, // 12: advisor.v1.Advice.regions:type_name -> advisor.v1.Advice.RegionsEntry
+	13, // 13: advisor.v1.Advice.ranking:type_name -> advisor.v1.RankedRegion
+	12, // 14: advisor.v1.RegionAdviceEvent.advice:type_name -> advisor.v1.RegionAdvice
+	8,  // 15: advisor.v1.CatalogueInstance.instance:type_name -> advisor.v1.Instance
+	19, // 16: advisor.v1.ListInstancesResponse.instances:type_name -> advisor.v1.CatalogueInstance
+	21, // 17: advisor.v1.RegionAggregates.on_demand:type_name -> advisor.v1.Aggregates
+	21, // 18: advisor.v1.RegionAggregates.spot:type_name -> advisor.v1.Aggregates
+	21, // 19: advisor.v1.RegionAggregates.region:type_name -> advisor.v1.Aggregates
+	21, // 20: advisor.v1.AggregatesResponse.global:type_name -> advisor.v1.Aggregates
+	29, // 21: advisor.v1.AggregatesResponse.regions:type_name -> advisor.v1.AggregatesResponse.RegionsEntry
+	9,  // 22: advisor.v1.Assignments.ServicesToInstancesEntry.value:type_name -> advisor.v1.InstanceIds
+	10, // 23: advisor.v1.Assignments.InstancesToServicesEntry.value:type_name -> advisor.v1.ServiceNames
+	8,  // 24: advisor.v1.RegionAdvice.InstancesEntry.value:type_name -> advisor.v1.Instance
+	12, // 25: advisor.v1.Advice.RegionsEntry.value:type_name -> advisor.v1.RegionAdvice
+	22, // 26: advisor.v1.AggregatesResponse.RegionsEntry.value:type_name -> advisor.v1.RegionAggregates
+	7,  // 27: advisor.v1.AdvisorService.Advise:input_type -> advisor.v1.AdviseRequest
+	7,  // 28: advisor.v1.AdvisorService.StreamAdvice:input_type -> advisor.v1.AdviseRequest
+	16, // 29: advisor.v1.AdvisorService.GetRegions:input_type -> advisor.v1.RegionsRequest
+	18, // 30: advisor.v1.AdvisorService.ListInstances:input_type -> advisor.v1.ListInstancesRequest
+	23, // 31: advisor.v1.AdvisorService.GetAggregates:input_type -> advisor.v1.AggregatesRequest
+	14, // 32: advisor.v1.AdvisorService.Advise:output_type -> advisor.v1.Advice
+	15, // 33: advisor.v1.AdvisorService.StreamAdvice:output_type -> advisor.v1.RegionAdviceEvent
+	17, // 34: advisor.v1.AdvisorService.GetRegions:output_type -> advisor.v1.RegionsResponse
+	20, // 35: advisor.v1.AdvisorService.ListInstances:output_type -> advisor.v1.ListInstancesResponse
+	24, // 36: advisor.v1.AdvisorService.GetAggregates:output_type -> advisor.v1.AggregatesResponse
+	32, // [32:37] is the sub-list for method output_type
+	27, // [27:32] is the sub-list for method input_type
+	27, // [27:27] is the sub-list for extension type_name
+	27, // [27:27] is the sub-list for extension extendee
+	0,  // [0:27] is the sub-list for field type_name
+}
+
+func init() { file_advisor_proto_init() }
+func file_advisor_proto_init() {
+	if File_advisor_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_advisor_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Service); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_advisor_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Placement); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_advisor_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LoadProfile); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_advisor_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AdvisorWeights); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_advisor_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Advisor); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_advisor_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Options); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_advisor_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*InstanceFilter); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_advisor_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AdviseRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_advisor_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Instance); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_advisor_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*InstanceIds); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_advisor_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ServiceNames); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_advisor_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Assignments); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_advisor_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RegionAdvice); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_advisor_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RankedRegion); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_advisor_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Advice); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_advisor_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RegionAdviceEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_advisor_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RegionsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_advisor_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RegionsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_advisor_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListInstancesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_advisor_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CatalogueInstance); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_advisor_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListInstancesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_advisor_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Aggregates); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_advisor_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RegionAggregates); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_advisor_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AggregatesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_advisor_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AggregatesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_advisor_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   30,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_advisor_proto_goTypes,
+		DependencyIndexes: file_advisor_proto_depIdxs,
+		MessageInfos:      file_advisor_proto_msgTypes,
+	}.Build()
+	File_advisor_proto = out.File
+	file_advisor_proto_rawDesc = nil
+	file_advisor_proto_goTypes = nil
+	file_advisor_proto_depIdxs = nil
+}
@@ -0,0 +1,302 @@
+// gRPC interface for the advice service.
+//
+// Messages mirror the JSON schemas in api/schema. Regenerate the Go code in
+// api/rpc/pb with `buf generate proto` from the api/rpc directory.
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             (unknown)
+// source: advisor.proto
+
+package pb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	AdvisorService_Advise_FullMethodName        = "/advisor.v1.AdvisorService/Advise"
+	AdvisorService_StreamAdvice_FullMethodName  = "/advisor.v1.AdvisorService/StreamAdvice"
+	AdvisorService_GetRegions_FullMethodName    = "/advisor.v1.AdvisorService/GetRegions"
+	AdvisorService_ListInstances_FullMethodName = "/advisor.v1.AdvisorService/ListInstances"
+	AdvisorService_GetAggregates_FullMethodName = "/advisor.v1.AdvisorService/GetAggregates"
+)
+
+// AdvisorServiceClient is the client API for AdvisorService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type AdvisorServiceClient interface {
+	// Advise generates Advice for a set of services across the requested regions.
+	Advise(ctx context.Context, in *AdviseRequest, opts ...grpc.CallOption) (*Advice, error)
+	// StreamAdvice generates Advice for a set of services, sending each
+	// region's advice as soon as it has been computed.
+	StreamAdvice(ctx context.Context, in *AdviseRequest, opts ...grpc.CallOption) (AdvisorService_StreamAdviceClient, error)
+	// GetRegions lists the regions supported by the service.
+	GetRegions(ctx context.Context, in *RegionsRequest, opts ...grpc.CallOption) (*RegionsResponse, error)
+	// ListInstances returns one page of the instances known to the service.
+	ListInstances(ctx context.Context, in *ListInstancesRequest, opts ...grpc.CallOption) (*ListInstancesResponse, error)
+	// GetAggregates returns aggregate information for the known instances.
+	GetAggregates(ctx context.Context, in *AggregatesRequest, opts ...grpc.CallOption) (*AggregatesResponse, error)
+}
+
+type advisorServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAdvisorServiceClient(cc grpc.ClientConnInterface) AdvisorServiceClient {
+	return &advisorServiceClient{cc}
+}
+
+func (c *advisorServiceClient) Advise(ctx context.Context, in *AdviseRequest, opts ...grpc.CallOption) (*Advice, error) {
+	out := new(Advice)
+	err := c.cc.Invoke(ctx, AdvisorService_Advise_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *advisorServiceClient) StreamAdvice(ctx context.Context, in *AdviseRequest, opts ...grpc.CallOption) (AdvisorService_StreamAdviceClient, error) {
+	stream, err := c.cc.NewStream(ctx, &AdvisorService_ServiceDesc.Streams[0], AdvisorService_StreamAdvice_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &advisorServiceStreamAdviceClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type AdvisorService_StreamAdviceClient interface {
+	Recv() (*RegionAdviceEvent, error)
+	grpc.ClientStream
+}
+
+type advisorServiceStreamAdviceClient struct {
+	grpc.ClientStream
+}
+
+func (x *advisorServiceStreamAdviceClient) Recv() (*RegionAdviceEvent, error) {
+	m := new(RegionAdviceEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *advisorServiceClient) GetRegions(ctx context.Context, in *RegionsRequest, opts ...grpc.CallOption) (*RegionsResponse, error) {
+	out := new(RegionsResponse)
+	err := c.cc.Invoke(ctx, AdvisorService_GetRegions_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *advisorServiceClient) ListInstances(ctx context.Context, in *ListInstancesRequest, opts ...grpc.CallOption) (*ListInstancesResponse, error) {
+	out := new(ListInstancesResponse)
+	err := c.cc.Invoke(ctx, AdvisorService_ListInstances_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *advisorServiceClient) GetAggregates(ctx context.Context, in *AggregatesRequest, opts ...grpc.CallOption) (*AggregatesResponse, error) {
+	out := new(AggregatesResponse)
+	err := c.cc.Invoke(ctx, AdvisorService_GetAggregates_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AdvisorServiceServer is the server API for AdvisorService service.
+// All implementations must embed UnimplementedAdvisorServiceServer
+// for forward compatibility
+type AdvisorServiceServer interface {
+	// Advise generates Advice for a set of services across the requested regions.
+	Advise(context.Context, *AdviseRequest) (*Advice, error)
+	// StreamAdvice generates Advice for a set of services, sending each
+	// region's advice as soon as it has been computed.
+	StreamAdvice(*AdviseRequest, AdvisorService_StreamAdviceServer) error
+	// GetRegions lists the regions supported by the service.
+	GetRegions(context.Context, *RegionsRequest) (*RegionsResponse, error)
+	// ListInstances returns one page of the instances known to the service.
+	ListInstances(context.Context, *ListInstancesRequest) (*ListInstancesResponse, error)
+	// GetAggregates returns aggregate information for the known instances.
+	GetAggregates(context.Context, *AggregatesRequest) (*AggregatesResponse, error)
+	mustEmbedUnimplementedAdvisorServiceServer()
+}
+
+// UnimplementedAdvisorServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedAdvisorServiceServer struct {
+}
+
+func (UnimplementedAdvisorServiceServer) Advise(context.Context, *AdviseRequest) (*Advice, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Advise not implemented")
+}
+func (UnimplementedAdvisorServiceServer) StreamAdvice(*AdviseRequest, AdvisorService_StreamAdviceServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamAdvice not implemented")
+}
+func (UnimplementedAdvisorServiceServer) GetRegions(context.Context, *RegionsRequest) (*RegionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRegions not implemented")
+}
+func (UnimplementedAdvisorServiceServer) ListInstances(context.Context, *ListInstancesRequest) (*ListInstancesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListInstances not implemented")
+}
+func (UnimplementedAdvisorServiceServer) GetAggregates(context.Context, *AggregatesRequest) (*AggregatesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAggregates not implemented")
+}
+func (UnimplementedAdvisorServiceServer) mustEmbedUnimplementedAdvisorServiceServer() {}
+
+// UnsafeAdvisorServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AdvisorServiceServer will
+// result in compilation errors.
+type UnsafeAdvisorServiceServer interface {
+	mustEmbedUnimplementedAdvisorServiceServer()
+}
+
+func RegisterAdvisorServiceServer(s grpc.ServiceRegistrar, srv AdvisorServiceServer) {
+	s.RegisterService(&AdvisorService_ServiceDesc, srv)
+}
+
+func _AdvisorService_Advise_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AdviseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdvisorServiceServer).Advise(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdvisorService_Advise_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdvisorServiceServer).Advise(ctx, req.(*AdviseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdvisorService_StreamAdvice_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(AdviseRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AdvisorServiceServer).StreamAdvice(m, &advisorServiceStreamAdviceServer{stream})
+}
+
+type AdvisorService_StreamAdviceServer interface {
+	Send(*RegionAdviceEvent) error
+	grpc.ServerStream
+}
+
+type advisorServiceStreamAdviceServer struct {
+	grpc.ServerStream
+}
+
+func (x *advisorServiceStreamAdviceServer) Send(m *RegionAdviceEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _AdvisorService_GetRegions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdvisorServiceServer).GetRegions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdvisorService_GetRegions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdvisorServiceServer).GetRegions(ctx, req.(*RegionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdvisorService_ListInstances_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListInstancesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdvisorServiceServer).ListInstances(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdvisorService_ListInstances_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdvisorServiceServer).ListInstances(ctx, req.(*ListInstancesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdvisorService_GetAggregates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AggregatesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdvisorServiceServer).GetAggregates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdvisorService_GetAggregates_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdvisorServiceServer).GetAggregates(ctx, req.(*AggregatesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// AdvisorService_ServiceDesc is the grpc.ServiceDesc for AdvisorService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var AdvisorService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "advisor.v1.AdvisorService",
+	HandlerType: (*AdvisorServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Advise",
+			Handler:    _AdvisorService_Advise_Handler,
+		},
+		{
+			MethodName: "GetRegions",
+			Handler:    _AdvisorService_GetRegions_Handler,
+		},
+		{
+			MethodName: "ListInstances",
+			Handler:    _AdvisorService_ListInstances_Handler,
+		},
+		{
+			MethodName: "GetAggregates",
+			Handler:    _AdvisorService_GetAggregates_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamAdvice",
+			Handler:       _AdvisorService_StreamAdvice_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "advisor.proto",
+}
@@ -0,0 +1,225 @@
+// Package rpc provides a gRPC interface to the advice service, sharing
+// the advisor and instance catalogue used by the HTTP API.
+package rpc
+
+import (
+	"aws-blended-instances-advisor/advisor"
+	"aws-blended-instances-advisor/api/auth"
+	"aws-blended-instances-advisor/api/rpc/pb"
+	"aws-blended-instances-advisor/api/schema"
+	awsTypes "aws-blended-instances-advisor/aws/types"
+	"aws-blended-instances-advisor/catalogue"
+	instPkg "aws-blended-instances-advisor/instances"
+	"aws-blended-instances-advisor/ranking"
+	"aws-blended-instances-advisor/snapshot"
+	"aws-blended-instances-advisor/utils"
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// INSTANCES_STALE_METADATA_KEY is set in the header metadata of responses
+// generated from stale instances.
+const INSTANCES_STALE_METADATA_KEY = "x-instances-stale"
+
+// An AdviseFunc generates Advice for a set of Services from the given
+// instance information, stopping once ctx is done and reporting per-region
+// progress to onProgress (which may be nil).
+type AdviseFunc func(
+	ctx context.Context,
+	info instPkg.GlobalInfo,
+	advisor schema.Advisor,
+	services []schema.Service,
+	options schema.Options,
+	onProgress advisor.ProgressHandler,
+) (*schema.Advice, error)
+
+// A PresetsFunc returns the named presets which requests can select.
+type PresetsFunc func() map[string]schema.Preset
+
+// A Server implements the AdvisorService gRPC service.
+type Server struct {
+	pb.UnimplementedAdvisorServiceServer
+	cat     *catalogue.Catalogue
+	advise  AdviseFunc
+	presets PresetsFunc
+	logger  *zap.Logger
+}
+
+// NewServer creates a Server which generates advice using advise from the
+// instances held in cat, applying the presets requests select.
+func NewServer(cat *catalogue.Catalogue, advise AdviseFunc, presets PresetsFunc, logger *zap.Logger) *Server {
+	return &Server{
+		cat:     cat,
+		advise:  advise,
+		presets: presets,
+		logger:  logger,
+	}
+}
+
+// Advise generates Advice for a set of services across the requested regions.
+func (s *Server) Advise(ctx context.Context, req *pb.AdviseRequest) (*pb.Advice, error) {
+	reqId := utils.GenerateUuid()
+	s.logger.Info("gRPC request received", zap.String("method", "Advise"), zap.String("requestId", reqId))
+
+	adviseReq, info, err := s.prepareAdvise(ctx, reqId, "advise", req)
+	if err != nil {
+		return nil, err
+	}
+
+	advice, err := s.advise(ctx, *info, adviseReq.Advisor, adviseReq.Services, adviseReq.Options, nil)
+	if err != nil {
+		return nil, adviseError(ctx, err)
+	}
+	s.logger.Info("advice generated for request", zap.String("requestId", reqId), zap.Any("advice", advice))
+
+	return adviceToPb(advice, ranking.Summarise(advice, adviseReq.Options.RankBy)), nil
+}
+
+// StreamAdvice generates Advice for a set of services, sending each region's
+// advice to the client as soon as it has been computed.
+func (s *Server) StreamAdvice(req *pb.AdviseRequest, stream pb.AdvisorService_StreamAdviceServer) error {
+	reqId := utils.GenerateUuid()
+	s.logger.Info("gRPC request received", zap.String("method", "StreamAdvice"), zap.String("requestId", reqId))
+
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+
+	adviseReq, info, err := s.prepareAdvise(ctx, reqId, "streamAdvice", req)
+	if err != nil {
+		return err
+	}
+
+	var sendErr error
+	onProgress := func(progress advisor.RegionProgress) {
+		if sendErr != nil {
+			return
+		}
+
+		event := &pb.RegionAdviceEvent{
+			Region:    progress.Region,
+			Completed: int32(progress.Completed),
+			Total:     int32(progress.Total),
+		}
+		if progress.Err != nil {
+			event.Error = progress.Err.Error()
+		} else if progress.Advice != nil {
+			event.Advice = regionAdviceToPb(progress.Advice)
+		}
+
+		sendErr = stream.Send(event)
+		if sendErr != nil {
+			s.logger.Warn("failed to send advice event", zap.String("requestId", reqId), zap.Error(sendErr))
+			cancel()
+		}
+	}
+
+	_, err = s.advise(ctx, *info, adviseReq.Advisor, adviseReq.Services, adviseReq.Options, onProgress)
+	if sendErr != nil {
+		return sendErr
+	}
+	if err != nil {
+		return adviseError(ctx, err)
+	}
+
+	s.logger.Info("advice streamed for request", zap.String("requestId", reqId))
+	return nil
+}
+
+// GetRegions lists the regions supported by the service.
+func (s *Server) GetRegions(ctx context.Context, req *pb.RegionsRequest) (*pb.RegionsResponse, error) {
+	regions := []string{}
+	for _, r := range awsTypes.GetAllRegions() {
+		regions = append(regions, r.CodeString())
+	}
+	return &pb.RegionsResponse{Regions: regions}, nil
+}
+
+// ListInstances returns one page of the instances known to the service.
+func (s *Server) ListInstances(ctx context.Context, req *pb.ListInstancesRequest) (*pb.ListInstancesResponse, error) {
+	query, err := queryFromPb(req)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, utils.PrependToError(err, "invalid query").Error())
+	}
+
+	info, err := s.cat.Get()
+	if err != nil {
+		return nil, catalogueError(err)
+	}
+	setStalenessHeader(ctx, s.cat, nil)
+
+	return instancesResponseToPb(catalogue.QueryInstances(*info, *query)), nil
+}
+
+// GetAggregates returns aggregate information for the known instances.
+func (s *Server) GetAggregates(ctx context.Context, req *pb.AggregatesRequest) (*pb.AggregatesResponse, error) {
+	info, err := s.cat.Get()
+	if err != nil {
+		return nil, catalogueError(err)
+	}
+	setStalenessHeader(ctx, s.cat, nil)
+
+	return aggregatesResponseToPb(catalogue.GetAggregates(*info)), nil
+}
+
+func (s *Server) prepareAdvise(
+	ctx context.Context,
+	reqId string,
+	action string,
+	req *pb.AdviseRequest,
+) (*schema.AdviseRequest, *instPkg.GlobalInfo, error) {
+	adviseReq := adviseRequestFromPb(req)
+	err := adviseReq.ApplyPreset(s.presets())
+	if err != nil {
+		return nil, nil, status.Error(codes.InvalidArgument, utils.PrependToError(err, "invalid request").Error())
+	}
+	err = adviseReq.Validate()
+	if err != nil {
+		return nil, nil, status.Error(codes.InvalidArgument, utils.PrependToError(err, "invalid request").Error())
+	}
+
+	info, err := s.cat.GetAsOf(adviseReq.AsOf)
+	if err != nil {
+		return nil, nil, catalogueError(err)
+	}
+	setStalenessHeader(ctx, s.cat, adviseReq.AsOf)
+
+	auth.LogAudit(ctx, remoteAddr(ctx), reqId, action, &adviseReq, s.logger)
+	schema.OrderServicesByDecreasingMemory(adviseReq.Services)
+
+	return &adviseReq, info, nil
+}
+
+func catalogueError(err error) error {
+	switch err {
+	case catalogue.ErrNotLoaded, catalogue.ErrTooStale:
+		return status.Error(codes.Unavailable, err.Error())
+	case catalogue.ErrNoHistory:
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case snapshot.ErrNoSnapshot:
+		return status.Error(codes.NotFound, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
+
+// setStalenessHeader flags responses generated from stale instances in their
+// header metadata. Responses generated from past instances (asOf) are not flagged.
+func setStalenessHeader(ctx context.Context, cat *catalogue.Catalogue, asOf *time.Time) {
+	if asOf != nil || !cat.IsStale() {
+		return
+	}
+	grpc.SetHeader(ctx, metadata.Pairs(INSTANCES_STALE_METADATA_KEY, "true"))
+}
+
+func adviseError(ctx context.Context, err error) error {
+	if ctx.Err() != nil {
+		return status.FromContextError(ctx.Err()).Err()
+	}
+	return status.Error(codes.Internal, err.Error())
+}
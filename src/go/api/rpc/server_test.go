@@ -0,0 +1,182 @@
+package rpc
+
+import (
+	"aws-blended-instances-advisor/advisor"
+	"aws-blended-instances-advisor/api/rpc/pb"
+	"aws-blended-instances-advisor/api/schema"
+	"aws-blended-instances-advisor/catalogue"
+	instPkg "aws-blended-instances-advisor/instances"
+	"aws-blended-instances-advisor/ranking"
+	"aws-blended-instances-advisor/utils"
+	"context"
+	"errors"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+var testRequest = &pb.AdviseRequest{
+	Services: []*pb.Service{{Name: "svc", MinMemory: 2, MaxVcpu: 4, MinInstances: 1, MaxInstances: 2}},
+	Options:  &pb.Options{Regions: []string{"us-east-1", "eu-west-1"}},
+}
+
+func testPresets() map[string]schema.Preset {
+	return map[string]schema.Preset{
+		"us": {Options: schema.Options{Regions: []string{"us-east-1"}}},
+	}
+}
+
+func fakeAdvise(
+	ctx context.Context,
+	info instPkg.GlobalInfo,
+	advisorInfo schema.Advisor,
+	services []schema.Service,
+	options schema.Options,
+	onProgress advisor.ProgressHandler,
+) (*schema.Advice, error) {
+	advice := schema.Advice{}
+	for i, region := range options.Regions {
+		if region == "eu-west-1" {
+			if onProgress != nil {
+				onProgress(advisor.RegionProgress{Region: region, Err: errors.New("TEST ERROR"), Completed: i + 1, Total: len(options.Regions)})
+			}
+			continue
+		}
+
+		regionAdvice := schema.RegionAdvice{Score: 1}
+		regionAdvice.AddAssignment(services[0].Name, &schema.Instance{Id: "i-1", Region: region})
+		advice[region] = regionAdvice
+		if onProgress != nil {
+			onProgress(advisor.RegionProgress{Region: region, Advice: &regionAdvice, Completed: i + 1, Total: len(options.Regions)})
+		}
+	}
+	ranking.Rank(&advice, options.RankBy)
+	return &advice, nil
+}
+
+func startTestServer(t *testing.T, cat *catalogue.Catalogue) pb.AdvisorServiceClient {
+	logger, err := utils.CreateMockLogger()
+	if err != nil {
+		t.Fatalf("Failed to create logger: %s", err.Error())
+	}
+
+	listener := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer()
+	pb.RegisterAdvisorServiceServer(server, NewServer(cat, fakeAdvise, testPresets, logger))
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.Dial(
+		"bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("Failed to dial test server: %s", err.Error())
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	return pb.NewAdvisorServiceClient(conn)
+}
+
+func TestAdvise(t *testing.T) {
+	cat := catalogue.New()
+	cat.Set(&instPkg.GlobalInfo{}, time.Now())
+	client := startTestServer(t, cat)
+
+	advice, err := client.Advise(context.Background(), testRequest)
+	if err != nil {
+		t.Fatalf("Advise failed: %s", err.Error())
+	}
+
+	regionAdvice, ok := advice.GetRegions()["us-east-1"]
+	if !ok {
+		t.Fatalf("Advice missing region us-east-1: %v", advice)
+	}
+	if ids := regionAdvice.GetAssignments().GetServicesToInstances()["svc"].GetIds(); len(ids) != 1 || ids[0] != "i-1" {
+		t.Fatalf("Wrong assignments for svc: %v", ids)
+	}
+	if ranked := advice.GetRanking(); len(ranked) != 1 || ranked[0].GetRegion() != "us-east-1" || ranked[0].GetRank() != 1 {
+		t.Fatalf("Wrong ranking: %v", ranked)
+	}
+}
+
+func TestAdviseWithPreset(t *testing.T) {
+	cat := catalogue.New()
+	cat.Set(&instPkg.GlobalInfo{}, time.Now())
+	client := startTestServer(t, cat)
+
+	advice, err := client.Advise(context.Background(), &pb.AdviseRequest{
+		Services: testRequest.Services,
+		Preset:   "us",
+	})
+	if err != nil {
+		t.Fatalf("Advise failed: %s", err.Error())
+	}
+	if _, ok := advice.GetRegions()["us-east-1"]; !ok || len(advice.GetRegions()) != 1 {
+		t.Fatalf("Expected advice for the preset's region only, got: %v", advice.GetRegions())
+	}
+
+	_, err = client.Advise(context.Background(), &pb.AdviseRequest{Services: testRequest.Services, Preset: "unknown"})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("Wrong code for unknown preset. Wanted: %s, got: %v", codes.InvalidArgument, err)
+	}
+}
+
+func TestAdviseErrors(t *testing.T) {
+	cat := catalogue.New()
+	client := startTestServer(t, cat)
+
+	_, err := client.Advise(context.Background(), testRequest)
+	if status.Code(err) != codes.Unavailable {
+		t.Fatalf("Wrong code before catalogue loaded. Wanted: %s, got: %s", codes.Unavailable, status.Code(err))
+	}
+
+	cat.Set(&instPkg.GlobalInfo{}, time.Now())
+	_, err = client.Advise(context.Background(), &pb.AdviseRequest{Options: &pb.Options{Regions: []string{"nowhere"}}})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("Wrong code for invalid request. Wanted: %s, got: %s", codes.InvalidArgument, status.Code(err))
+	}
+}
+
+func TestStreamAdvice(t *testing.T) {
+	cat := catalogue.New()
+	cat.Set(&instPkg.GlobalInfo{}, time.Now())
+	client := startTestServer(t, cat)
+
+	stream, err := client.StreamAdvice(context.Background(), testRequest)
+	if err != nil {
+		t.Fatalf("StreamAdvice failed: %s", err.Error())
+	}
+
+	events := []*pb.RegionAdviceEvent{}
+	for {
+		event, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Failed to receive event: %s", err.Error())
+		}
+		events = append(events, event)
+	}
+
+	if len(events) != 2 {
+		t.Fatalf("Wrong number of events. Wanted: 2, got: %d", len(events))
+	}
+	if events[0].GetRegion() != "us-east-1" || events[0].GetAdvice() == nil || events[0].GetCompleted() != 1 {
+		t.Fatalf("Wrong first event: %v", events[0])
+	}
+	if events[1].GetRegion() != "eu-west-1" || events[1].GetError() == "" || events[1].GetAdvice() != nil {
+		t.Fatalf("Wrong second event: %v", events[1])
+	}
+}
@@ -1,10 +1,36 @@
 package schema
 
+import "encoding/json"
+
 // An Advice describes a list of suggested offerings to
 // purchase for a given set of services and constraints
 // for one or more regions.
 type Advice map[string]RegionAdvice
 
+// UnmarshalJSON parses an Advice, or the regions of an AdviseResponse, so
+// that the response from advising can be given wherever Advice is expected.
+func (a *Advice) UnmarshalJSON(data []byte) error {
+	var fields map[string]json.RawMessage
+	err := json.Unmarshal(data, &fields)
+	if err != nil {
+		return err
+	}
+
+	regions, hasRegions := fields["regions"]
+	_, hasRanking := fields["ranking"]
+	if hasRegions && hasRanking {
+		data = regions
+	}
+
+	advice := make(map[string]RegionAdvice)
+	err = json.Unmarshal(data, &advice)
+	if err != nil {
+		return err
+	}
+	*a = advice
+	return nil
+}
+
 // A RegionAdvice describes a list of suggested offerings to
 // purchase for a given set of services and constraints
 // for exactly one region.
@@ -12,6 +38,19 @@ type RegionAdvice struct {
 	Score       float64              `json:"score"`
 	Instances   map[string]*Instance `json:"instances"` // ID to instance
 	Assignments Assignments          `json:"assignments"`
+
+	// The region's position when the regions of an Advice are ranked,
+	// from 1 for the best region (0 when unranked)
+	Rank int `json:"rank,omitempty"`
+
+	// The memory and vCPUs reserved on each instance, by instance ID
+	Utilisation map[string]InstanceUtilisation `json:"utilisation,omitempty"`
+
+	// When each profiled Service's instances run, by Service name
+	Schedules map[string]ServiceSchedule `json:"schedules,omitempty"`
+
+	// The cost of running instances only when scheduled (nil without schedules)
+	Projection *CostProjection `json:"projection,omitempty"`
 }
 
 // Assignments lists the relationships between Services and Instances
@@ -49,6 +88,25 @@ func (ra *RegionAdvice) AddAssignment(serviceName string, instance *Instance) {
 	ra.Assignments.add(serviceName, instance.Id)
 }
 
+// PricePerHour returns the total price per hour of all Instances in a RegionAdvice.
+func (ra *RegionAdvice) PricePerHour() float64 {
+	total := 0.0
+	for _, inst := range ra.Instances {
+		total += inst.PricePerHour
+	}
+	return total
+}
+
+// ExpectedRevocations returns the expected number of Instances in a RegionAdvice
+// which will be revoked, using each Instance's revocation probability.
+func (ra *RegionAdvice) ExpectedRevocations() float64 {
+	total := 0.0
+	for _, inst := range ra.Instances {
+		total += inst.RevocationProbability
+	}
+	return total
+}
+
 func (a *Assignments) add(serviceName string, instanceId string) {
 	if a.InstancesToServices == nil {
 		a.InstancesToServices = make(map[string][]string)
@@ -0,0 +1,37 @@
+package schema
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestUnmarshalAdvice(t *testing.T) {
+	tests := map[string]string{
+		"advice":   `{"us-east-1": {"score": 0.5, "rank": 1}}`,
+		"response": `{"regions": {"us-east-1": {"score": 0.5, "rank": 1}}, "ranking": [{"region": "us-east-1", "rank": 1}]}`,
+	}
+
+	for name, body := range tests {
+		var advice Advice
+		err := json.Unmarshal([]byte(body), &advice)
+		if err != nil {
+			t.Fatalf("Unexpected error for %s: %s", name, err.Error())
+		}
+		if len(advice) != 1 || advice["us-east-1"].Score != 0.5 || advice["us-east-1"].Rank != 1 {
+			t.Fatalf("Wrong advice for %s: %+v", name, advice)
+		}
+	}
+}
+
+func TestUnmarshalAdviseResponse(t *testing.T) {
+	body := `{"regions": {"us-east-1": {"score": 0.5, "rank": 1}}, "ranking": [{"region": "us-east-1", "rank": 1}]}`
+
+	var resp AdviseResponse
+	err := json.Unmarshal([]byte(body), &resp)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+	if len(resp.Regions) != 1 || len(resp.Ranking) != 1 || resp.Ranking[0].Region != "us-east-1" {
+		t.Fatalf("Wrong response: %+v", resp)
+	}
+}
@@ -0,0 +1,75 @@
+package schema
+
+import (
+	"aws-blended-instances-advisor/utils"
+	"errors"
+	"fmt"
+)
+
+// A BatchAdviseRequest contains multiple named AdviseRequests, which are
+// evaluated against the same instance data so their advice can be compared.
+type BatchAdviseRequest struct {
+	Scenarios []Scenario `json:"scenarios"`
+}
+
+// A Scenario is a named AdviseRequest within a BatchAdviseRequest.
+type Scenario struct {
+	Name    string        `json:"name"`
+	Request AdviseRequest `json:"request"`
+}
+
+// A BatchAdviseResponse contains the Advice for each Scenario of a
+// BatchAdviseRequest, along with a table comparing them.
+type BatchAdviseResponse struct {
+	Comparison []ScenarioComparison `json:"comparison"`
+	Advice     map[string]Advice    `json:"advice"` // Scenario name to advice
+	Errors     map[string]string    `json:"errors"` // Scenario name to error
+}
+
+// A ScenarioComparison summarises the advice for one region of one Scenario.
+type ScenarioComparison struct {
+	Scenario            string  `json:"scenario"`
+	Region              string  `json:"region"`
+	Score               float64 `json:"score"`
+	PricePerHour        float64 `json:"price"`
+	ExpectedRevocations float64 `json:"expectedRevocations"`
+	InstanceCount       int     `json:"instanceCount"`
+	Rank                int     `json:"rank,omitempty"` // Within the Scenario
+}
+
+// Validate checks that a BatchAdviseRequest is well-formed
+// and is true to the API specification.
+func (r *BatchAdviseRequest) Validate() error {
+	if len(r.Scenarios) == 0 {
+		return errors.New("no scenarios provided")
+	}
+
+	names := make(utils.StringSet)
+	for _, scenario := range r.Scenarios {
+		if scenario.Name == "" {
+			return errors.New("scenario name is empty")
+		}
+		if names.Contains(scenario.Name) {
+			return errors.New("scenario names are not unique")
+		}
+		names.Add(scenario.Name)
+
+		err := scenario.Request.Validate()
+		if err != nil {
+			return utils.PrependToError(err, fmt.Sprintf("scenario %s invalid", scenario.Name))
+		}
+	}
+	return nil
+}
+
+// ApplyPresets applies the Preset named by each Scenario's request, as
+// described by AdviseRequest.ApplyPreset.
+func (r *BatchAdviseRequest) ApplyPresets(presets map[string]Preset) error {
+	for i := range r.Scenarios {
+		err := r.Scenarios[i].Request.ApplyPreset(presets)
+		if err != nil {
+			return utils.PrependToError(err, fmt.Sprintf("scenario %s", r.Scenarios[i].Name))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+package schema
+
+// A CatalogueInstance is an Instance known to the service, along with
+// the market it is offered in.
+type CatalogueInstance struct {
+	Instance
+	Market string `json:"market"`
+}
+
+// An InstancesResponse contains one page of Instances matching a query.
+type InstancesResponse struct {
+	Instances []CatalogueInstance `json:"instances"`
+	Total     int                 `json:"total"`
+	Page      int                 `json:"page"`
+	PageSize  int                 `json:"pageSize"`
+}
+
+// Aggregates contains aggregate information for a group of Instances.
+type Aggregates struct {
+	Count int `json:"count"`
+
+	MinVcpu  int     `json:"minVcpu"`
+	MaxVcpu  int     `json:"maxVcpu"`
+	MeanVcpu float64 `json:"meanVcpu"`
+
+	MinRevocationProbability  float64 `json:"minRevocProb"`
+	MaxRevocationProbability  float64 `json:"maxRevocProb"`
+	MeanRevocationProbability float64 `json:"meanRevocProb"`
+
+	MinPricePerHour  float64 `json:"minPrice"`
+	MaxPricePerHour  float64 `json:"maxPrice"`
+	MeanPricePerHour float64 `json:"meanPrice"`
+}
+
+// RegionAggregates contains the Aggregates for a single region's Instances.
+type RegionAggregates struct {
+	OnDemand Aggregates `json:"onDemand"`
+	Spot     Aggregates `json:"spot"`
+	Region   Aggregates `json:"region"`
+}
+
+// An AggregatesResponse contains the Aggregates for all Instances, and for
+// each region's Instances.
+type AggregatesResponse struct {
+	Global  Aggregates                  `json:"global"`
+	Regions map[string]RegionAggregates `json:"regions"`
+}
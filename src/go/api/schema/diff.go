@@ -0,0 +1,138 @@
+package schema
+
+import (
+	"errors"
+	"time"
+)
+
+// A DiffRequest asks for the differences between two Advice documents.
+//
+// When After is not given, it is generated from Request using the current
+// instances (or those at AfterAsOf), showing how Before would change if
+// it were advised again. When BeforeAsOf is given instead of Before, Before
+// is also generated from Request, comparing the same request against two
+// instance snapshots.
+type DiffRequest struct {
+	Before  *Advice        `json:"before,omitempty"`
+	After   *Advice        `json:"after,omitempty"`
+	Request *AdviseRequest `json:"request,omitempty"`
+
+	// When set instead of Before, Before is generated from Request using the
+	// instances as they were at this time
+	BeforeAsOf *time.Time `json:"beforeAsOf,omitempty"`
+
+	// When set, After is generated from Request using the instances as they
+	// were at this time (the same as setting Request.AsOf)
+	AfterAsOf *time.Time `json:"afterAsOf,omitempty"`
+}
+
+// Validate checks that a DiffRequest is well-formed
+// and is true to the API specification.
+func (r *DiffRequest) Validate() error {
+	if r.Before == nil && r.BeforeAsOf == nil {
+		return errors.New("one of before advice or beforeAsOf must be given")
+	}
+	if r.Before != nil && r.BeforeAsOf != nil {
+		return errors.New("only one of before advice or beforeAsOf can be given")
+	}
+	if r.After != nil && r.AfterAsOf != nil {
+		return errors.New("only one of after advice or afterAsOf can be given")
+	}
+
+	if r.Request == nil {
+		if r.BeforeAsOf != nil || r.AfterAsOf != nil {
+			return errors.New("beforeAsOf and afterAsOf can only be given with a request")
+		}
+		if r.After == nil {
+			return errors.New("one of after advice or request must be given")
+		}
+		return nil
+	}
+
+	if r.Before != nil && r.After != nil {
+		return errors.New("a request cannot be given with both before and after advice")
+	}
+	if r.AfterAsOf != nil && r.Request.AsOf != nil {
+		return errors.New("only one of afterAsOf or the request's asOf can be given")
+	}
+	now := time.Now()
+	if (r.BeforeAsOf != nil && r.BeforeAsOf.After(now)) || (r.AfterAsOf != nil && r.AfterAsOf.After(now)) {
+		return errors.New("beforeAsOf and afterAsOf cannot be in the future")
+	}
+	return r.Request.Validate()
+}
+
+// GetAfterAsOf returns the time at which instances are taken to generate
+// After from Request, or nil for the current instances.
+func (r *DiffRequest) GetAfterAsOf() *time.Time {
+	if r.AfterAsOf != nil {
+		return r.AfterAsOf
+	}
+	if r.Request != nil {
+		return r.Request.AsOf
+	}
+	return nil
+}
+
+// ApplyPreset applies the Preset named by the DiffRequest's request, if it
+// has one, as described by AdviseRequest.ApplyPreset.
+func (r *DiffRequest) ApplyPreset(presets map[string]Preset) error {
+	if r.Request == nil {
+		return nil
+	}
+	return r.Request.ApplyPreset(presets)
+}
+
+// A DiffStatus describes how something differs between two Advice documents.
+type DiffStatus string
+
+const (
+	DiffAdded     DiffStatus = "added"
+	DiffRemoved   DiffStatus = "removed"
+	DiffChanged   DiffStatus = "changed"
+	DiffUnchanged DiffStatus = "unchanged"
+)
+
+// An AdviceDiff describes the differences between two Advice documents
+// for each region in either.
+type AdviceDiff struct {
+	Regions map[string]RegionDiff `json:"regions"`
+}
+
+// A RegionDiff describes how the RegionAdvice for one region differs
+// between two Advice documents.
+type RegionDiff struct {
+	Status      DiffStatus             `json:"status"`
+	ScoreBefore float64                `json:"scoreBefore"`
+	ScoreAfter  float64                `json:"scoreAfter"`
+	ScoreDelta  float64                `json:"scoreDelta"`
+	PriceBefore float64                `json:"priceBefore"`
+	PriceAfter  float64                `json:"priceAfter"`
+	PriceDelta  float64                `json:"priceDelta"`
+	Services    map[string]ServiceDiff `json:"services"`
+}
+
+// A ServiceDiff describes how the instances assigned to one service differ
+// between two RegionAdvices.
+//
+// A service's price counts an equal share of each instance it shares
+// with other services.
+type ServiceDiff struct {
+	Status        DiffStatus         `json:"status"`
+	PriceBefore   float64            `json:"priceBefore"`
+	PriceAfter    float64            `json:"priceAfter"`
+	PriceDelta    float64            `json:"priceDelta"`
+	InstanceTypes []InstanceTypeDiff `json:"instanceTypes"`
+}
+
+// An InstanceTypeDiff describes how the number and price of one instance type,
+// in one market, assigned to a service differs between two RegionAdvices.
+type InstanceTypeDiff struct {
+	Status      DiffStatus `json:"status"`
+	Name        string     `json:"name"`
+	Spot        bool       `json:"spot"`
+	CountBefore int        `json:"countBefore"`
+	CountAfter  int        `json:"countAfter"`
+	PriceBefore float64    `json:"priceBefore"` // Mean per instance
+	PriceAfter  float64    `json:"priceAfter"`  // Mean per instance
+}
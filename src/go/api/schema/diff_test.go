@@ -0,0 +1,68 @@
+package schema
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDiffRequestValidate(t *testing.T) {
+	past := time.Now().Add(-time.Hour)
+	future := time.Now().Add(time.Hour)
+	advice := &Advice{}
+	request := func() *AdviseRequest {
+		return &AdviseRequest{
+			Services: []Service{{Name: "svc", MinMemory: 1, MaxVcpu: 1, MinInstances: 1, MaxInstances: 1}},
+			Advisor:  Advisor{Type: Weighted},
+			Options:  Options{Regions: []string{"us-east-1"}},
+		}
+	}
+	requestAsOf := request()
+	requestAsOf.AsOf = &past
+
+	tests := map[string]struct {
+		req     DiffRequest
+		isValid bool
+	}{
+		"two advice":                {req: DiffRequest{Before: advice, After: advice}, isValid: true},
+		"before and request":        {req: DiffRequest{Before: advice, Request: request()}, isValid: true},
+		"snapshots":                 {req: DiffRequest{BeforeAsOf: &past, Request: request()}, isValid: true},
+		"snapshots with after time": {req: DiffRequest{BeforeAsOf: &past, AfterAsOf: &past, Request: request()}, isValid: true},
+		"before time and after":     {req: DiffRequest{BeforeAsOf: &past, After: advice, Request: request()}, isValid: true},
+		"no before":                 {req: DiffRequest{After: advice}, isValid: false},
+		"before advice and time":    {req: DiffRequest{Before: advice, BeforeAsOf: &past, Request: request()}, isValid: false},
+		"after advice and time":     {req: DiffRequest{Before: advice, After: advice, AfterAsOf: &past}, isValid: false},
+		"no after":                  {req: DiffRequest{Before: advice}, isValid: false},
+		"time without request":      {req: DiffRequest{BeforeAsOf: &past, After: advice}, isValid: false},
+		"unused request":            {req: DiffRequest{Before: advice, After: advice, Request: request()}, isValid: false},
+		"two after times":           {req: DiffRequest{Before: advice, AfterAsOf: &past, Request: requestAsOf}, isValid: false},
+		"future before time":        {req: DiffRequest{BeforeAsOf: &future, Request: request()}, isValid: false},
+		"invalid request":           {req: DiffRequest{Before: advice, Request: &AdviseRequest{Options: Options{Regions: []string{"nowhere"}}}}, isValid: false},
+	}
+
+	for name, test := range tests {
+		err := test.req.Validate()
+		if (err == nil) != test.isValid {
+			t.Fatalf("Wrong validation result for \"%s\". Wanted valid: %t, got error: %v", name, test.isValid, err)
+		}
+	}
+}
+
+func TestDiffRequestGetAfterAsOf(t *testing.T) {
+	before := time.Now().Add(-2 * time.Hour)
+	after := time.Now().Add(-time.Hour)
+
+	req := DiffRequest{Request: &AdviseRequest{AsOf: &before}}
+	if got := req.GetAfterAsOf(); got != &before {
+		t.Fatalf("Expected the request's asOf, got: %v", got)
+	}
+
+	req = DiffRequest{Request: &AdviseRequest{}, AfterAsOf: &after}
+	if got := req.GetAfterAsOf(); got != &after {
+		t.Fatalf("Expected afterAsOf, got: %v", got)
+	}
+
+	req = DiffRequest{After: &Advice{}}
+	if got := req.GetAfterAsOf(); got != nil {
+		t.Fatalf("Expected no time, got: %v", got)
+	}
+}
@@ -0,0 +1,25 @@
+package schema
+
+// An EventName names a Server-Sent Event emitted while streaming advice.
+type EventName string
+
+const (
+	RegionAdviceEventName EventName = "region" // Data is a RegionAdviceEvent
+	AdviceEventName       EventName = "advice" // Data is the complete Advice
+	ErrorEventName        EventName = "error"  // Data is an ErrorEvent
+)
+
+// A RegionAdviceEvent is sent each time advice has been generated, or has
+// failed to be generated, for a single region.
+type RegionAdviceEvent struct {
+	Region    string        `json:"region"`
+	Completed int           `json:"completed"`
+	Total     int           `json:"total"`
+	Advice    *RegionAdvice `json:"advice,omitempty"`
+	Error     string        `json:"error,omitempty"`
+}
+
+// An ErrorEvent is sent when advice could not be generated for a request.
+type ErrorEvent struct {
+	Error string `json:"error"`
+}
@@ -0,0 +1,35 @@
+package schema
+
+import "time"
+
+// A HealthResponse describes whether the service is alive or ready.
+type HealthResponse struct {
+	Status string `json:"status"`
+}
+
+// A StatusResponse describes the state of the service's instance catalogue.
+type StatusResponse struct {
+	Ready          bool                        `json:"ready"`
+	LoadedAt       *time.Time                  `json:"loadedAt,omitempty"`
+	FetchedAt      *time.Time                  `json:"fetchedAt,omitempty"`
+	DataAgeSeconds float64                     `json:"dataAgeSeconds"`
+	Stale          bool                        `json:"stale"` // Whether instances are being served while revalidated
+	ValidUntil     *time.Time                  `json:"validUntil,omitempty"`
+	Regions        map[string]RegionStatus     `json:"regions"`
+	CacheEntries   map[string]CacheEntryStatus `json:"cacheEntries"`
+	LastError      string                      `json:"lastError,omitempty"`
+	LastErrorAt    *time.Time                  `json:"lastErrorAt,omitempty"`
+}
+
+// A RegionStatus describes the instances known for a single region.
+type RegionStatus struct {
+	OnDemandCount int `json:"onDemandCount"`
+	SpotCount     int `json:"spotCount"`
+}
+
+// A CacheEntryStatus describes a single entry in the service's cache.
+type CacheEntryStatus struct {
+	SetAt       time.Time `json:"setAt"`
+	InvalidFrom time.Time `json:"invalidFrom"`
+	Valid       bool      `json:"valid"`
+}
@@ -11,3 +11,12 @@ type Instance struct {
 	PricePerHour          float64 `json:"price"`
 	RevocationProbability float64 `json:"revocProb"`
 }
+
+// IsSpot returns true if the Instance is offered in the spot market, and
+// false if it is offered on-demand.
+//
+// On-demand Instances are never revoked, so only spot Instances have a
+// revocation probability.
+func (inst *Instance) IsSpot() bool {
+	return inst.RevocationProbability > 0
+}
@@ -0,0 +1,51 @@
+package schema
+
+import "time"
+
+// A JobStatus describes the state of an asynchronous advice job.
+type JobStatus string
+
+const (
+	JobPending   JobStatus = "pending"
+	JobRunning   JobStatus = "running"
+	JobCompleted JobStatus = "completed"
+	JobFailed    JobStatus = "failed"
+	JobCancelled JobStatus = "cancelled"
+)
+
+// IsFinished returns true if a job with the JobStatus will make no further
+// progress, and false otherwise.
+func (status JobStatus) IsFinished() bool {
+	return status == JobCompleted || status == JobFailed || status == JobCancelled
+}
+
+// A JobCreatedResponse is returned when an asynchronous advice job is created.
+type JobCreatedResponse struct {
+	Id string `json:"id"`
+}
+
+// A JobResponse describes the state of an asynchronous advice job, including
+// the final Advice once the job is completed.
+type JobResponse struct {
+	Id         string                       `json:"id"`
+	Status     JobStatus                    `json:"status"`
+	Progress   JobProgress                  `json:"progress"`
+	Regions    map[string]RegionJobProgress `json:"regions"`
+	Advice     *Advice                      `json:"advice,omitempty"`
+	Error      string                       `json:"error,omitempty"`
+	CreatedAt  time.Time                    `json:"createdAt"`
+	FinishedAt *time.Time                   `json:"finishedAt,omitempty"`
+}
+
+// JobProgress describes how many of a job's regions have been advised for.
+type JobProgress struct {
+	Completed int `json:"completed"`
+	Total     int `json:"total"`
+}
+
+// RegionJobProgress describes the state of a single region within
+// an asynchronous advice job.
+type RegionJobProgress struct {
+	Status JobStatus `json:"status"`
+	Error  string    `json:"error,omitempty"`
+}
@@ -2,24 +2,128 @@ package schema
 
 import (
 	awsTypes "aws-blended-instances-advisor/aws/types"
+	"errors"
 )
 
 type Options struct {
-	AvoidRepeatedInstanceTypes    bool     `json:"avoidRepeatedInstanceTypes"`
-	ShareInstancesBetweenServices bool     `json:"shareInstancesBetweenServices"`
-	ConsiderFreeInstances         bool     `json:"considerFreeInstances"`
-	Regions                       []string `json:"regions"`
+	AvoidRepeatedInstanceTypes    bool           `json:"avoidRepeatedInstanceTypes"`
+	ShareInstancesBetweenServices bool           `json:"shareInstancesBetweenServices"`
+	ConsiderFreeInstances         bool           `json:"considerFreeInstances"`
+	Regions                       []string       `json:"regions"`
+	Filter                        InstanceFilter `json:"filter"`
+
+	// When true, each Service's instances are distributed across the regions
+	// as one deployment plan, rather than advised for each region separately
+	CrossRegion bool `json:"crossRegion"`
+
+	// When given, only regions in these geographies are advised for, chosen
+	// from all regions when no regions are given (e.g. ["europe"])
+	Geographies []string `json:"geographies"`
+
+	// How services are packed onto shared instances when
+	// ShareInstancesBetweenServices is set (first-fit-decreasing when empty)
+	Packing PackingStrategy `json:"packing"`
+
+	// The criteria regions are ranked by, each breaking ties in the previous
+	// (score then price when empty)
+	RankBy []RankingCriterion `json:"rankBy"`
+}
+
+// Copy returns a copy of an Options which shares no slices with the
+// original, so that either can be modified without changing the other.
+func (o Options) Copy() Options {
+	copied := o
+	copied.Regions = copyStrings(o.Regions)
+	copied.Geographies = copyStrings(o.Geographies)
+	if o.RankBy != nil {
+		copied.RankBy = append([]RankingCriterion{}, o.RankBy...)
+	}
+	return copied
+}
+
+func copyStrings(values []string) []string {
+	if values == nil {
+		return nil
+	}
+	return append([]string{}, values...)
+}
+
+// An InstanceFilter restricts which instances can be selected for advice.
+//
+// Zero values are treated as "no constraint".
+type InstanceFilter struct {
+	OperatingSystem string  `json:"os"`
+	Family          string  `json:"family"`
+	MinMemory       float64 `json:"minMemory"`
+	MinVcpu         int     `json:"minVcpu"`
+	MaxVcpu         int     `json:"maxVcpu"`
+	MaxPrice        float64 `json:"maxPrice"`
 }
 
 // Validate checks that an Options variable is well-formed
 // and is true to the API specification.
 func (o *Options) Validate() error {
-	_, err := awsTypes.NewRegions(o.Regions)
-	return err
+	regions, err := o.GetRegionsAsAwsRegions()
+	if err != nil {
+		return err
+	}
+	if len(regions) == 0 && len(o.Geographies) > 0 {
+		return errors.New("no requested regions are in the requested geographies")
+	}
+	err = o.Packing.Validate()
+	if err != nil {
+		return err
+	}
+	for _, criterion := range o.RankBy {
+		err = criterion.Validate()
+		if err != nil {
+			return err
+		}
+	}
+	return o.Filter.Validate()
+}
+
+// Validate checks that an InstanceFilter is well-formed
+// and is true to the API specification.
+func (f *InstanceFilter) Validate() error {
+	if f.MinMemory < 0 || f.MinVcpu < 0 || f.MaxVcpu < 0 || f.MaxPrice < 0 {
+		return errors.New("filter values cannot be negative")
+	}
+	if f.MaxVcpu > 0 && f.MinVcpu > f.MaxVcpu {
+		return errors.New("filter minVcpu cannot be greater than maxVcpu")
+	}
+	return nil
 }
 
 // GetRegionsAsAwsRegions converts an Options' regions strings into
-// Regions used in the aws/api package.
+// Regions used in the aws/api package, keeping only those in its
+// geographies when any are given.
 func (o *Options) GetRegionsAsAwsRegions() ([]awsTypes.Region, error) {
-	return awsTypes.NewRegions(o.Regions)
+	regions, err := awsTypes.NewRegions(o.Regions)
+	if err != nil || len(o.Geographies) == 0 {
+		return regions, err
+	}
+
+	geographies, err := awsTypes.NewGeographies(o.Geographies)
+	if err != nil {
+		return nil, err
+	}
+	if len(regions) == 0 {
+		regions = awsTypes.GetAllRegions()
+	}
+	return awsTypes.RegionsInGeographies(regions, geographies), nil
+}
+
+// GetRegionCodes returns the codes of the Regions advice is generated for,
+// as described by GetRegionsAsAwsRegions, or nil if they are invalid.
+func (o *Options) GetRegionCodes() []string {
+	regions, err := o.GetRegionsAsAwsRegions()
+	if err != nil {
+		return nil
+	}
+	codes := []string{}
+	for _, region := range regions {
+		codes = append(codes, region.CodeString())
+	}
+	return codes
 }
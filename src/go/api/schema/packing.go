@@ -0,0 +1,36 @@
+package schema
+
+import "fmt"
+
+// A PackingStrategy decides which already purchased Instance, if any, a
+// Service's instance is placed on when Instances are shared between Services.
+type PackingStrategy string
+
+const (
+	// Places the largest instances first, each on the first Instance it fits
+	FirstFitDecreasing PackingStrategy = "first-fit-decreasing"
+
+	// Places instances in request order, each on the Instance it leaves least free
+	BestFit PackingStrategy = "best-fit"
+)
+
+// Validate checks that a PackingStrategy is well-formed
+// and is true to the API specification.
+//
+// An empty PackingStrategy is valid, and is treated as FirstFitDecreasing.
+func (p PackingStrategy) Validate() error {
+	switch p {
+	case "", FirstFitDecreasing, BestFit:
+		return nil
+	}
+	return fmt.Errorf("provided value of \"%s\" does not match any packing strategy", p)
+}
+
+// InstanceUtilisation describes how much of an Instance is reserved by the
+// Services assigned to it.
+type InstanceUtilisation struct {
+	MemoryGb       float64 `json:"memory"`
+	Vcpu           int     `json:"vcpu"`
+	MemoryFraction float64 `json:"memoryFraction"`
+	VcpuFraction   float64 `json:"vcpuFraction"`
+}
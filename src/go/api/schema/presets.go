@@ -0,0 +1,123 @@
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// A Preset is a named set of defaults for an AdviseRequest, defined in the
+// server's config and selected by a request's preset field.
+type Preset struct {
+	Description string         `json:"description"`
+	Weights     AdvisorWeights `json:"weights"`
+	Options     Options        `json:"options"`
+}
+
+type PresetsResponse struct {
+	Presets map[string]Preset `json:"presets"`
+}
+
+// adviseRequestFields has the fields of an AdviseRequest without its
+// UnmarshalJSON method, so that it can be decoded as usual.
+type adviseRequestFields AdviseRequest
+
+// UnmarshalJSON decodes an AdviseRequest, keeping its JSON so that the
+// fields it gives can later be applied on top of a Preset.
+func (r *AdviseRequest) UnmarshalJSON(data []byte) error {
+	err := json.Unmarshal(data, (*adviseRequestFields)(r))
+	if err != nil {
+		return err
+	}
+	r.body = append([]byte{}, data...)
+	return nil
+}
+
+// ApplyPreset fills in each field of an AdviseRequest which was not given
+// in its JSON from the Preset it names, doing nothing if it names none.
+//
+// Fields given in the request override the preset field by field, with
+// lists such as regions replaced as a whole. Requests which were not decoded
+// from JSON, such as gRPC requests, give each field which is not a zero
+// value. An error is returned if the named preset is not in presets.
+func (r *AdviseRequest) ApplyPreset(presets map[string]Preset) error {
+	if r.Preset == "" {
+		return nil
+	}
+	preset, ok := presets[r.Preset]
+	if !ok {
+		return fmt.Errorf("preset does not exist: %s", r.Preset)
+	}
+
+	body := r.body
+	if body == nil {
+		var err error
+		body, err = nonZeroJson((*adviseRequestFields)(r))
+		if err != nil {
+			return err
+		}
+	}
+
+	req := AdviseRequest{
+		Advisor: Advisor{Weights: preset.Weights},
+		Options: preset.Options.Copy(),
+	}
+
+	err := json.Unmarshal(body, (*adviseRequestFields)(&req))
+	if err != nil {
+		return err
+	}
+	req.body = r.body
+	*r = req
+	return nil
+}
+
+// nonZeroJson encodes v as JSON, leaving out every field with a zero value.
+func nonZeroJson(v interface{}) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var decoded interface{}
+	err = json.Unmarshal(data, &decoded)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(removeZeroValues(decoded))
+}
+
+// removeZeroValues removes the fields of decoded JSON objects which hold
+// zero values, returning nil if nothing is left.
+func removeZeroValues(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, field := range v {
+			if cleaned := removeZeroValues(field); cleaned == nil {
+				delete(v, key)
+			} else {
+				v[key] = cleaned
+			}
+		}
+		if len(v) == 0 {
+			return nil
+		}
+		return v
+	case []interface{}:
+		if len(v) == 0 {
+			return nil
+		}
+		return v
+	case string:
+		if v == "" {
+			return nil
+		}
+	case float64:
+		if v == 0 {
+			return nil
+		}
+	case bool:
+		if !v {
+			return nil
+		}
+	}
+	return value
+}
@@ -0,0 +1,110 @@
+package schema
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestApplyPresetLeavesPresetUnchanged(t *testing.T) {
+	presets := map[string]Preset{
+		"eu": {
+			Weights: AdvisorWeights{Price: 2},
+			Options: Options{
+				Regions:     []string{"eu-west-1", "eu-central-1"},
+				Geographies: []string{"europe", "asia-pacific"},
+				RankBy:      []RankingCriterion{RankByScore, RankByPrice},
+				Filter:      InstanceFilter{Family: "m5"},
+			},
+		},
+	}
+	expected := presets["eu"].Options.Copy()
+
+	bodies := []string{
+		`{"preset":"eu","options":{"regions":["us-east-1"],"geographies":["north-america"],"rankBy":["price"]}}`,
+		`{"preset":"eu","options":{"filter":{"family":"c5"}}}`,
+	}
+	for _, body := range bodies {
+		var req AdviseRequest
+		err := json.Unmarshal([]byte(body), &req)
+		if err != nil {
+			t.Fatalf("Failed to decode request: %s", err.Error())
+		}
+		err = req.ApplyPreset(presets)
+		if err != nil {
+			t.Fatalf("Failed to apply preset: %s", err.Error())
+		}
+
+		if !reflect.DeepEqual(presets["eu"].Options, expected) {
+			t.Fatalf("Preset changed by request %s. Wanted: %+v, got: %+v", body, expected, presets["eu"].Options)
+		}
+	}
+}
+
+func TestApplyPresetOverridesFields(t *testing.T) {
+	presets := map[string]Preset{
+		"eu": {
+			Weights: AdvisorWeights{Price: 2, Availability: 1},
+			Options: Options{
+				Regions:     []string{"eu-west-1"},
+				Geographies: []string{"europe"},
+				RankBy:      []RankingCriterion{RankByScore},
+			},
+		},
+	}
+
+	var req AdviseRequest
+	err := json.Unmarshal([]byte(`{"preset":"eu","options":{"rankBy":["price","score"]}}`), &req)
+	if err != nil {
+		t.Fatalf("Failed to decode request: %s", err.Error())
+	}
+	err = req.ApplyPreset(presets)
+	if err != nil {
+		t.Fatalf("Failed to apply preset: %s", err.Error())
+	}
+
+	if !reflect.DeepEqual(req.Options.Regions, []string{"eu-west-1"}) {
+		t.Fatalf("Wrong regions: %v", req.Options.Regions)
+	}
+	if !reflect.DeepEqual(req.Options.RankBy, []RankingCriterion{RankByPrice, RankByScore}) {
+		t.Fatalf("Wrong rankBy: %v", req.Options.RankBy)
+	}
+	if req.Advisor.Weights.Price != 2 {
+		t.Fatalf("Wrong price weight: %f", req.Advisor.Weights.Price)
+	}
+
+	req.Options.Regions[0] = "us-east-1"
+	if presets["eu"].Options.Regions[0] != "eu-west-1" {
+		t.Fatalf("Preset shares regions with request")
+	}
+}
+
+func TestApplyPresetWithoutJson(t *testing.T) {
+	presets := map[string]Preset{
+		"eu": {
+			Weights: AdvisorWeights{Price: 2, Availability: 1},
+			Options: Options{Regions: []string{"eu-west-1"}, ShareInstancesBetweenServices: true},
+		},
+	}
+
+	req := AdviseRequest{
+		Preset:   "eu",
+		Services: []Service{{Name: "web", MaxVcpu: 2, MaxInstances: 1}},
+		Advisor:  Advisor{Type: Weighted, Weights: AdvisorWeights{Price: 3}},
+		Options:  Options{Geographies: []string{"europe"}},
+	}
+	err := req.ApplyPreset(presets)
+	if err != nil {
+		t.Fatalf("Failed to apply preset: %s", err.Error())
+	}
+
+	if req.Advisor.Weights != (AdvisorWeights{Price: 3, Availability: 1}) {
+		t.Fatalf("Wrong weights: %+v", req.Advisor.Weights)
+	}
+	if !reflect.DeepEqual(req.Options.Regions, []string{"eu-west-1"}) || !req.Options.ShareInstancesBetweenServices {
+		t.Fatalf("Preset options not applied: %+v", req.Options)
+	}
+	if !reflect.DeepEqual(req.Options.Geographies, []string{"europe"}) || len(req.Services) != 1 {
+		t.Fatalf("Request fields not kept: %+v", req)
+	}
+}
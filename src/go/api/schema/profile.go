@@ -0,0 +1,120 @@
+package schema
+
+import (
+	"errors"
+	"fmt"
+)
+
+// A ProfilePeriod is the length of time over which a LoadProfile repeats.
+type ProfilePeriod string
+
+const (
+	DailyProfile  ProfilePeriod = "daily"  // Hourly buckets from midnight UTC
+	WeeklyProfile ProfilePeriod = "weekly" // Hourly buckets from midnight UTC on Monday
+)
+
+const HOURS_PER_DAY = 24
+
+// NewProfilePeriod converts a string into a ProfilePeriod, returning an
+// error if the string does not name a ProfilePeriod.
+func NewProfilePeriod(value string) (ProfilePeriod, error) {
+	switch ProfilePeriod(value) {
+	case DailyProfile, WeeklyProfile:
+		return ProfilePeriod(value), nil
+	}
+	return "", fmt.Errorf("provided value of \"%s\" does not match any profile period", value)
+}
+
+// Hours returns the number of hourly buckets in a ProfilePeriod.
+func (p ProfilePeriod) Hours() int {
+	if p == WeeklyProfile {
+		return 7 * HOURS_PER_DAY
+	}
+	return HOURS_PER_DAY
+}
+
+// A LoadProfile describes how many instances a Service needs in each hour
+// of a repeating period, e.g. to follow diurnal traffic.
+type LoadProfile struct {
+	Period    ProfilePeriod `json:"period"`
+	Instances []int         `json:"instances"` // The instances needed in each hourly bucket
+}
+
+// Validate checks that a LoadProfile is well-formed
+// and is true to the API specification.
+func (p *LoadProfile) Validate() error {
+	_, err := NewProfilePeriod(string(p.Period))
+	if err != nil {
+		return err
+	}
+	if len(p.Instances) != p.Period.Hours() {
+		return fmt.Errorf(
+			"a %s profile needs %d hourly buckets, got %d",
+			p.Period,
+			p.Period.Hours(),
+			len(p.Instances),
+		)
+	}
+	for _, count := range p.Instances {
+		if count < 0 {
+			return errors.New("instances is negative in a bucket")
+		}
+	}
+	if p.Peak() == 0 {
+		return errors.New("instances is zero in every bucket")
+	}
+	return nil
+}
+
+// Baseline returns the fewest instances needed in any bucket of a
+// LoadProfile, which are run throughout the period.
+func (p *LoadProfile) Baseline() int {
+	baseline := p.Peak()
+	for _, count := range p.Instances {
+		if count < baseline {
+			baseline = count
+		}
+	}
+	return baseline
+}
+
+// Peak returns the most instances needed in any bucket of a LoadProfile.
+func (p *LoadProfile) Peak() int {
+	peak := 0
+	for _, count := range p.Instances {
+		if count > peak {
+			peak = count
+		}
+	}
+	return peak
+}
+
+// A ServiceSchedule describes when a Service's instances run, following
+// its LoadProfile.
+type ServiceSchedule struct {
+	Period    ProfilePeriod     `json:"period"`
+	Baseline  int               `json:"baseline"`  // Permanent instances run throughout the period
+	Scheduled []int             `json:"scheduled"` // Transient instances run in each hourly bucket
+	Actions   []ScheduledAction `json:"actions"`   // Changes to transient capacity between buckets
+}
+
+// A ScheduledAction sets the capacity of the auto scaling group running a
+// Service's transient instances at a recurring time, in the form of an
+// auto scaling scheduled action.
+type ScheduledAction struct {
+	Name            string `json:"scheduledActionName"`
+	Recurrence      string `json:"recurrence"` // Cron expression
+	TimeZone        string `json:"timeZone"`
+	MinSize         int    `json:"minSize"`
+	MaxSize         int    `json:"maxSize"`
+	DesiredCapacity int    `json:"desiredCapacity"`
+}
+
+// A CostProjection describes the cost of a RegionAdvice over a period,
+// when each Service's instances only run when its LoadProfile needs them.
+type CostProjection struct {
+	Period              ProfilePeriod `json:"period"`
+	PricePerPeriod      float64       `json:"pricePerPeriod"`
+	AveragePricePerHour float64       `json:"averagePricePerHour"`
+	PeakPricePerHour    float64       `json:"peakPricePerHour"`
+}
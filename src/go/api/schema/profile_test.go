@@ -0,0 +1,40 @@
+package schema
+
+import "testing"
+
+func TestLoadProfile(t *testing.T) {
+	tests := map[string]struct {
+		profile  LoadProfile
+		isValid  bool
+		baseline int
+		peak     int
+	}{
+		"daily":          {LoadProfile{DailyProfile, append([]int{5, 1}, make([]int, 22)...)}, true, 0, 5},
+		"weekly":         {LoadProfile{WeeklyProfile, repeat(3, 168)}, true, 3, 3},
+		"short weekly":   {LoadProfile{WeeklyProfile, repeat(3, 24)}, false, 3, 3},
+		"unknown period": {LoadProfile{"monthly", repeat(3, 24)}, false, 3, 3},
+		"negative":       {LoadProfile{DailyProfile, append([]int{-1, 2}, repeat(2, 22)...)}, false, -1, 2},
+		"all zero":       {LoadProfile{DailyProfile, repeat(0, 24)}, false, 0, 0},
+	}
+
+	for name, test := range tests {
+		err := test.profile.Validate()
+		if (err == nil) != test.isValid {
+			t.Fatalf("Wrong validation result for \"%s\". Wanted valid: %t, got error: %v", name, test.isValid, err)
+		}
+		if baseline := test.profile.Baseline(); baseline != test.baseline {
+			t.Fatalf("Wrong baseline for \"%s\". Wanted: %d, got: %d", name, test.baseline, baseline)
+		}
+		if peak := test.profile.Peak(); peak != test.peak {
+			t.Fatalf("Wrong peak for \"%s\". Wanted: %d, got: %d", name, test.peak, peak)
+		}
+	}
+}
+
+func repeat(count int, times int) []int {
+	values := make([]int, times)
+	for i := range values {
+		values[i] = count
+	}
+	return values
+}
@@ -0,0 +1,41 @@
+package schema
+
+import "fmt"
+
+// A RankingCriterion is a property of a RegionAdvice by which regions are
+// ranked.
+type RankingCriterion string
+
+const (
+	RankByScore       RankingCriterion = "score"       // Highest score first
+	RankByPrice       RankingCriterion = "price"       // Lowest total price per hour first
+	RankByRevocations RankingCriterion = "revocations" // Fewest expected revocations first
+	RankByInstances   RankingCriterion = "instances"   // Fewest instances first
+
+	// Regions which tie on every requested criterion are ordered by region
+	// code. It is reported in a RankedRegion, but cannot be requested.
+	RankByRegion RankingCriterion = "region"
+)
+
+// A RankedRegion summarises the RegionAdvice of one region, for listing the
+// regions of an Advice in rank order.
+type RankedRegion struct {
+	Region       string  `json:"region"`
+	Rank         int     `json:"rank"`
+	Score        float64 `json:"score"`
+	PricePerHour float64 `json:"pricePerHour"`
+
+	// The first criterion on which the region differs from the region ranked
+	// after it (empty for the last region)
+	DecidedBy RankingCriterion `json:"decidedBy,omitempty"`
+}
+
+// Validate checks that a RankingCriterion is well-formed
+// and is true to the API specification.
+func (c RankingCriterion) Validate() error {
+	switch c {
+	case RankByScore, RankByPrice, RankByRevocations, RankByInstances:
+		return nil
+	}
+	return fmt.Errorf("provided value of \"%s\" does not match any ranking criterion", c)
+}
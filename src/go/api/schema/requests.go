@@ -1,9 +1,23 @@
 package schema
 
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
 type AdviseRequest struct {
 	Services []Service `json:"services"`
 	Advisor  Advisor   `json:"advisor"`
 	Options  Options   `json:"options"`
+
+	// When set, advice is generated using instances as they were at this time
+	AsOf *time.Time `json:"asOf,omitempty"`
+
+	// When set, fields not given in the request are taken from this Preset
+	Preset string `json:"preset,omitempty"`
+
+	body []byte // The JSON the request was decoded from
 }
 
 // Validate checks that an AdviseReques is well-formed
@@ -18,5 +32,24 @@ func (r *AdviseRequest) Validate() error {
 		return err
 	}
 	err = r.Options.Validate()
-	return err
+	if err != nil {
+		return err
+	}
+	if !r.Options.CrossRegion {
+		for _, svc := range r.Services {
+			if svc.IsPlaced() {
+				return fmt.Errorf("service %s has minRegions or placement, which need crossRegion", svc.Name)
+			}
+		}
+	} else {
+		for _, svc := range r.Services {
+			if svc.LoadProfile != nil {
+				return fmt.Errorf("service %s has a loadProfile, which cannot be used with crossRegion", svc.Name)
+			}
+		}
+	}
+	if r.AsOf != nil && r.AsOf.After(time.Now()) {
+		return errors.New("asOf cannot be in the future")
+	}
+	return nil
 }
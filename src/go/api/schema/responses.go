@@ -4,4 +4,9 @@ type RegionsResponse struct {
 	Regions []string `json:"regions"`
 }
 
-type AdviseResponse Advice
+// An AdviseResponse contains the Advice for each region, along with a summary
+// of the regions in rank order.
+type AdviseResponse struct {
+	Regions Advice         `json:"regions"`
+	Ranking []RankedRegion `json:"ranking"`
+}
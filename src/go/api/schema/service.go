@@ -1,9 +1,11 @@
 package schema
 
 import (
+	awsTypes "aws-blended-instances-advisor/aws/types"
 	"aws-blended-instances-advisor/utils"
 	"errors"
 	"fmt"
+	"sort"
 )
 
 type Service struct {
@@ -12,17 +14,63 @@ type Service struct {
 	MaxVcpu      int     `json:"maxVcpu"`
 	MinInstances int     `json:"minInstances"`
 	MaxInstances int     `json:"maxInstances"`
+
+	// The vCPUs each instance of the service needs, which are reserved on
+	// instances shared with other services (1 when 0)
+	MinVcpu int `json:"minVcpu,omitempty"`
+
+	// The minimum number of regions the service's instances are spread
+	// across when advising across regions (1 when 0)
+	MinRegions int `json:"minRegions,omitempty"`
+
+	// Where the service's instances can be placed when advising across regions
+	Placement *Placement `json:"placement,omitempty"`
+
+	// The instances needed over time, from which MinInstances and
+	// MaxInstances are taken when given
+	LoadProfile *LoadProfile `json:"loadProfile,omitempty"`
+}
+
+// A Placement constrains which regions a Service's instances can be placed
+// in when advising across regions.
+type Placement struct {
+	// The regions the service can run in, e.g. for data residency (any when empty)
+	Regions []string `json:"regions"`
+
+	// The region nearest the service's users, from which latency is estimated
+	LatencyFrom string `json:"latencyFrom"`
+
+	// The maximum estimated round-trip latency from LatencyFrom in milliseconds
+	// (no limit when 0)
+	MaxLatencyMs float64 `json:"maxLatencyMs"`
 }
 
 // Validate checks that a Service is well-formed
 // and is true to the API specification.
 func (s *Service) Validate() error {
+	if s.LoadProfile != nil {
+		if s.MinInstances != 0 || s.MaxInstances != 0 {
+			return errors.New("minInstances and maxInstances cannot be given with loadProfile")
+		}
+		err := s.LoadProfile.Validate()
+		if err != nil {
+			return utils.PrependToError(err, "loadProfile invalid")
+		}
+		s = s.WithLoadProfile()
+	}
+
 	if s.MinMemory < 0 {
 		return errors.New("minMemory is not positive")
 	}
 	if s.MaxVcpu <= 0 {
 		return errors.New("maxVcpu is not postive")
 	}
+	if s.MinVcpu < 0 {
+		return errors.New("minVcpu is negative")
+	}
+	if s.MinVcpu > s.MaxVcpu {
+		return errors.New("minVcpu is greater than maxVcpu")
+	}
 	if s.MinInstances < 0 {
 		return errors.New("minInstances is not positive")
 	}
@@ -32,6 +80,67 @@ func (s *Service) Validate() error {
 	if s.MinInstances > s.MaxInstances {
 		return errors.New("minInstances is greater than totalInstances")
 	}
+	if s.MinRegions < 0 {
+		return errors.New("minRegions is negative")
+	}
+	if s.MinRegions > s.MaxInstances {
+		return errors.New("minRegions is greater than maxInstances")
+	}
+	if s.Placement != nil {
+		err := s.Placement.Validate()
+		if err != nil {
+			return utils.PrependToError(err, "placement invalid")
+		}
+	}
+	return nil
+}
+
+// ReservedVcpu returns the vCPUs reserved by each instance of a Service.
+func (s *Service) ReservedVcpu() int {
+	if s.MinVcpu == 0 {
+		return 1
+	}
+	return s.MinVcpu
+}
+
+// WithLoadProfile returns a copy of a Service whose MinInstances and
+// MaxInstances are the baseline and peak of its LoadProfile, or the Service
+// itself if it has no LoadProfile.
+func (s *Service) WithLoadProfile() *Service {
+	if s.LoadProfile == nil {
+		return s
+	}
+	profiled := *s
+	profiled.MinInstances = s.LoadProfile.Baseline()
+	profiled.MaxInstances = s.LoadProfile.Peak()
+	return &profiled
+}
+
+// IsPlaced returns true if a Service constrains how its instances are
+// placed across regions, and false otherwise.
+func (s *Service) IsPlaced() bool {
+	return s.MinRegions > 1 || s.Placement != nil
+}
+
+// Validate checks that a Placement is well-formed
+// and is true to the API specification.
+func (p *Placement) Validate() error {
+	_, err := awsTypes.NewRegions(p.Regions)
+	if err != nil {
+		return err
+	}
+	if p.LatencyFrom != "" {
+		_, err = awsTypes.NewRegion(p.LatencyFrom)
+		if err != nil {
+			return utils.PrependToError(err, "latencyFrom invalid")
+		}
+	}
+	if p.MaxLatencyMs < 0 {
+		return errors.New("maxLatencyMs is negative")
+	}
+	if p.MaxLatencyMs > 0 && p.LatencyFrom == "" {
+		return errors.New("maxLatencyMs cannot be given without latencyFrom")
+	}
 	return nil
 }
 
@@ -53,6 +162,14 @@ func ValidateServices(services []Service) error {
 	return nil
 }
 
+// OrderServicesByDecreasingMemory sorts Services in place so that those
+// requiring the most memory come first.
+func OrderServicesByDecreasingMemory(services []Service) {
+	sort.Slice(services, func(i, j int) bool {
+		return services[i].MinMemory > services[j].MinMemory
+	})
+}
+
 func namesAreUnique(services []Service) bool {
 	namesSet := make(map[string]bool)
 	for _, s := range services {
@@ -1,24 +1,25 @@
 package service
 
 import (
+	"aws-blended-instances-advisor/api/auth"
 	"aws-blended-instances-advisor/api/schema"
+	"aws-blended-instances-advisor/catalogue"
 	"aws-blended-instances-advisor/config"
+	"aws-blended-instances-advisor/ranking"
+	"aws-blended-instances-advisor/render"
 	"aws-blended-instances-advisor/utils"
+	"bytes"
 	"encoding/json"
 	"io"
 	"net/http"
-	"sort"
 
 	"go.uber.org/zap"
 )
 
 func getAdviseEndpointHandler(
-	advise func(
-		advisor schema.Advisor,
-		services []schema.Service,
-		options schema.Options,
-	) (*schema.Advice, error),
-	cfg *config.ApiConfig,
+	cat *catalogue.Catalogue,
+	advise AdviseFunc,
+	live *config.Live,
 	logger *zap.Logger,
 ) func(http.ResponseWriter, *http.Request) {
 
@@ -31,7 +32,7 @@ func getAdviseEndpointHandler(
 			zap.String("requestId", reqId),
 		)
 
-		err := utils.AddCorsHeader(w, r, cfg.AllowedDomains)
+		err := utils.AddCorsHeader(w, r, live.AllowedDomains())
 		if err != nil {
 			writeErrorResponse(w, reqId, err, http.StatusForbidden, logger)
 			return
@@ -40,11 +41,11 @@ func getAdviseEndpointHandler(
 
 		switch r.Method {
 		case "OPTIONS":
-			adviseEndpointOptionsHandler(w, r, reqId, logger)
+			optionsHandler(w, reqId, "OPTIONS, POST", logger)
 			return
 
 		case "POST":
-			adviseEndpointPostHandler(w, r, reqId, advise, logger)
+			adviseEndpointPostHandler(w, r, reqId, cat, advise, live.Presets(), logger)
 			return
 
 		default:
@@ -54,58 +55,48 @@ func getAdviseEndpointHandler(
 	}
 }
 
-func adviseEndpointOptionsHandler(
-	w http.ResponseWriter,
-	r *http.Request,
-	reqId string,
-	logger *zap.Logger,
-) {
-	allowedMethods := "OPTIONS, POST"
-	allowedHeaders := getAllowedHeaders()
-	w.Header().Set("Access-Control-Allow-Methods", allowedMethods)
-	w.Header().Set("Access-Control-Allow-Headers", allowedHeaders)
-
-	logger.Info(
-		"added headers to response",
-		zap.String("requestId", reqId),
-		zap.String("Access-Control-Allow-Methods", allowedMethods),
-		zap.String("Access-Control-Allow-Headers", allowedHeaders),
-	)
-
-	w.WriteHeader(http.StatusOK)
-	logger.Info("responded to request", zap.String("requestId", reqId))
-}
-
 func adviseEndpointPostHandler(
 	w http.ResponseWriter,
 	r *http.Request,
 	reqId string,
-	advise func(
-		advisor schema.Advisor,
-		services []schema.Service,
-		options schema.Options,
-	) (*schema.Advice, error),
+	cat *catalogue.Catalogue,
+	advise AdviseFunc,
+	presets map[string]schema.Preset,
 	logger *zap.Logger,
 ) {
-	req, err := parseRequest(r, reqId, logger)
+	format, err := parseFormat(r)
+	if err != nil {
+		writeErrorResponse(w, reqId, err, http.StatusBadRequest, logger)
+		return
+	}
+
+	req, err := parseRequest(r, reqId, presets, logger)
 	if err != nil {
 		writeErrorResponse(w, reqId, err, http.StatusBadRequest, logger)
 		return
 	}
+
+	info, err := cat.GetAsOf(req.AsOf)
+	if err != nil {
+		writeErrorResponse(w, reqId, err, getCatalogueErrorCode(err), logger)
+		return
+	}
+	addStalenessHeaders(w, cat, req.AsOf)
+	auth.LogAudit(r.Context(), r.RemoteAddr, reqId, "advise", req, logger)
 	logger.Info(
 		"services parsed from request",
 		zap.String("requestId", reqId),
 		zap.Any("parsedServices", req.Services),
 	)
 
-	orderServicesByDecreasingMemory(req.Services)
+	schema.OrderServicesByDecreasingMemory(req.Services)
 	logger.Info(
 		"services sorted",
 		zap.String("requestId", reqId),
 		zap.Any("sortedServices", req.Services),
 	)
 
-	advice, err := advise(req.Advisor, req.Services, req.Options)
+	advice, err := advise(r.Context(), *info, req.Advisor, req.Services, req.Options, nil)
 	if err != nil {
 		writeErrorResponse(w, reqId, err, http.StatusInternalServerError, logger)
 		return
@@ -116,14 +107,20 @@ func adviseEndpointPostHandler(
 		zap.Any("advice", advice),
 	)
 
-	err = writeAdviceResponse(w, reqId, advice, logger)
+	resp := &schema.AdviseResponse{Regions: *advice, Ranking: ranking.Summarise(advice, req.Options.RankBy)}
+	err = writeAdviceResponse(w, reqId, resp, format, logger)
 	if err != nil {
 		writeErrorResponse(w, reqId, err, http.StatusInternalServerError, logger)
 		return
 	}
 }
 
-func parseRequest(r *http.Request, reqId string, logger *zap.Logger) (*schema.AdviseRequest, error) {
+func parseRequest(
+	r *http.Request,
+	reqId string,
+	presets map[string]schema.Preset,
+	logger *zap.Logger,
+) (*schema.AdviseRequest, error) {
 	body, err := io.ReadAll(r.Body)
 	if err != nil {
 		return nil, utils.PrependToError(err, "could not read request body")
@@ -141,6 +138,11 @@ func parseRequest(r *http.Request, reqId string, logger *zap.Logger) (*schema.Ad
 		return nil, utils.PrependToError(err, "could not parse body JSON")
 	}
 
+	err = req.ApplyPreset(presets)
+	if err != nil {
+		return nil, utils.PrependToError(err, "could not apply preset")
+	}
+
 	err = req.Validate()
 	if err != nil {
 		return nil, utils.PrependToError(err, "invalid request")
@@ -149,24 +151,41 @@ func parseRequest(r *http.Request, reqId string, logger *zap.Logger) (*schema.Ad
 	return &req, nil
 }
 
-func orderServicesByDecreasingMemory(services []schema.Service) {
-	sort.Slice(services, func(i, j int) bool {
-		return services[i].MinMemory > services[j].MinMemory
-	})
+// parseFormat returns the Format advice should be rendered in, given by the
+// request's "format" query parameter (JSON by default).
+func parseFormat(r *http.Request) (render.Format, error) {
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		return render.JsonFormat, nil
+	}
+	return render.NewFormat(format)
 }
 
 func writeAdviceResponse(
 	w http.ResponseWriter,
 	requestId string,
-	advice *schema.Advice,
+	resp *schema.AdviseResponse,
+	format render.Format,
 	logger *zap.Logger,
 ) error {
-	respBody, err := json.Marshal(advice)
-	if err != nil {
-		return utils.PrependToError(err, "could not marshal advice into JSON")
+	var respBody []byte
+	var err error
+
+	if format == render.JsonFormat {
+		respBody, err = json.Marshal(resp)
+		if err != nil {
+			return utils.PrependToError(err, "could not marshal advice into JSON")
+		}
+	} else {
+		var buf bytes.Buffer
+		err = render.Advice(&buf, resp, format)
+		if err != nil {
+			return utils.PrependToError(err, "could not render advice")
+		}
+		respBody = buf.Bytes()
 	}
 
-	utils.AddJsonContentTypeHeader(w)
+	w.Header().Set("Content-Type", format.ContentType())
 
 	_, err = w.Write(respBody)
 	if err != nil {
@@ -0,0 +1,56 @@
+package service
+
+import (
+	"aws-blended-instances-advisor/api/auth"
+	"aws-blended-instances-advisor/metrics"
+	"aws-blended-instances-advisor/utils"
+	"net/http"
+
+	"go.uber.org/zap"
+)
+
+var (
+	authFailures = metrics.NewCounterVec(
+		"api_auth_failures_total",
+		"Number of requests rejected by API key authentication.",
+		"reason",
+	)
+)
+
+func authenticateHandler(
+	authenticator *auth.Authenticator,
+	handler func(http.ResponseWriter, *http.Request),
+	logger *zap.Logger,
+) func(http.ResponseWriter, *http.Request) {
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !authenticator.Enabled() || r.Method == "OPTIONS" {
+			handler(w, r)
+			return
+		}
+
+		keyName, err := authenticator.Authenticate(r)
+		if err != nil {
+			reqId := utils.GenerateUuid()
+			code := http.StatusUnauthorized
+			switch err {
+			case auth.ErrRateLimited, auth.ErrQuotaExceeded:
+				code = http.StatusTooManyRequests
+			}
+
+			authFailures.Inc(auth.FailureReason(err))
+			logger.Warn(
+				"request rejected by authentication",
+				zap.String("requestId", reqId),
+				zap.String("apiKey", keyName),
+				zap.String("path", r.URL.Path),
+				zap.String("remoteAddr", r.RemoteAddr),
+				zap.Error(err),
+			)
+			writeErrorResponse(w, reqId, err, code, logger)
+			return
+		}
+
+		handler(w, r.WithContext(auth.ContextWithKeyName(r.Context(), keyName)))
+	}
+}
@@ -0,0 +1,146 @@
+package service
+
+import (
+	"aws-blended-instances-advisor/api/auth"
+	"aws-blended-instances-advisor/api/schema"
+	"aws-blended-instances-advisor/batch"
+	"aws-blended-instances-advisor/catalogue"
+	"aws-blended-instances-advisor/config"
+	"aws-blended-instances-advisor/utils"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"go.uber.org/zap"
+)
+
+func getBatchEndpointHandler(
+	cat *catalogue.Catalogue,
+	advise AdviseFunc,
+	live *config.Live,
+	logger *zap.Logger,
+) func(http.ResponseWriter, *http.Request) {
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		reqId := utils.GenerateUuid()
+		logger.Info(
+			"request received",
+			zap.String("url", r.Host),
+			zap.String("method", r.Method),
+			zap.String("requestId", reqId),
+		)
+
+		err := utils.AddCorsHeader(w, r, live.AllowedDomains())
+		if err != nil {
+			writeErrorResponse(w, reqId, err, http.StatusForbidden, logger)
+			return
+		}
+
+		switch r.Method {
+		case "OPTIONS":
+			optionsHandler(w, reqId, "OPTIONS, POST", logger)
+			return
+
+		case "POST":
+			batchEndpointPostHandler(w, r, reqId, cat, advise, live.Presets(), logger)
+			return
+
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+	}
+}
+
+func batchEndpointPostHandler(
+	w http.ResponseWriter,
+	r *http.Request,
+	reqId string,
+	cat *catalogue.Catalogue,
+	advise AdviseFunc,
+	presets map[string]schema.Preset,
+	logger *zap.Logger,
+) {
+	req, err := parseBatchRequest(r, reqId, presets, logger)
+	if err != nil {
+		writeErrorResponse(w, reqId, err, http.StatusBadRequest, logger)
+		return
+	}
+
+	info, err := cat.Get()
+	if err != nil {
+		writeErrorResponse(w, reqId, err, getCatalogueErrorCode(err), logger)
+		return
+	}
+	addStalenessHeaders(w, cat, nil)
+
+	for _, scenario := range req.Scenarios {
+		auth.LogAudit(r.Context(), r.RemoteAddr, reqId, "batch:"+scenario.Name, &scenario.Request, logger)
+		schema.OrderServicesByDecreasingMemory(scenario.Request.Services)
+	}
+
+	resp := batch.Run(
+		r.Context(),
+		req.Scenarios,
+		func(ctx context.Context, scenarioReq schema.AdviseRequest) (*schema.Advice, error) {
+			scenarioInfo := info
+			if scenarioReq.AsOf != nil {
+				pastInfo, err := cat.GetAsOf(scenarioReq.AsOf)
+				if err != nil {
+					return nil, err
+				}
+				scenarioInfo = pastInfo
+			}
+			return advise(ctx, *scenarioInfo, scenarioReq.Advisor, scenarioReq.Services, scenarioReq.Options, nil)
+		},
+		logger,
+	)
+	logger.Info(
+		"batch advice generated for request",
+		zap.String("requestId", reqId),
+		zap.Int("scenarioCount", len(req.Scenarios)),
+		zap.Int("failedScenarioCount", len(resp.Errors)),
+	)
+
+	err = writeJsonResponse(w, reqId, resp, http.StatusOK, logger)
+	if err != nil {
+		writeErrorResponse(w, reqId, err, http.StatusInternalServerError, logger)
+	}
+}
+
+func parseBatchRequest(
+	r *http.Request,
+	reqId string,
+	presets map[string]schema.Preset,
+	logger *zap.Logger,
+) (*schema.BatchAdviseRequest, error) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, utils.PrependToError(err, "could not read request body")
+	}
+
+	logger.Info(
+		"request body read",
+		zap.String("requestId", reqId),
+		zap.ByteString("requestBody", body),
+	)
+
+	var req schema.BatchAdviseRequest
+	err = json.Unmarshal(body, &req)
+	if err != nil {
+		return nil, utils.PrependToError(err, "could not parse body JSON")
+	}
+
+	err = req.ApplyPresets(presets)
+	if err != nil {
+		return nil, utils.PrependToError(err, "could not apply preset")
+	}
+
+	err = req.Validate()
+	if err != nil {
+		return nil, utils.PrependToError(err, "invalid request")
+	}
+
+	return &req, nil
+}
@@ -0,0 +1,195 @@
+package service
+
+import (
+	"aws-blended-instances-advisor/api/auth"
+	"aws-blended-instances-advisor/api/schema"
+	"aws-blended-instances-advisor/catalogue"
+	"aws-blended-instances-advisor/config"
+	"aws-blended-instances-advisor/diff"
+	instPkg "aws-blended-instances-advisor/instances"
+	"aws-blended-instances-advisor/render"
+	"aws-blended-instances-advisor/utils"
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"go.uber.org/zap"
+)
+
+func getDiffEndpointHandler(
+	cat *catalogue.Catalogue,
+	advise AdviseFunc,
+	live *config.Live,
+	logger *zap.Logger,
+) func(http.ResponseWriter, *http.Request) {
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		reqId := utils.GenerateUuid()
+		logger.Info(
+			"request received",
+			zap.String("url", r.Host),
+			zap.String("method", r.Method),
+			zap.String("requestId", reqId),
+		)
+
+		err := utils.AddCorsHeader(w, r, live.AllowedDomains())
+		if err != nil {
+			writeErrorResponse(w, reqId, err, http.StatusForbidden, logger)
+			return
+		}
+
+		switch r.Method {
+		case "OPTIONS":
+			optionsHandler(w, reqId, "OPTIONS, POST", logger)
+			return
+
+		case "POST":
+			diffEndpointPostHandler(w, r, reqId, cat, advise, live.Presets(), logger)
+			return
+
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+	}
+}
+
+func diffEndpointPostHandler(
+	w http.ResponseWriter,
+	r *http.Request,
+	reqId string,
+	cat *catalogue.Catalogue,
+	advise AdviseFunc,
+	presets map[string]schema.Preset,
+	logger *zap.Logger,
+) {
+	format, err := parseFormat(r)
+	if err != nil {
+		writeErrorResponse(w, reqId, err, http.StatusBadRequest, logger)
+		return
+	}
+
+	req, err := parseDiffRequest(r, reqId, presets, logger)
+	if err != nil {
+		writeErrorResponse(w, reqId, err, http.StatusBadRequest, logger)
+		return
+	}
+
+	var beforeInfo, afterInfo *instPkg.GlobalInfo
+	if req.Before == nil {
+		beforeInfo, err = cat.GetAsOf(req.BeforeAsOf)
+		if err != nil {
+			writeErrorResponse(w, reqId, err, getCatalogueErrorCode(err), logger)
+			return
+		}
+	}
+	if req.After == nil {
+		afterInfo, err = cat.GetAsOf(req.GetAfterAsOf())
+		if err != nil {
+			writeErrorResponse(w, reqId, err, getCatalogueErrorCode(err), logger)
+			return
+		}
+		addStalenessHeaders(w, cat, req.GetAfterAsOf())
+	}
+
+	if req.Request != nil {
+		auth.LogAudit(r.Context(), r.RemoteAddr, reqId, "diff", req.Request, logger)
+		schema.OrderServicesByDecreasingMemory(req.Request.Services)
+	}
+
+	before := req.Before
+	if before == nil {
+		before, err = advise(r.Context(), *beforeInfo, req.Request.Advisor, req.Request.Services, req.Request.Options, nil)
+		if err != nil {
+			writeErrorResponse(w, reqId, err, http.StatusInternalServerError, logger)
+			return
+		}
+	}
+
+	after := req.After
+	if after == nil {
+		after, err = advise(r.Context(), *afterInfo, req.Request.Advisor, req.Request.Services, req.Request.Options, nil)
+		if err != nil {
+			writeErrorResponse(w, reqId, err, http.StatusInternalServerError, logger)
+			return
+		}
+	}
+
+	adviceDiff := diff.Compare(before, after)
+	logger.Info("advice compared for request", zap.String("requestId", reqId))
+
+	if format == render.JsonFormat {
+		err = writeJsonResponse(w, reqId, adviceDiff, http.StatusOK, logger)
+	} else {
+		err = writeRenderedDiffResponse(w, reqId, &adviceDiff, format, logger)
+	}
+	if err != nil {
+		writeErrorResponse(w, reqId, err, http.StatusInternalServerError, logger)
+	}
+}
+
+func parseDiffRequest(
+	r *http.Request,
+	reqId string,
+	presets map[string]schema.Preset,
+	logger *zap.Logger,
+) (*schema.DiffRequest, error) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, utils.PrependToError(err, "could not read request body")
+	}
+
+	logger.Info(
+		"request body read",
+		zap.String("requestId", reqId),
+		zap.ByteString("requestBody", body),
+	)
+
+	var req schema.DiffRequest
+	err = json.Unmarshal(body, &req)
+	if err != nil {
+		return nil, utils.PrependToError(err, "could not parse body JSON")
+	}
+
+	err = req.ApplyPreset(presets)
+	if err != nil {
+		return nil, utils.PrependToError(err, "could not apply preset")
+	}
+
+	err = req.Validate()
+	if err != nil {
+		return nil, utils.PrependToError(err, "invalid request")
+	}
+
+	return &req, nil
+}
+
+func writeRenderedDiffResponse(
+	w http.ResponseWriter,
+	requestId string,
+	adviceDiff *schema.AdviceDiff,
+	format render.Format,
+	logger *zap.Logger,
+) error {
+	var buf bytes.Buffer
+	err := render.AdviceDiff(&buf, adviceDiff, format)
+	if err != nil {
+		return utils.PrependToError(err, "could not render advice diff")
+	}
+
+	w.Header().Set("Content-Type", format.ContentType())
+
+	_, err = w.Write(buf.Bytes())
+	if err != nil {
+		return utils.PrependToError(err, "could not write body of HTTP response")
+	}
+
+	logger.Info(
+		"responded to request",
+		zap.String("requestId", requestId),
+		zap.Int("responseCode", http.StatusOK),
+	)
+
+	return nil
+}
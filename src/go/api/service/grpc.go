@@ -0,0 +1,90 @@
+package service
+
+import (
+	"aws-blended-instances-advisor/api/auth"
+	"aws-blended-instances-advisor/api/rpc"
+	"aws-blended-instances-advisor/api/rpc/pb"
+	"aws-blended-instances-advisor/catalogue"
+	"aws-blended-instances-advisor/config"
+	"aws-blended-instances-advisor/utils"
+	"context"
+	"net"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+func createGrpcServer(
+	cfg *config.ApiConfig,
+	authenticator *auth.Authenticator,
+	cat *catalogue.Catalogue,
+	advise AdviseFunc,
+	live *config.Live,
+	logger *zap.Logger,
+) (*grpc.Server, error) {
+	opts := []grpc.ServerOption{
+		grpc.UnaryInterceptor(rpc.UnaryAuthInterceptor(authenticator, logger)),
+		grpc.StreamInterceptor(rpc.StreamAuthInterceptor(authenticator, logger)),
+	}
+
+	if cfg.Tls.Enabled() {
+		tlsConfig, err := createTlsConfig(&cfg.Tls)
+		if err != nil {
+			return nil, err
+		}
+
+		cert, err := loadCertificate(&cfg.Tls)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.Certificates = append(tlsConfig.Certificates, cert)
+
+		opts = append(opts, grpc.Creds(credentials.NewTLS(tlsConfig)))
+	}
+
+	server := grpc.NewServer(opts...)
+	pb.RegisterAdvisorServiceServer(server, rpc.NewServer(cat, rpc.AdviseFunc(advise), live.Presets, logger))
+
+	return server, nil
+}
+
+// serveGrpc starts serving gRPC requests in the background, sending an
+// error to serveErr if the server stops unexpectedly.
+func serveGrpc(cfg *config.ApiConfig, server *grpc.Server, serveErr chan<- error, logger *zap.Logger) error {
+	address := formatAddress(cfg.Host, cfg.GrpcPort)
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return utils.PrependToError(err, "failed to listen for gRPC requests")
+	}
+
+	go func() {
+		logger.Info(
+			"starting gRPC interface for advice service",
+			zap.String("address", address),
+			zap.Bool("tls", cfg.Tls.Enabled()),
+		)
+		err := server.Serve(listener)
+		if err != nil {
+			serveErr <- utils.PrependToError(err, "gRPC interface stopped")
+		}
+	}()
+
+	return nil
+}
+
+// stopGrpc gracefully stops a gRPC server, forcibly closing any remaining
+// calls once ctx is done.
+func stopGrpc(ctx context.Context, server *grpc.Server) {
+	stopped := make(chan struct{})
+	go func() {
+		server.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		server.Stop()
+	}
+}
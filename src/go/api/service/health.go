@@ -0,0 +1,113 @@
+package service
+
+import (
+	"aws-blended-instances-advisor/api/schema"
+	"aws-blended-instances-advisor/cache"
+	"aws-blended-instances-advisor/catalogue"
+	"aws-blended-instances-advisor/config"
+	"aws-blended-instances-advisor/utils"
+	"net/http"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+func getHealthzEndpointHandler(logger *zap.Logger) func(http.ResponseWriter, *http.Request) {
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		reqId := utils.GenerateUuid()
+
+		err := writeJsonResponse(w, reqId, schema.HealthResponse{Status: "ok"}, http.StatusOK, logger)
+		if err != nil {
+			writeErrorResponse(w, reqId, err, http.StatusInternalServerError, logger)
+		}
+	}
+}
+
+func getReadyzEndpointHandler(cat *catalogue.Catalogue, logger *zap.Logger) func(http.ResponseWriter, *http.Request) {
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		reqId := utils.GenerateUuid()
+
+		resp, code := schema.HealthResponse{Status: "ready"}, http.StatusOK
+		if !cat.IsReady() {
+			resp, code = schema.HealthResponse{Status: "not ready"}, http.StatusServiceUnavailable
+		} else if cat.IsStale() {
+			resp = schema.HealthResponse{Status: "stale"} // Still ready, while instances are revalidated
+		}
+
+		err := writeJsonResponse(w, reqId, resp, code, logger)
+		if err != nil {
+			writeErrorResponse(w, reqId, err, http.StatusInternalServerError, logger)
+		}
+	}
+}
+
+func getStatusEndpointHandler(
+	cat *catalogue.Catalogue,
+	c cache.Cache,
+	live *config.Live,
+	logger *zap.Logger,
+) func(http.ResponseWriter, *http.Request) {
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		reqId := utils.GenerateUuid()
+		logger.Info(
+			"request received",
+			zap.String("url", r.Host),
+			zap.String("requestId", reqId),
+		)
+
+		utils.AddCorsHeader(w, r, live.AllowedDomains())
+
+		err := writeJsonResponse(w, reqId, createStatusResponse(cat, c), http.StatusOK, logger)
+		if err != nil {
+			writeErrorResponse(w, reqId, err, http.StatusInternalServerError, logger)
+		}
+	}
+}
+
+func createStatusResponse(cat *catalogue.Catalogue, c cache.Cache) schema.StatusResponse {
+	status := cat.Status()
+	now := time.Now()
+
+	resp := schema.StatusResponse{
+		Ready:        status.Ready,
+		Regions:      make(map[string]schema.RegionStatus),
+		CacheEntries: make(map[string]schema.CacheEntryStatus),
+	}
+
+	if status.Ready {
+		resp.LoadedAt = &status.LoadedAt
+		resp.FetchedAt = &status.FetchedAt
+		resp.DataAgeSeconds = now.Sub(status.FetchedAt).Seconds()
+		resp.Stale = status.Stale
+		if !status.ValidUntil.IsZero() {
+			resp.ValidUntil = &status.ValidUntil
+		}
+	}
+	if status.LastError != nil {
+		resp.LastError = status.LastError.Error()
+		resp.LastErrorAt = &status.LastErrorAt
+	}
+
+	if info, err := cat.Get(); err == nil {
+		for region, regionInfo := range info.RegionInfoMap {
+			resp.Regions[region.CodeString()] = schema.RegionStatus{
+				OnDemandCount: len(regionInfo.PermanentInstances),
+				SpotCount:     len(regionInfo.TransientInstances),
+			}
+		}
+	}
+
+	entries, _ := c.List() // An unreadable cache is reported as having no entries
+	for file, entry := range entries {
+		resp.CacheEntries[file] = schema.CacheEntryStatus{
+			SetAt:       entry.SetDate,
+			InvalidFrom: entry.InvalidationDate,
+			Valid:       entry.InvalidationDate.After(now),
+		}
+	}
+
+	return resp
+}
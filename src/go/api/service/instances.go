@@ -0,0 +1,168 @@
+package service
+
+import (
+	awsTypes "aws-blended-instances-advisor/aws/types"
+	"aws-blended-instances-advisor/catalogue"
+	"aws-blended-instances-advisor/config"
+	"aws-blended-instances-advisor/instances/filter"
+	"aws-blended-instances-advisor/utils"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+func getInstancesEndpointHandler(
+	cat *catalogue.Catalogue,
+	live *config.Live,
+	logger *zap.Logger,
+) func(http.ResponseWriter, *http.Request) {
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		reqId := utils.GenerateUuid()
+		logger.Info(
+			"request received",
+			zap.String("url", r.Host),
+			zap.String("query", r.URL.RawQuery),
+			zap.String("requestId", reqId),
+		)
+
+		utils.AddCorsHeader(w, r, live.AllowedDomains())
+
+		if r.Method != "GET" {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		query, err := parseInstancesQuery(r.URL.Query())
+		if err != nil {
+			writeErrorResponse(w, reqId, err, http.StatusBadRequest, logger)
+			return
+		}
+
+		info, err := cat.Get()
+		if err != nil {
+			writeErrorResponse(w, reqId, err, getCatalogueErrorCode(err), logger)
+			return
+		}
+		addStalenessHeaders(w, cat, nil)
+
+		resp := catalogue.QueryInstances(*info, *query)
+
+		err = writeJsonResponse(w, reqId, resp, http.StatusOK, logger)
+		if err != nil {
+			writeErrorResponse(w, reqId, err, http.StatusInternalServerError, logger)
+		}
+	}
+}
+
+func getAggregatesEndpointHandler(
+	cat *catalogue.Catalogue,
+	live *config.Live,
+	logger *zap.Logger,
+) func(http.ResponseWriter, *http.Request) {
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		reqId := utils.GenerateUuid()
+		logger.Info(
+			"request received",
+			zap.String("url", r.Host),
+			zap.String("requestId", reqId),
+		)
+
+		utils.AddCorsHeader(w, r, live.AllowedDomains())
+
+		if r.Method != "GET" {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		info, err := cat.Get()
+		if err != nil {
+			writeErrorResponse(w, reqId, err, getCatalogueErrorCode(err), logger)
+			return
+		}
+		addStalenessHeaders(w, cat, nil)
+
+		resp := catalogue.GetAggregates(*info)
+
+		err = writeJsonResponse(w, reqId, resp, http.StatusOK, logger)
+		if err != nil {
+			writeErrorResponse(w, reqId, err, http.StatusInternalServerError, logger)
+		}
+	}
+}
+
+func parseInstancesQuery(values url.Values) (*catalogue.Query, error) {
+	var err error
+	query := catalogue.NewQuery()
+
+	regionValues := []string{}
+	for _, value := range values["region"] {
+		regionValues = append(regionValues, strings.Split(value, ",")...)
+	}
+	query.Filter.Regions, err = awsTypes.NewRegions(regionValues)
+	if err != nil {
+		return nil, err
+	}
+
+	query.Filter.Market, err = filter.NewMarket(values.Get("market"))
+	if err != nil {
+		return nil, err
+	}
+
+	query.Filter.OperatingSystem = values.Get("os")
+	query.Filter.Family = values.Get("family")
+
+	floatParams := map[string]*float64{
+		"minMemory": &query.Filter.MinMemory,
+		"minPrice":  &query.Filter.MinPrice,
+		"maxPrice":  &query.Filter.MaxPrice,
+	}
+	for name, dest := range floatParams {
+		if values.Get(name) == "" {
+			continue
+		}
+		*dest, err = strconv.ParseFloat(values.Get(name), 64)
+		if err != nil || *dest < 0 {
+			return nil, fmt.Errorf("%s is not a non-negative number", name)
+		}
+	}
+
+	intParams := map[string]*int{
+		"minVcpu":  &query.Filter.MinVcpu,
+		"maxVcpu":  &query.Filter.MaxVcpu,
+		"page":     &query.Page,
+		"pageSize": &query.PageSize,
+	}
+	for name, dest := range intParams {
+		if values.Get(name) == "" {
+			continue
+		}
+		*dest, err = strconv.Atoi(values.Get(name))
+		if err != nil || *dest < 0 {
+			return nil, fmt.Errorf("%s is not a non-negative integer", name)
+		}
+	}
+
+	query.SortBy = values.Get("sort")
+
+	switch values.Get("order") {
+	case "", "asc":
+		query.Descending = false
+	case "desc":
+		query.Descending = true
+	default:
+		return nil, fmt.Errorf("order must be asc or desc")
+	}
+
+	err = query.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	return &query, nil
+}
@@ -0,0 +1,160 @@
+package service
+
+import (
+	"aws-blended-instances-advisor/advisor"
+	"aws-blended-instances-advisor/api/auth"
+	"aws-blended-instances-advisor/api/jobs"
+	"aws-blended-instances-advisor/api/schema"
+	"aws-blended-instances-advisor/catalogue"
+	"aws-blended-instances-advisor/config"
+	"aws-blended-instances-advisor/utils"
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+const JOBS_PATH = "/jobs"
+
+func getJobsEndpointHandler(
+	manager *jobs.Manager,
+	cat *catalogue.Catalogue,
+	advise AdviseFunc,
+	live *config.Live,
+	logger *zap.Logger,
+) func(http.ResponseWriter, *http.Request) {
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		reqId := utils.GenerateUuid()
+		logger.Info(
+			"request received",
+			zap.String("url", r.Host),
+			zap.String("path", r.URL.Path),
+			zap.String("method", r.Method),
+			zap.String("requestId", reqId),
+		)
+
+		err := utils.AddCorsHeader(w, r, live.AllowedDomains())
+		if err != nil {
+			writeErrorResponse(w, reqId, err, http.StatusForbidden, logger)
+			return
+		}
+
+		jobId := strings.Trim(strings.TrimPrefix(r.URL.Path, JOBS_PATH), "/")
+
+		if jobId == "" {
+			switch r.Method {
+			case "OPTIONS":
+				optionsHandler(w, reqId, "OPTIONS, POST", logger)
+			case "POST":
+				jobsEndpointPostHandler(w, r, reqId, manager, cat, advise, live.Presets(), logger)
+			default:
+				w.WriteHeader(http.StatusMethodNotAllowed)
+			}
+			return
+		}
+
+		switch r.Method {
+		case "OPTIONS":
+			optionsHandler(w, reqId, "OPTIONS, GET, DELETE", logger)
+		case "GET":
+			jobEndpointGetHandler(w, reqId, jobId, manager, logger)
+		case "DELETE":
+			auth.LogAudit(r.Context(), r.RemoteAddr, reqId, "cancelJob:"+jobId, nil, logger)
+			jobEndpointDeleteHandler(w, reqId, jobId, manager, logger)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	}
+}
+
+func jobsEndpointPostHandler(
+	w http.ResponseWriter,
+	r *http.Request,
+	reqId string,
+	manager *jobs.Manager,
+	cat *catalogue.Catalogue,
+	advise AdviseFunc,
+	presets map[string]schema.Preset,
+	logger *zap.Logger,
+) {
+	req, err := parseRequest(r, reqId, presets, logger)
+	if err != nil {
+		writeErrorResponse(w, reqId, err, http.StatusBadRequest, logger)
+		return
+	}
+
+	info, err := cat.GetAsOf(req.AsOf)
+	if err != nil {
+		writeErrorResponse(w, reqId, err, getCatalogueErrorCode(err), logger)
+		return
+	}
+	addStalenessHeaders(w, cat, req.AsOf)
+
+	auth.LogAudit(r.Context(), r.RemoteAddr, reqId, "createJob", req, logger)
+	schema.OrderServicesByDecreasingMemory(req.Services)
+
+	jobId := manager.Submit(
+		req.Options.GetRegionCodes(),
+		func(ctx context.Context, onProgress advisor.ProgressHandler) (*schema.Advice, error) {
+			return advise(ctx, *info, req.Advisor, req.Services, req.Options, onProgress)
+		},
+	)
+	logger.Info(
+		"advice job created for request",
+		zap.String("requestId", reqId),
+		zap.String("jobId", jobId),
+	)
+
+	w.Header().Set("Location", JOBS_PATH+"/"+jobId)
+	err = writeJsonResponse(w, reqId, schema.JobCreatedResponse{Id: jobId}, http.StatusAccepted, logger)
+	if err != nil {
+		writeErrorResponse(w, reqId, err, http.StatusInternalServerError, logger)
+	}
+}
+
+func jobEndpointGetHandler(
+	w http.ResponseWriter,
+	reqId string,
+	jobId string,
+	manager *jobs.Manager,
+	logger *zap.Logger,
+) {
+	job, err := manager.Get(jobId)
+	if err != nil {
+		writeErrorResponse(w, reqId, err, http.StatusNotFound, logger)
+		return
+	}
+
+	err = writeJsonResponse(w, reqId, job, http.StatusOK, logger)
+	if err != nil {
+		writeErrorResponse(w, reqId, err, http.StatusInternalServerError, logger)
+	}
+}
+
+func jobEndpointDeleteHandler(
+	w http.ResponseWriter,
+	reqId string,
+	jobId string,
+	manager *jobs.Manager,
+	logger *zap.Logger,
+) {
+	err := manager.Cancel(jobId)
+	if err != nil {
+		writeErrorResponse(w, reqId, err, http.StatusNotFound, logger)
+		return
+	}
+
+	job, err := manager.Get(jobId)
+	if err != nil {
+		writeErrorResponse(w, reqId, errors.New("job removed after cancellation"), http.StatusGone, logger)
+		return
+	}
+
+	err = writeJsonResponse(w, reqId, job, http.StatusOK, logger)
+	if err != nil {
+		writeErrorResponse(w, reqId, err, http.StatusInternalServerError, logger)
+	}
+}
@@ -0,0 +1,116 @@
+package service
+
+import (
+	"aws-blended-instances-advisor/catalogue"
+	"aws-blended-instances-advisor/metrics"
+	"aws-blended-instances-advisor/utils"
+	"net/http"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+var (
+	apiRequests = metrics.NewCounterVec(
+		"api_requests_total",
+		"Number of HTTP requests handled by the API.",
+		"endpoint", "method", "status",
+	)
+	apiRequestDuration = metrics.NewHistogramVec(
+		"api_request_duration_seconds",
+		"Time taken to handle HTTP requests to the API.",
+		metrics.DefaultBuckets,
+		"endpoint", "method", "status",
+	)
+)
+
+// statusRecorder is a http.ResponseWriter which records the status code
+// written to it.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (rec *statusRecorder) WriteHeader(status int) {
+	rec.status = status
+	rec.ResponseWriter.WriteHeader(status)
+}
+
+// Flush allows handlers to stream responses through a statusRecorder.
+func (rec *statusRecorder) Flush() {
+	if flusher, ok := rec.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func instrumentHandler(
+	endpoint string,
+	handler func(http.ResponseWriter, *http.Request),
+) func(http.ResponseWriter, *http.Request) {
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+
+		handler(rec, r)
+
+		status := strconv.Itoa(rec.status)
+		apiRequests.Inc(endpoint, r.Method, status)
+		apiRequestDuration.Observe(time.Since(start).Seconds(), endpoint, r.Method, status)
+	}
+}
+
+var (
+	catalogueMetricsOnce sync.Once
+	metricsCatalogue     atomic.Value // Holds the *catalogue.Catalogue reported on
+)
+
+// registerCatalogueMetrics reports on cat in the catalogue gauges. The gauges
+// are registered once, so that the service can be started again, with later
+// calls replacing the catalogue they report on.
+func registerCatalogueMetrics(cat *catalogue.Catalogue) {
+	metricsCatalogue.Store(cat)
+
+	catalogueMetricsOnce.Do(func() {
+		metrics.NewGaugeFunc(
+			"catalogue_age_seconds",
+			"Time since the loaded instance data was fetched from AWS.",
+			func() float64 {
+				status := getMetricsCatalogue().Status()
+				if !status.Ready {
+					return 0
+				}
+				return time.Since(status.FetchedAt).Seconds()
+			},
+		)
+		metrics.NewGaugeFunc(
+			"catalogue_ready",
+			"Whether instance data has been loaded (1) or not (0).",
+			func() float64 {
+				if getMetricsCatalogue().IsReady() {
+					return 1
+				}
+				return 0
+			},
+		)
+	})
+}
+
+func getMetricsCatalogue() *catalogue.Catalogue {
+	return metricsCatalogue.Load().(*catalogue.Catalogue)
+}
+
+func getMetricsEndpointHandler(logger *zap.Logger) func(http.ResponseWriter, *http.Request) {
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+
+		err := metrics.DefaultRegistry.Write(w)
+		if err != nil {
+			writeErrorResponse(w, utils.GenerateUuid(), err, http.StatusInternalServerError, logger)
+		}
+	}
+}
@@ -0,0 +1,28 @@
+package service
+
+import (
+	"aws-blended-instances-advisor/catalogue"
+	instPkg "aws-blended-instances-advisor/instances"
+	"aws-blended-instances-advisor/metrics"
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRegisterCatalogueMetricsTwice(t *testing.T) {
+	registerCatalogueMetrics(catalogue.New())
+
+	ready := catalogue.New()
+	ready.Set(&instPkg.GlobalInfo{}, time.Now())
+	registerCatalogueMetrics(ready)
+
+	var buf bytes.Buffer
+	err := metrics.DefaultRegistry.Write(&buf)
+	if err != nil {
+		t.Fatalf("Failed to write metrics: %s", err.Error())
+	}
+	if !strings.Contains(buf.String(), "\ncatalogue_ready 1\n") {
+		t.Fatalf("Expected the last registered catalogue to be reported:\n%s", buf.String())
+	}
+}
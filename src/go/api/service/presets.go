@@ -0,0 +1,52 @@
+package service
+
+import (
+	"aws-blended-instances-advisor/api/schema"
+	"aws-blended-instances-advisor/config"
+	"aws-blended-instances-advisor/utils"
+	"net/http"
+
+	"go.uber.org/zap"
+)
+
+func getPresetsEndpointHandler(live *config.Live, logger *zap.Logger) func(http.ResponseWriter, *http.Request) {
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		reqId := utils.GenerateUuid()
+		logger.Info(
+			"request received",
+			zap.String("url", r.Host),
+			zap.String("method", r.Method),
+			zap.String("requestId", reqId),
+		)
+
+		err := utils.AddCorsHeader(w, r, live.AllowedDomains())
+		if err != nil {
+			writeErrorResponse(w, reqId, err, http.StatusForbidden, logger)
+			return
+		}
+
+		switch r.Method {
+		case "OPTIONS":
+			optionsHandler(w, reqId, "OPTIONS, GET", logger)
+			return
+
+		case "GET":
+			presets := live.Presets()
+			if presets == nil {
+				presets = map[string]schema.Preset{}
+			}
+			resp := schema.PresetsResponse{Presets: presets}
+
+			err = writeJsonResponse(w, reqId, resp, http.StatusOK, logger)
+			if err != nil {
+				writeErrorResponse(w, reqId, err, http.StatusInternalServerError, logger)
+			}
+			return
+
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+	}
+}
@@ -11,7 +11,7 @@ import (
 	"go.uber.org/zap"
 )
 
-func getRegionsEndpointHandler(cfg *config.ApiConfig, logger *zap.Logger) func(http.ResponseWriter, *http.Request) {
+func getRegionsEndpointHandler(live *config.Live, logger *zap.Logger) func(http.ResponseWriter, *http.Request) {
 
 	return func(w http.ResponseWriter, r *http.Request) {
 		reqId := utils.GenerateUuid()
@@ -21,7 +21,7 @@ func getRegionsEndpointHandler(cfg *config.ApiConfig, logger *zap.Logger) func(h
 			zap.String("requestId", reqId),
 		)
 
-		utils.AddCorsHeader(w, r, cfg.AllowedDomains)
+		utils.AddCorsHeader(w, r, live.AllowedDomains())
 
 		regions := []string{}
 		for _, r := range awsTypes.GetAllRegions() {
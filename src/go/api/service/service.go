@@ -1,33 +1,199 @@
 package service
 
 import (
+	"aws-blended-instances-advisor/advisor"
+	"aws-blended-instances-advisor/api/auth"
+	"aws-blended-instances-advisor/api/jobs"
 	"aws-blended-instances-advisor/api/schema"
+	"aws-blended-instances-advisor/cache"
+	"aws-blended-instances-advisor/catalogue"
 	"aws-blended-instances-advisor/config"
+	instPkg "aws-blended-instances-advisor/instances"
+	"aws-blended-instances-advisor/utils"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
 	"net/http"
 	"strconv"
+	"time"
 
 	"go.uber.org/zap"
+	"google.golang.org/grpc"
 )
 
-// StartAdviseService initialises HTTP endpoints for the API.
+// An AdviseFunc generates Advice for a set of Services from the given
+// instance information, stopping once ctx is done and reporting per-region
+// progress to onProgress (which may be nil).
+type AdviseFunc func(
+	ctx context.Context,
+	info instPkg.GlobalInfo,
+	advisor schema.Advisor,
+	services []schema.Service,
+	options schema.Options,
+	onProgress advisor.ProgressHandler,
+) (*schema.Advice, error)
+
+// StartService initialises HTTP endpoints for the API, and the gRPC
+// interface if a gRPC port is configured.
+//
+// Blocks the current thread until ctx is done or the API fails. Once ctx is
+// done, the API stops accepting requests and in-flight requests are given
+// the configured shutdown timeout to complete. The domains allowed to
+// access the API are read from live for each request.
 //
-// Blocks the current thread, until failure, at which point the error is logged
-// and the service is stopped.
+// Returns an error if the API could not be started or failed while running.
 func StartService(
+	ctx context.Context,
 	cfg *config.ApiConfig,
+	live *config.Live,
 	logger *zap.Logger,
-	advise func(advisor schema.Advisor, services []schema.Service, options schema.Options) (*schema.Advice, error),
-) {
-	http.HandleFunc("/regions", getRegionsEndpointHandler(cfg, logger))
-	http.HandleFunc("/advise", getAdviseEndpointHandler(advise, cfg, logger))
-	logger.Info("registered API endpoint", zap.String("path", "/advise"))
+	cat *catalogue.Catalogue,
+	c cache.Cache,
+	advise AdviseFunc,
+) error {
+	jobManager := jobs.NewManager(time.Duration(cfg.JobRetentionMinutes)*time.Minute, logger)
+	registerCatalogueMetrics(cat)
+
+	authenticator := auth.New(&cfg.Auth)
+	logger.Info("API key authentication configured", zap.Bool("enabled", authenticator.Enabled()))
+
+	mux := http.NewServeMux()
+	handlePublic := func(path string, handler func(http.ResponseWriter, *http.Request)) {
+		mux.HandleFunc(path, instrumentHandler(path, handler))
+		logger.Info("registered API endpoint", zap.String("path", path))
+	}
+	handle := func(path string, handler func(http.ResponseWriter, *http.Request)) {
+		handlePublic(path, authenticateHandler(authenticator, handler, logger))
+	}
+
+	handlePublic("/healthz", getHealthzEndpointHandler(logger))
+	handlePublic("/readyz", getReadyzEndpointHandler(cat, logger))
+	handle("/status", getStatusEndpointHandler(cat, c, live, logger))
+	handle("/metrics", getMetricsEndpointHandler(logger))
+	handle("/regions", getRegionsEndpointHandler(live, logger))
+	handle("/presets", getPresetsEndpointHandler(live, logger))
+	handle("/advise", getAdviseEndpointHandler(cat, advise, live, logger))
+	handle(ADVISE_STREAM_PATH, getAdviseStreamEndpointHandler(cat, advise, live, logger))
+	handle(JOBS_PATH, getJobsEndpointHandler(jobManager, cat, advise, live, logger))
+	handle(JOBS_PATH+"/", getJobsEndpointHandler(jobManager, cat, advise, live, logger))
+	handle("/batch", getBatchEndpointHandler(cat, advise, live, logger))
+	handle("/diff", getDiffEndpointHandler(cat, advise, live, logger))
+	handle("/instances", getInstancesEndpointHandler(cat, live, logger))
+	handle("/aggregates", getAggregatesEndpointHandler(cat, live, logger))
+
+	server, err := createServer(cfg, mux)
+	if err != nil {
+		return utils.PrependToError(err, "failed to create server")
+	}
+
+	serveErr := make(chan error, 2)
+
+	var grpcServer *grpc.Server
+	if cfg.GrpcPort != 0 {
+		grpcServer, err = createGrpcServer(cfg, authenticator, cat, advise, live, logger)
+		if err != nil {
+			return utils.PrependToError(err, "failed to create gRPC server")
+		}
+		err = serveGrpc(cfg, grpcServer, serveErr, logger)
+		if err != nil {
+			return err
+		}
+	}
+
+	go func() {
+		logger.Info(
+			"starting API for advice service",
+			zap.String("address", server.Addr),
+			zap.Bool("tls", cfg.Tls.Enabled()),
+			zap.Bool("mutualTls", cfg.Tls.ClientCaFile != ""),
+		)
+		if cfg.Tls.Enabled() {
+			serveErr <- server.ListenAndServeTLS(cfg.Tls.CertFile, cfg.Tls.KeyFile)
+		} else {
+			serveErr <- server.ListenAndServe()
+		}
+	}()
+
+	select {
+	case err := <-serveErr:
+		if grpcServer != nil {
+			grpcServer.Stop()
+		}
+		server.Close()
+		return utils.PrependToError(err, "API stopped listening to requests")
+
+	case <-ctx.Done():
+		timeout := time.Duration(cfg.ShutdownTimeoutSeconds) * time.Second
+		logger.Info("shutting down API", zap.Duration("timeout", timeout))
+
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
 
-	logger.Info("starting API for advice service", zap.Int("port", cfg.Port))
-	err := http.ListenAndServe(formatPort(cfg.Port), nil)
+		if grpcServer != nil {
+			stopGrpc(shutdownCtx, grpcServer)
+		}
+		err := server.Shutdown(shutdownCtx)
+		jobManager.CancelAll()
+		if err != nil {
+			server.Close()
+			return utils.PrependToError(err, "in-flight requests did not complete before shutdown timeout")
+		}
+
+		logger.Info("API shut down")
+		return nil
+	}
+}
+
+func createServer(cfg *config.ApiConfig, handler http.Handler) (*http.Server, error) {
+	server := &http.Server{
+		Addr:    formatAddress(cfg.Host, cfg.Port),
+		Handler: handler,
+	}
+
+	if cfg.Tls.Enabled() {
+		tlsConfig, err := createTlsConfig(&cfg.Tls)
+		if err != nil {
+			return nil, err
+		}
+		server.TLSConfig = tlsConfig
+	}
+
+	return server, nil
+}
+
+func createTlsConfig(cfg *config.TlsConfig) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+	}
+
+	if cfg.ClientCaFile != "" {
+		caBytes, err := utils.FileToBytes(cfg.ClientCaFile)
+		if err != nil {
+			return nil, utils.PrependToError(err, "failed to read client CA file")
+		}
+
+		clientCas := x509.NewCertPool()
+		if !clientCas.AppendCertsFromPEM(caBytes) {
+			return nil, fmt.Errorf("no certificates found in client CA file %s", cfg.ClientCaFile)
+		}
+
+		tlsConfig.ClientCAs = clientCas
+		tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+
+	return tlsConfig, nil
+}
 
-	logger.Fatal("API stopped listening to requests", zap.Error(err))
+func loadCertificate(cfg *config.TlsConfig) (tls.Certificate, error) {
+	cert, err := tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile)
+	if err != nil {
+		return cert, utils.PrependToError(err, "failed to load TLS certificate")
+	}
+	return cert, nil
 }
 
-func formatPort(port int) string {
-	return "127.0.0.1:" + strconv.Itoa(port)
+func formatAddress(host string, port int) string {
+	return net.JoinHostPort(host, strconv.Itoa(port))
 }
@@ -0,0 +1,147 @@
+package service
+
+import (
+	"aws-blended-instances-advisor/advisor"
+	"aws-blended-instances-advisor/api/auth"
+	"aws-blended-instances-advisor/api/schema"
+	"aws-blended-instances-advisor/catalogue"
+	"aws-blended-instances-advisor/config"
+	"aws-blended-instances-advisor/utils"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"go.uber.org/zap"
+)
+
+const ADVISE_STREAM_PATH = "/advise/stream"
+
+func getAdviseStreamEndpointHandler(
+	cat *catalogue.Catalogue,
+	advise AdviseFunc,
+	live *config.Live,
+	logger *zap.Logger,
+) func(http.ResponseWriter, *http.Request) {
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		reqId := utils.GenerateUuid()
+		logger.Info(
+			"request received",
+			zap.String("url", r.Host),
+			zap.String("method", r.Method),
+			zap.String("requestId", reqId),
+		)
+
+		err := utils.AddCorsHeader(w, r, live.AllowedDomains())
+		if err != nil {
+			writeErrorResponse(w, reqId, err, http.StatusForbidden, logger)
+			return
+		}
+
+		switch r.Method {
+		case "OPTIONS":
+			optionsHandler(w, reqId, "OPTIONS, POST", logger)
+			return
+
+		case "POST":
+			adviseStreamPostHandler(w, r, reqId, cat, advise, live.Presets(), logger)
+			return
+
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+	}
+}
+
+func adviseStreamPostHandler(
+	w http.ResponseWriter,
+	r *http.Request,
+	reqId string,
+	cat *catalogue.Catalogue,
+	advise AdviseFunc,
+	presets map[string]schema.Preset,
+	logger *zap.Logger,
+) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeErrorResponse(w, reqId, errors.New("streaming is not supported"), http.StatusInternalServerError, logger)
+		return
+	}
+
+	req, err := parseRequest(r, reqId, presets, logger)
+	if err != nil {
+		writeErrorResponse(w, reqId, err, http.StatusBadRequest, logger)
+		return
+	}
+
+	info, err := cat.GetAsOf(req.AsOf)
+	if err != nil {
+		writeErrorResponse(w, reqId, err, getCatalogueErrorCode(err), logger)
+		return
+	}
+	addStalenessHeaders(w, cat, req.AsOf)
+	auth.LogAudit(r.Context(), r.RemoteAddr, reqId, "adviseStream", req, logger)
+	schema.OrderServicesByDecreasingMemory(req.Services)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	var writeErr error
+	onProgress := func(progress advisor.RegionProgress) {
+		if writeErr != nil {
+			return
+		}
+
+		event := schema.RegionAdviceEvent{
+			Region:    progress.Region,
+			Completed: progress.Completed,
+			Total:     progress.Total,
+			Advice:    progress.Advice,
+		}
+		if progress.Err != nil {
+			event.Error = progress.Err.Error()
+		}
+
+		writeErr = writeEvent(w, flusher, schema.RegionAdviceEventName, event)
+	}
+
+	advice, err := advise(r.Context(), *info, req.Advisor, req.Services, req.Options, onProgress)
+	if writeErr != nil {
+		logger.Warn("failed to stream advice", zap.String("requestId", reqId), zap.Error(writeErr))
+		return
+	}
+	if err != nil {
+		logger.Error("failed to generate advice", zap.String("requestId", reqId), zap.Error(err))
+		writeErr = writeEvent(w, flusher, schema.ErrorEventName, schema.ErrorEvent{Error: err.Error()})
+	} else {
+		writeErr = writeEvent(w, flusher, schema.AdviceEventName, advice)
+	}
+	if writeErr != nil {
+		logger.Warn("failed to stream advice", zap.String("requestId", reqId), zap.Error(writeErr))
+		return
+	}
+
+	logger.Info("streamed advice for request", zap.String("requestId", reqId))
+}
+
+// writeEvent writes a single Server-Sent Event with JSON data, flushing
+// it to the client immediately.
+func writeEvent(w http.ResponseWriter, flusher http.Flusher, name schema.EventName, data interface{}) error {
+	body, err := json.Marshal(data)
+	if err != nil {
+		return utils.PrependToError(err, "could not marshal event into JSON")
+	}
+
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", name, body)
+	if err != nil {
+		return utils.PrependToError(err, "could not write event")
+	}
+
+	flusher.Flush()
+	return nil
+}
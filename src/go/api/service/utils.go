@@ -1,12 +1,24 @@
 package service
 
 import (
+	"aws-blended-instances-advisor/api/auth"
+	"aws-blended-instances-advisor/catalogue"
+	"aws-blended-instances-advisor/snapshot"
+	"aws-blended-instances-advisor/utils"
+	"encoding/json"
 	"net/http"
+	"strconv"
 	"strings"
+	"time"
 
 	"go.uber.org/zap"
 )
 
+const (
+	INSTANCES_STALE_HEADER      = "X-Instances-Stale"
+	INSTANCES_FETCHED_AT_HEADER = "X-Instances-Fetched-At"
+)
+
 var ALLOWED_HEADERS = [...]string{
 	"Access-Control-Allow-Headers",
 	"Origin", "Accept",
@@ -14,6 +26,7 @@ var ALLOWED_HEADERS = [...]string{
 	"Content-Type",
 	"Access-Control-Request-Method",
 	"Access-Control-Request-Headers",
+	auth.API_KEY_HEADER,
 }
 
 func writeErrorResponse(
@@ -32,6 +45,93 @@ func writeErrorResponse(
 	)
 }
 
+func optionsHandler(
+	w http.ResponseWriter,
+	reqId string,
+	allowedMethods string,
+	logger *zap.Logger,
+) {
+	allowedHeaders := getAllowedHeaders()
+	w.Header().Set("Access-Control-Allow-Methods", allowedMethods)
+	w.Header().Set("Access-Control-Allow-Headers", allowedHeaders)
+
+	logger.Info(
+		"added headers to response",
+		zap.String("requestId", reqId),
+		zap.String("Access-Control-Allow-Methods", allowedMethods),
+		zap.String("Access-Control-Allow-Headers", allowedHeaders),
+	)
+
+	w.WriteHeader(http.StatusOK)
+	logger.Info("responded to request", zap.String("requestId", reqId))
+}
+
+func writeJsonResponse(
+	w http.ResponseWriter,
+	requestId string,
+	resp interface{},
+	statusCode int,
+	logger *zap.Logger,
+) error {
+	respBody, err := json.Marshal(resp)
+	if err != nil {
+		return utils.PrependToError(err, "could not marshal response into JSON")
+	}
+
+	utils.AddJsonContentTypeHeader(w)
+	w.WriteHeader(statusCode)
+
+	_, err = w.Write(respBody)
+	if err != nil {
+		return utils.PrependToError(err, "could not write body of HTTP response")
+	}
+
+	logger.Info(
+		"responded to request",
+		zap.String("requestId", requestId),
+		zap.Int("responseCode", statusCode),
+		zap.ByteString("response", respBody),
+	)
+
+	return nil
+}
+
 func getAllowedHeaders() string {
 	return strings.Join(ALLOWED_HEADERS[:], ", ")
 }
+
+// getCatalogueErrorCode returns the HTTP status code used to respond to a
+// request when instances could not be retrieved from the catalogue.
+func getCatalogueErrorCode(err error) int {
+	switch err {
+	case catalogue.ErrNotLoaded, catalogue.ErrTooStale:
+		return http.StatusServiceUnavailable
+	case catalogue.ErrNoHistory:
+		return http.StatusBadRequest
+	case snapshot.ErrNoSnapshot:
+		return http.StatusNotFound
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// addStalenessHeaders describes the age of the catalogue's current instances
+// in a response's headers, flagging responses generated from stale instances.
+// No headers are added for responses generated from past instances (asOf).
+func addStalenessHeaders(w http.ResponseWriter, cat *catalogue.Catalogue, asOf *time.Time) {
+	if asOf != nil {
+		return
+	}
+
+	status := cat.Status()
+	if !status.Ready {
+		return
+	}
+
+	w.Header().Set("Access-Control-Expose-Headers", INSTANCES_STALE_HEADER+", "+INSTANCES_FETCHED_AT_HEADER)
+	w.Header().Set(INSTANCES_FETCHED_AT_HEADER, status.FetchedAt.UTC().Format(time.RFC3339))
+	w.Header().Set(INSTANCES_STALE_HEADER, strconv.FormatBool(status.Stale))
+	if status.Stale {
+		w.Header().Set("Warning", `110 - "Response is Stale"`)
+	}
+}
@@ -1,45 +1,47 @@
 package api
 
 import (
-	types "aws-blended-instances-advisor/aws/types"
 	"aws-blended-instances-advisor/cache"
 	"aws-blended-instances-advisor/config"
 	instPkg "aws-blended-instances-advisor/instances"
+	"aws-blended-instances-advisor/utils"
 	"context"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	awsConfig "github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/credentials/stscreds"
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
 	"github.com/aws/aws-sdk-go-v2/service/pricing"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
 	"go.uber.org/zap"
 )
 
 const (
 	AWS_PRICING_API_REGION   = "us-east-1" // Only us-east-1 works currently (2021-11-11)
 	INSTANCES_CACHE_FILENAME = "instances.json"
-	INSTANCES_CACHE_DURATION = 672
 )
 
 // GetInstancesAndInfo fetches spot and on-demandinstance offerings from the
 // AWS API, returning them as a list of Instances and InstanceInfo (wrapped in
 // a GlobalInfo).
 //
+// Cached instances are returned while they are valid, otherwise only the
+// sources which are no longer valid are fetched (see RefreshInstancesAndInfo).
+//
 // An error is returned if a critical failure is encountered during
 // the processes execution, with handleable failures being logged and
 // handled appropriately.
 func GetInstancesAndInfo(
 	apiConfig *config.AwsApiConfig,
 	creds *config.Credentials,
-	cache *cache.Cache,
+	cache cache.Cache,
 	logger *zap.Logger,
 ) (
 	*instPkg.GlobalInfo,
 	error,
 ) {
 
-	regions := types.GetAllRegions()
-
 	globalInstanceInfo, err := getGlobalInstanceInfoFromCache(INSTANCES_CACHE_FILENAME, cache)
 	if err != nil {
 		logger.Info("no instances found in cache", zap.String("reason", err.Error()))
@@ -52,43 +54,86 @@ func GetInstancesAndInfo(
 		logger.Warn("invalid instances cache", zap.Error(err))
 	}
 
-	logger.Info("fetching instances from AWS API")
+	return RefreshInstancesAndInfo(apiConfig, creds, cache, logger)
+}
 
-	awsCreds := createAwsCredentials(creds)
+// FetchInstancesAndInfo fetches spot and on-demand instance offerings from the
+// AWS API regardless of what is cached, storing the result in the cache and
+// returning it as a GlobalInfo.
+//
+// An error is returned if a critical failure is encountered during
+// the processes execution.
+func FetchInstancesAndInfo(
+	apiConfig *config.AwsApiConfig,
+	creds *config.Credentials,
+	cache cache.Cache,
+	logger *zap.Logger,
+) (
+	*instPkg.GlobalInfo,
+	error,
+) {
 
-	onDemandInstances, err := GetOnDemandInstances(apiConfig, regions, awsCreds, logger)
-	if err != nil {
-		logger.Error("error fetching on-demand instances", zap.Error(err))
-		return nil, err
-	}
-	spotInstances, err := GetSpotInstances(apiConfig, regions, awsCreds, logger)
+	logger.Info("fetching instances from AWS API")
+
+	r, err := newRefresher(apiConfig, creds, cache, true, logger)
 	if err != nil {
-		logger.Error("error fetching spot instances", zap.Error(err))
 		return nil, err
 	}
+	return r.refresh()
+}
 
-	globalInfo := instPkg.CreateGlobalInfo(onDemandInstances, spotInstances, regions)
+// createAwsCredentials creates a credentials provider from the configured
+// credentials: a static key if one is given, or the AWS SDK's default
+// credential chain (using the configured profile) otherwise. If a role ARN
+// is given, the role is assumed using those credentials.
+func createAwsCredentials(creds *config.Credentials) (aws.CredentialsProvider, error) {
+	var provider aws.CredentialsProvider
+	stsRegion := AWS_PRICING_API_REGION
 
-	err = storeGlobalInstanceInfoInCache(globalInfo, INSTANCES_CACHE_FILENAME, cache)
-	if err != nil {
-		logger.Error("failed to store instances in cache", zap.Error(err))
-		return nil, err
+	if creds.IsStatic() {
+		provider = credentials.NewStaticCredentialsProvider(creds.AwsKeyId, creds.AwsSecretKey, creds.AwsSessionToken)
+	} else {
+		options := []func(*awsConfig.LoadOptions) error{}
+		if creds.Profile != "" {
+			options = append(options, awsConfig.WithSharedConfigProfile(creds.Profile))
+		}
+		defaultConfig, err := awsConfig.LoadDefaultConfig(context.Background(), options...)
+		if err != nil {
+			return nil, utils.PrependToError(err, "could not load default AWS credentials")
+		}
+		provider = defaultConfig.Credentials
+		if defaultConfig.Region != "" {
+			stsRegion = defaultConfig.Region
+		}
 	}
 
-	globalInfo.Log("stored instances in cache", logger)
-
-	return &globalInfo, nil
-}
+	if creds.RoleArn == "" {
+		return provider, nil
+	}
 
-func createAwsCredentials(creds *config.Credentials) credentials.StaticCredentialsProvider {
-	return credentials.NewStaticCredentialsProvider(creds.AwsKeyId, creds.AwsSecretKey, "")
+	stsClient := sts.New(sts.Options{
+		Region:      stsRegion,
+		Credentials: provider,
+		Retryer:     newCountingRetryer("sts"),
+	})
+	assumeRoleProvider := stscreds.NewAssumeRoleProvider(
+		stsClient,
+		creds.RoleArn,
+		func(options *stscreds.AssumeRoleOptions) {
+			if creds.RoleSessionName != "" {
+				options.RoleSessionName = creds.RoleSessionName
+			}
+		},
+	)
+	return aws.NewCredentialsCache(assumeRoleProvider), nil
 }
 
-func createAwsConfig(awsRegion string, creds credentials.StaticCredentialsProvider) (aws.Config, error) {
+func createAwsConfig(awsRegion string, creds aws.CredentialsProvider) (aws.Config, error) {
 	return awsConfig.LoadDefaultConfig(
 		context.Background(),
 		awsConfig.WithCredentialsProvider(creds),
 		awsConfig.WithRegion(awsRegion),
+		awsConfig.WithRetryer(func() aws.Retryer { return newCountingRetryer("ec2") }),
 	)
 }
 
@@ -96,9 +141,10 @@ func createEc2Client(awsConfig aws.Config) *ec2.Client {
 	return ec2.NewFromConfig(awsConfig)
 }
 
-func createAwsPricingClient(awsCredentials credentials.StaticCredentialsProvider) *pricing.Client {
+func createAwsPricingClient(awsCredentials aws.CredentialsProvider) *pricing.Client {
 	return pricing.New(pricing.Options{
 		Region:      AWS_PRICING_API_REGION,
 		Credentials: awsCredentials,
+		Retryer:     newCountingRetryer("pricing"),
 	})
 }
@@ -3,32 +3,53 @@ package api
 import (
 	"aws-blended-instances-advisor/cache"
 	instPkg "aws-blended-instances-advisor/instances"
+	"aws-blended-instances-advisor/utils"
 	"encoding/json"
-	"errors"
 	"time"
 )
 
-func getGlobalInstanceInfoFromCache(instancesCacheFilename string, c *cache.Cache) (*instPkg.GlobalInfo, error) {
-	isValid := c.IsValid(instancesCacheFilename)
-	if isValid {
-		instancesFileContent, err := c.Get(instancesCacheFilename)
-		if err != nil {
-			return nil, err
-		}
-		var globalInfo instPkg.GlobalInfo
-		err = json.Unmarshal([]byte(instancesFileContent), &globalInfo)
-		if err != nil {
-			return nil, err
-		}
-		return &globalInfo, nil
+// GetInstancesAndInfoFromCache returns the instance offerings stored in the
+// cache, even if they are no longer valid, without contacting the AWS API.
+//
+// Returns an error if no valid instance information is cached.
+func GetInstancesAndInfoFromCache(c cache.Cache) (*instPkg.GlobalInfo, error) {
+	instancesFileContent, err := c.GetIgnoringValidity(INSTANCES_CACHE_FILENAME)
+	if err != nil {
+		return nil, utils.PrependToError(err, "instances not in cache")
+	}
+
+	var globalInfo instPkg.GlobalInfo
+	err = json.Unmarshal([]byte(instancesFileContent), &globalInfo)
+	if err != nil {
+		return nil, utils.PrependToError(err, "could not parse cached instances")
+	}
+
+	err = globalInfo.Validate()
+	if err != nil {
+		return nil, utils.PrependToError(err, "cached instances are invalid")
+	}
+
+	return &globalInfo, nil
+}
+
+func getGlobalInstanceInfoFromCache(instancesCacheFilename string, c cache.Cache) (*instPkg.GlobalInfo, error) {
+	instancesFileContent, err := c.Get(instancesCacheFilename)
+	if err != nil {
+		return nil, utils.PrependToError(err, "instances not in cache")
+	}
+	var globalInfo instPkg.GlobalInfo
+	err = json.Unmarshal([]byte(instancesFileContent), &globalInfo)
+	if err != nil {
+		return nil, err
 	}
-	return nil, errors.New("instances not in cache")
+	return &globalInfo, nil
 }
 
 func storeGlobalInstanceInfoInCache(
 	globalInstanceInfo instPkg.GlobalInfo,
 	instancesCacheFilename string,
-	c *cache.Cache,
+	c cache.Cache,
+	lifetime time.Duration,
 ) error {
 	instancesFileContent, err := json.Marshal(globalInstanceInfo)
 	if err != nil {
@@ -37,7 +58,7 @@ func storeGlobalInstanceInfoInCache(
 	err = c.Set(
 		instancesCacheFilename,
 		string(instancesFileContent),
-		time.Hour*INSTANCES_CACHE_DURATION,
+		lifetime,
 	)
 	if err != nil {
 		return err
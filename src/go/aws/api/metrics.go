@@ -0,0 +1,66 @@
+package api
+
+import (
+	"aws-blended-instances-advisor/metrics"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/retry"
+)
+
+var (
+	awsApiCalls = metrics.NewCounterVec(
+		"aws_api_calls_total",
+		"Number of calls made to AWS APIs.",
+		"service", "operation",
+	)
+	awsApiErrors = metrics.NewCounterVec(
+		"aws_api_errors_total",
+		"Number of calls to AWS APIs which returned an error.",
+		"service", "operation",
+	)
+	awsApiRetries = metrics.NewCounterVec(
+		"aws_api_retries_total",
+		"Number of retries made by AWS API clients.",
+		"service",
+	)
+	instanceSourceRefreshes = metrics.NewCounterVec(
+		"instance_source_refreshes_total",
+		"Number of times a region's source of instance information was fetched.",
+		"source",
+	)
+)
+
+// recordAwsApiCall records a call to an AWS API operation, along with
+// whether the call returned an error.
+func recordAwsApiCall(service string, operation string, err error) {
+	awsApiCalls.Inc(service, operation)
+	if err != nil {
+		awsApiErrors.Inc(service, operation)
+	}
+}
+
+// recordSourceRefresh records that a source of instance information was fetched.
+func recordSourceRefresh(source string) {
+	instanceSourceRefreshes.Inc(source)
+}
+
+// countingRetryer is an aws.Retryer which counts the retries made
+// by the Retryer it wraps.
+type countingRetryer struct {
+	aws.Retryer
+	service string
+}
+
+func newCountingRetryer(service string) aws.Retryer {
+	return countingRetryer{
+		Retryer: retry.NewStandard(),
+		service: service,
+	}
+}
+
+// RetryDelay is called before each retry, so is used to count retries.
+func (r countingRetryer) RetryDelay(attempt int, opErr error) (time.Duration, error) {
+	awsApiRetries.Inc(r.service)
+	return r.Retryer.RetryDelay(attempt, opErr)
+}
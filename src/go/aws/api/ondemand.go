@@ -6,7 +6,7 @@ import (
 	instPkg "aws-blended-instances-advisor/instances"
 	"context"
 
-	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/pricing"
 	pricingTypes "github.com/aws/aws-sdk-go-v2/service/pricing/types"
 	"go.uber.org/zap"
@@ -27,7 +27,7 @@ const (
 func GetOnDemandInstances(
 	config *config.AwsApiConfig,
 	regions []types.Region,
-	creds credentials.StaticCredentialsProvider,
+	creds aws.CredentialsProvider,
 	logger *zap.Logger,
 ) (
 	map[types.Region][]*instPkg.Instance,
@@ -57,61 +57,78 @@ func getRegionToOnDemandInstancesMap(
 	regionToInstancesMap := make(map[types.Region][]*instPkg.Instance)
 
 	for _, region := range regions {
-		regionInstances := make([]*instPkg.Instance, 0)
-
-		nextToken := ""
-		firstIter := true
-		total := 0
-		for (total < maxInstanceCount || maxInstanceCount <= 0) && (nextToken != "" || firstIter) {
-
-			resp, err := getOnDemandInstancesFromApi(pricingClient, region, nextToken)
-			if err != nil {
-				logger.Error("error fetching on-demand instances from API", zap.Error(err))
-				return nil, err
-			}
-			logger.Info("fetched on-demand instances", zap.Int("instanceCount", len(resp.PriceList)))
-
-			parsedInstances := parseOnDemandApiResponseToInstances(cfg, resp, logger)
-
-			logger.Info(
-				"parsed on-demand instances",
-				zap.String("region", region.CodeString()),
-				zap.Int("parsedCount", len(parsedInstances)),
-				zap.Int("skippedCount", len(resp.PriceList)-len(parsedInstances)),
-			)
-
-			total += len(parsedInstances)
-
-			regionInstances = append(regionInstances, parsedInstances...)
-
-			firstIter = false
-			if resp.NextToken != nil {
-				nextToken = *resp.NextToken
-			} else {
-				nextToken = ""
-			}
+		regionInstances, err := getRegionOnDemandInstances(cfg, pricingClient, region, maxInstanceCount, logger)
+		if err != nil {
+			return nil, err
 		}
+		regionToInstancesMap[region] = regionInstances
+	}
+
+	return regionToInstancesMap, nil
+}
+
+func getRegionOnDemandInstances(
+	cfg *config.AwsApiConfig,
+	pricingClient *pricing.Client,
+	region types.Region,
+	maxInstanceCount int,
+	logger *zap.Logger,
+) (
+	[]*instPkg.Instance,
+	error,
+) {
+	regionInstances := make([]*instPkg.Instance, 0)
+
+	nextToken := ""
+	firstIter := true
+	total := 0
+	for (total < maxInstanceCount || maxInstanceCount <= 0) && (nextToken != "" || firstIter) {
+
+		resp, err := getOnDemandInstancesFromApi(pricingClient, region, nextToken)
+		if err != nil {
+			logger.Error("error fetching on-demand instances from API", zap.Error(err))
+			return nil, err
+		}
+		logger.Info("fetched on-demand instances", zap.Int("instanceCount", len(resp.PriceList)))
+
+		parsedInstances := parseOnDemandApiResponseToInstances(cfg, resp, logger)
 
 		logger.Info(
-			"finished fetching on-demand instances for region",
+			"parsed on-demand instances",
 			zap.String("region", region.CodeString()),
-			zap.Int("totalInstanceCount", total),
-			zap.Int("maxInstanceCount", total),
+			zap.Int("parsedCount", len(parsedInstances)),
+			zap.Int("skippedCount", len(resp.PriceList)-len(parsedInstances)),
 		)
 
-		if len(regionInstances) > maxInstanceCount && maxInstanceCount > 0 {
-			logger.Info(
-				"removed excess instances to keep to max instance count",
-				zap.String("region", region.CodeString()),
-				zap.Int("removed", len(regionInstances)-maxInstanceCount),
-			)
-			regionInstances = regionInstances[:maxInstanceCount]
+		total += len(parsedInstances)
+
+		regionInstances = append(regionInstances, parsedInstances...)
+
+		firstIter = false
+		if resp.NextToken != nil {
+			nextToken = *resp.NextToken
+		} else {
+			nextToken = ""
 		}
+	}
 
-		regionToInstancesMap[region] = regionInstances
+	logger.Info(
+		"finished fetching on-demand instances for region",
+		zap.String("region", region.CodeString()),
+		zap.Int("totalInstanceCount", total),
+		zap.Int("maxInstanceCount", total),
+	)
+
+	if len(regionInstances) > maxInstanceCount && maxInstanceCount > 0 {
+		logger.Info(
+			"removed excess instances to keep to max instance count",
+			zap.String("region", region.CodeString()),
+			zap.Int("removed", len(regionInstances)-maxInstanceCount),
+		)
+		regionInstances = regionInstances[:maxInstanceCount]
 	}
 
-	return regionToInstancesMap, nil
+	return regionInstances, nil
 }
 
 func getOnDemandInstancesFromApi(
@@ -124,7 +141,7 @@ func getOnDemandInstancesFromApi(
 	locationFilterKey := LOCATION_FILTER_KEY
 	locationFilterValue := region.NameString()
 
-	return pricingClient.GetProducts(context.TODO(), &pricing.GetProductsInput{
+	resp, err := pricingClient.GetProducts(context.TODO(), &pricing.GetProductsInput{
 		ServiceCode: &serviceCode,
 		NextToken:   &nextToken,
 		Filters: []pricingTypes.Filter{{
@@ -133,4 +150,7 @@ func getOnDemandInstancesFromApi(
 			Type:  TERM_MATCH_FILTER_TYPE,
 		}},
 	})
+	recordAwsApiCall("pricing", "GetProducts", err)
+
+	return resp, err
 }
@@ -0,0 +1,348 @@
+package api
+
+import (
+	types "aws-blended-instances-advisor/aws/types"
+	"aws-blended-instances-advisor/cache"
+	"aws-blended-instances-advisor/config"
+	instPkg "aws-blended-instances-advisor/instances"
+	"aws-blended-instances-advisor/utils"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	ec2Types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/aws/aws-sdk-go-v2/service/pricing"
+	"go.uber.org/zap"
+)
+
+const (
+	SPOT_ADVISOR_CACHE_FILENAME       = "spot-advisor.json"
+	SPOT_PRICES_CACHE_FILENAME_FORMAT = "spot-prices-%s.json" // Formatted with a region code
+	ON_DEMAND_CACHE_FILENAME_FORMAT   = "on-demand-%s.json"   // Formatted with a region code
+
+	SPOT_PRICES_SOURCE  = "spotPrices"
+	ON_DEMAND_SOURCE    = "onDemand"
+	SPOT_ADVISOR_SOURCE = "spotAdvisor"
+
+	MIN_INSTANCES_LIFETIME = time.Second
+)
+
+// RefreshInstancesAndInfo fetches only the sources of instance information
+// which are no longer valid in the cache, for each region, combining them
+// with the previously cached instances and returning the result as a GlobalInfo.
+//
+// Spot prices, on-demand instances and spot advisor data are each cached per
+// region with their own lifetimes, so refreshing one region's spot prices
+// neither calls the Pricing API nor recalculates other regions' aggregates.
+//
+// Sources which were fetched are kept in the cache even if a later source
+// fails, so that a retry resumes where the refresh stopped.
+func RefreshInstancesAndInfo(
+	apiConfig *config.AwsApiConfig,
+	creds *config.Credentials,
+	c cache.Cache,
+	logger *zap.Logger,
+) (
+	*instPkg.GlobalInfo,
+	error,
+) {
+	r, err := newRefresher(apiConfig, creds, c, false, logger)
+	if err != nil {
+		return nil, err
+	}
+	return r.refresh()
+}
+
+// SpotPricesCacheFilename returns the cache filename of a region's spot prices.
+func SpotPricesCacheFilename(region types.Region) string {
+	return fmt.Sprintf(SPOT_PRICES_CACHE_FILENAME_FORMAT, region.CodeString())
+}
+
+// OnDemandCacheFilename returns the cache filename of a region's on-demand instances.
+func OnDemandCacheFilename(region types.Region) string {
+	return fmt.Sprintf(ON_DEMAND_CACHE_FILENAME_FORMAT, region.CodeString())
+}
+
+// A refresher refreshes cached instance information, one source and region at a time.
+type refresher struct {
+	config *config.AwsApiConfig
+	creds  aws.CredentialsProvider
+	cache  cache.Cache
+	force  bool // Whether every source is fetched, regardless of the cache
+	logger *zap.Logger
+
+	pricingClient  *pricing.Client    // Created when first needed
+	advisorInfo    *spotInstancesInfo // Loaded when first needed
+	advisorChanged bool
+}
+
+func newRefresher(
+	apiConfig *config.AwsApiConfig,
+	creds *config.Credentials,
+	c cache.Cache,
+	force bool,
+	logger *zap.Logger,
+) (*refresher, error) {
+	awsCreds, err := createAwsCredentials(creds)
+	if err != nil {
+		return nil, err
+	}
+	return &refresher{
+		config: apiConfig,
+		creds:  awsCreds,
+		cache:  c,
+		force:  force,
+		logger: logger,
+	}, nil
+}
+
+func (r *refresher) refresh() (*instPkg.GlobalInfo, error) {
+	regions := types.GetAllRegions()
+
+	previous, err := GetInstancesAndInfoFromCache(r.cache)
+	if err != nil || r.force {
+		previous = &instPkg.GlobalInfo{RegionInfoMap: make(instPkg.RegionInfoMap)}
+	}
+
+	if !r.isFresh(SPOT_ADVISOR_CACHE_FILENAME) {
+		err = r.fetchSpotAdvisorInfo()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	updated := make(instPkg.RegionInfoMap)
+	for _, region := range regions {
+		regionInfo, changed, err := r.refreshRegion(region, previous.RegionInfoMap)
+		if err != nil {
+			return nil, utils.PrependToError(err, fmt.Sprintf("could not refresh %s", region.CodeString()))
+		}
+		if changed {
+			updated[region] = regionInfo
+		}
+	}
+
+	globalInfo := previous.WithRegions(updated)
+	err = globalInfo.Validate()
+	if err != nil {
+		return nil, utils.PrependToError(err, "refreshed instances are invalid")
+	}
+
+	err = storeGlobalInstanceInfoInCache(globalInfo, INSTANCES_CACHE_FILENAME, r.cache, r.instancesLifetime(regions))
+	if err != nil {
+		r.logger.Error("failed to store instances in cache", zap.Error(err))
+		return nil, err
+	}
+
+	r.logger.Info(
+		"refreshed instances",
+		zap.Int("updatedRegionCount", len(updated)),
+		zap.Int("regionCount", len(regions)),
+	)
+	globalInfo.Log("stored instances in cache", r.logger)
+
+	return &globalInfo, nil
+}
+
+// refreshRegion returns the region's RegionInfo, fetching any of its sources
+// which are no longer valid, and whether it differs from the previous RegionInfo.
+func (r *refresher) refreshRegion(
+	region types.Region,
+	previous instPkg.RegionInfoMap,
+) (
+	instPkg.RegionInfo,
+	bool,
+	error,
+) {
+	regionInfo, hasPrevious := previous[region]
+	changed := false
+
+	if !hasPrevious || !r.isFresh(OnDemandCacheFilename(region)) {
+		onDemandInstances, err := r.getOnDemandInstances(region)
+		if err != nil {
+			return regionInfo, false, err
+		}
+		regionInfo = regionInfo.WithPermanentInstances(onDemandInstances)
+		changed = true
+	}
+
+	if !hasPrevious || r.advisorChanged || !r.isFresh(SpotPricesCacheFilename(region)) {
+		spotInstances, err := r.getSpotInstances(region)
+		if err != nil {
+			return regionInfo, false, err
+		}
+		regionInfo = regionInfo.WithTransientInstances(spotInstances)
+		changed = true
+	}
+
+	return regionInfo, changed, nil
+}
+
+// getOnDemandInstances returns the region's on-demand instances,
+// from the cache if they are still valid or from the Pricing API otherwise.
+func (r *refresher) getOnDemandInstances(region types.Region) ([]*instPkg.Instance, error) {
+	filename := OnDemandCacheFilename(region)
+
+	var instances []*instPkg.Instance
+	if r.isFresh(filename) && r.getCachedJson(filename, &instances) == nil {
+		return instances, nil
+	}
+
+	if r.pricingClient == nil {
+		r.pricingClient = createAwsPricingClient(r.creds)
+	}
+	instances, err := getRegionOnDemandInstances(r.config, r.pricingClient, region, r.config.MaxInstancesToFetch, r.logger)
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.setCachedJson(filename, instances, time.Duration(r.config.Refresh.OnDemandHours)*time.Hour)
+	if err != nil {
+		return nil, err
+	}
+	recordSourceRefresh(ON_DEMAND_SOURCE)
+
+	return instances, nil
+}
+
+// getSpotInstances returns the region's spot instances, created from its spot
+// prices and the spot advisor info. Spot prices are taken from the cache if
+// they are still valid, or from the EC2 API otherwise.
+func (r *refresher) getSpotInstances(region types.Region) ([]*instPkg.Instance, error) {
+	advisorInfo, err := r.getSpotAdvisorInfo()
+	if err != nil {
+		return nil, err
+	}
+
+	filename := SpotPricesCacheFilename(region)
+
+	var prices []ec2Types.SpotPrice
+	if !r.isFresh(filename) || r.getCachedJson(filename, &prices) != nil {
+		prices, err = getSpotInstancePricesForRegion(r.config, region, r.creds, r.logger)
+		if err != nil {
+			r.logger.Error(
+				"could not fetch region spot prices",
+				zap.String("region", region.CodeString()),
+				zap.Error(err),
+			)
+			return nil, err
+		}
+
+		err = r.setCachedJson(filename, prices, time.Duration(r.config.Refresh.SpotPricesMinutes)*time.Minute)
+		if err != nil {
+			return nil, err
+		}
+		recordSourceRefresh(SPOT_PRICES_SOURCE)
+	}
+
+	return createRegionSpotInstancesFromPrices(r.config, region, advisorInfo, prices, r.logger)
+}
+
+// getSpotAdvisorInfo returns the spot advisor info, loading it
+// from the cache when first needed.
+func (r *refresher) getSpotAdvisorInfo() (*spotInstancesInfo, error) {
+	if r.advisorInfo != nil {
+		return r.advisorInfo, nil
+	}
+
+	infoFile, err := r.cache.Get(SPOT_ADVISOR_CACHE_FILENAME)
+	if err != nil {
+		err = r.fetchSpotAdvisorInfo()
+		return r.advisorInfo, err
+	}
+
+	advisorInfo, err := parseSpotAdvisorInfo([]byte(infoFile))
+	if err != nil {
+		err = r.fetchSpotAdvisorInfo()
+		return r.advisorInfo, err
+	}
+
+	r.advisorInfo = advisorInfo
+	return r.advisorInfo, nil
+}
+
+// fetchSpotAdvisorInfo downloads and caches the spot advisor info, meaning
+// that every region's spot instances must be recreated.
+func (r *refresher) fetchSpotAdvisorInfo() error {
+	infoFile, err := downloadSpotAdvisorInfo(r.config, r.logger)
+	if err != nil {
+		r.logger.Error("error fetching spot instance revocation info and specifications from API", zap.Error(err))
+		return err
+	}
+
+	advisorInfo, err := parseSpotAdvisorInfo(infoFile)
+	if err != nil {
+		return err
+	}
+
+	err = r.cache.Set(
+		SPOT_ADVISOR_CACHE_FILENAME,
+		string(infoFile),
+		time.Duration(r.config.Refresh.SpotAdvisorHours)*time.Hour,
+	)
+	if err != nil {
+		return utils.PrependToError(err, "could not cache spot advisor info")
+	}
+	recordSourceRefresh(SPOT_ADVISOR_SOURCE)
+
+	r.advisorInfo = advisorInfo
+	r.advisorChanged = true
+	return nil
+}
+
+// instancesLifetime returns how long combined instances remain valid,
+// which is until the first of the sources they were created from expires.
+func (r *refresher) instancesLifetime(regions []types.Region) time.Duration {
+	filenames := []string{SPOT_ADVISOR_CACHE_FILENAME}
+	for _, region := range regions {
+		filenames = append(filenames, OnDemandCacheFilename(region), SpotPricesCacheFilename(region))
+	}
+
+	now := time.Now()
+	lifetime := time.Duration(0)
+	for _, filename := range filenames {
+		entry, err := r.cache.GetEntry(filename)
+		if err != nil {
+			continue
+		}
+		remaining := entry.InvalidationDate.Sub(now)
+		if lifetime == 0 || remaining < lifetime {
+			lifetime = remaining
+		}
+	}
+
+	if lifetime < MIN_INSTANCES_LIFETIME {
+		return MIN_INSTANCES_LIFETIME
+	}
+	return lifetime
+}
+
+func (r *refresher) isFresh(filename string) bool {
+	return !r.force && r.cache.IsValid(filename)
+}
+
+func (r *refresher) getCachedJson(filename string, v interface{}) error {
+	content, err := r.cache.Get(filename)
+	if err != nil {
+		return err
+	}
+	err = json.Unmarshal([]byte(content), v)
+	if err != nil {
+		r.logger.Warn("could not parse cached file", zap.String("file", filename), zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+func (r *refresher) setCachedJson(filename string, v interface{}, lifetime time.Duration) error {
+	content, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	err = r.cache.Set(filename, string(content), lifetime)
+	if err != nil {
+		return utils.PrependToError(err, fmt.Sprintf("could not cache %s", filename))
+	}
+	return nil
+}
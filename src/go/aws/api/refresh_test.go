@@ -0,0 +1,124 @@
+package api
+
+import (
+	types "aws-blended-instances-advisor/aws/types"
+	"aws-blended-instances-advisor/cache"
+	"aws-blended-instances-advisor/config"
+	instPkg "aws-blended-instances-advisor/instances"
+	"aws-blended-instances-advisor/utils"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	ec2Types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+)
+
+const (
+	TEST_INSTANCE_TYPE      = "m5.large"
+	TEST_AVAILABILITY_ZONE  = "test-zone-1a"
+	TEST_SPOT_PRICES_EXPIRY = 30 * time.Minute
+)
+
+func TestRefreshFromCachedSources(t *testing.T) {
+	logger, err := utils.CreateMockLogger()
+	if err != nil {
+		t.Fatalf("Failed to create mock logger: %s", err.Error())
+	}
+
+	c, err := cache.New(t.TempDir())
+	if err != nil {
+		t.Fatalf("Failed to create cache: %s", err.Error())
+	}
+	defer c.Close()
+
+	setCachedSources(t, c)
+
+	cfg := config.AwsApiConfig{
+		Refresh: config.RefreshConfig{
+			SpotPricesMinutes: 60,
+			OnDemandHours:     24,
+			SpotAdvisorHours:  24,
+		},
+	}
+
+	info, err := RefreshInstancesAndInfo(&cfg, &config.Credentials{}, c, logger)
+	if err != nil {
+		t.Fatalf("Failed to refresh instances from cached sources: %s", err.Error())
+	}
+
+	regions := types.GetAllRegions()
+	if info.GlobalAggregates.Count != 2*len(regions) {
+		t.Fatalf("Incorrect instance count: %d (expected %d)", info.GlobalAggregates.Count, 2*len(regions))
+	}
+
+	entry, err := c.GetEntry(INSTANCES_CACHE_FILENAME)
+	if err != nil {
+		t.Fatalf("Refreshed instances not cached: %s", err.Error())
+	}
+	expiry := entry.InvalidationDate.Sub(entry.SetDate)
+	if expiry > TEST_SPOT_PRICES_EXPIRY || expiry < TEST_SPOT_PRICES_EXPIRY-time.Minute {
+		t.Fatalf("Cached instances should expire with the first source (%s), but expire after %s", TEST_SPOT_PRICES_EXPIRY, expiry)
+	}
+
+	err = c.Invalidate(INSTANCES_CACHE_FILENAME)
+	if err != nil {
+		t.Fatalf("Failed to invalidate instances: %s", err.Error())
+	}
+
+	refreshed, err := RefreshInstancesAndInfo(&cfg, &config.Credentials{}, c, logger)
+	if err != nil {
+		t.Fatalf("Failed to refresh instances again: %s", err.Error())
+	}
+	for _, region := range regions {
+		before := info.RegionInfoMap[region].TransientInstances[0].Id
+		after := refreshed.RegionInfoMap[region].TransientInstances[0].Id
+		if before != after {
+			t.Fatalf("Spot instances in %s were recreated although their sources are still valid", region.CodeString())
+		}
+	}
+}
+
+func setCachedSources(t *testing.T, c cache.Cache) {
+	advisorInfo := spotInstancesInfo{
+		SpecsMap:     map[string]spotInstanceSpecs{TEST_INSTANCE_TYPE: {MemoryGb: 8, Vcpu: 2}},
+		RegionPrices: make(map[string]regionSpotInstanceRevocationInfo),
+	}
+	for _, region := range types.GetAllRegions() {
+		advisorInfo.RegionPrices[region.CodeString()] = regionSpotInstanceRevocationInfo{
+			LinuxInstances: map[string]spotInstanceRevocationInfo{
+				TEST_INSTANCE_TYPE: {RevocationProbabilityTier: 1},
+			},
+		}
+
+		onDemandInstances := []*instPkg.Instance{{
+			Id:              utils.GenerateUuid(),
+			Name:            TEST_INSTANCE_TYPE,
+			MemoryGb:        8,
+			Vcpu:            2,
+			Region:          region,
+			OperatingSystem: "Linux",
+			PricePerHour:    0.1,
+		}}
+		setCachedJson(t, c, OnDemandCacheFilename(region), onDemandInstances, time.Hour)
+
+		spotPrices := []ec2Types.SpotPrice{{
+			AvailabilityZone: aws.String(TEST_AVAILABILITY_ZONE),
+			InstanceType:     ec2Types.InstanceType(TEST_INSTANCE_TYPE),
+			SpotPrice:        aws.String("0.04"),
+		}}
+		setCachedJson(t, c, SpotPricesCacheFilename(region), spotPrices, TEST_SPOT_PRICES_EXPIRY)
+	}
+	setCachedJson(t, c, SPOT_ADVISOR_CACHE_FILENAME, advisorInfo, time.Hour)
+}
+
+func setCachedJson(t *testing.T, c cache.Cache, filename string, v interface{}, lifetime time.Duration) {
+	content, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("Failed to marshal %s: %s", filename, err.Error())
+	}
+	err = c.Set(filename, string(content), lifetime)
+	if err != nil {
+		t.Fatalf("Failed to cache %s: %s", filename, err.Error())
+	}
+}
@@ -9,7 +9,7 @@ import (
 	"encoding/json"
 	"strconv"
 
-	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
 	ec2Types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
 	"go.uber.org/zap"
@@ -24,13 +24,13 @@ import (
 func GetSpotInstances(
 	config *config.AwsApiConfig,
 	regions []types.Region,
-	creds credentials.StaticCredentialsProvider,
+	creds aws.CredentialsProvider,
 	logger *zap.Logger,
 ) (map[types.Region][]*instPkg.Instance, error) {
 
 	regionToInstanceMap := make(map[types.Region][]*instPkg.Instance)
 
-	regionRevocationInfoMap, instanceSpecMap, err := fetchSpotInstanceRevocationInfoAndSpecsMap(config, logger)
+	advisorInfo, err := fetchSpotAdvisorInfo(config, logger)
 	if err != nil {
 		logger.Error("error fetching spot instance revocation info and specifications from API", zap.Error(err))
 		return nil, err
@@ -39,13 +39,6 @@ func GetSpotInstances(
 	for _, region := range regions {
 		logger.Info("creating spot instances for region", zap.String("region", region.CodeString()))
 
-		regionRevocationInfo, ok := regionRevocationInfoMap[region.CodeString()]
-		if !ok {
-			logger.Error("could not find region revocation info", zap.String("region", region.CodeString()))
-			continue
-		}
-		logger.Debug("fetched revocation info")
-
 		regionSpotPrices, err := getSpotInstancePricesForRegion(config, region, creds, logger)
 		if err != nil {
 			logger.Error(
@@ -56,23 +49,53 @@ func GetSpotInstances(
 			return nil, err
 		}
 
-		regionPriceMap := createInstancePriceMap(regionSpotPrices)
-
-		instances, err := createRegionSpotInstances(config, region, &regionRevocationInfo, regionPriceMap, instanceSpecMap, logger)
+		instances, err := createRegionSpotInstancesFromPrices(config, region, advisorInfo, regionSpotPrices, logger)
 		if err != nil {
 			return nil, err
 		}
+		if instances == nil {
+			continue
+		}
 		regionToInstanceMap[region] = instances
-		logger.Info(
-			"Finished creating spot instances for region",
-			zap.String("region", region.CodeString()),
-			zap.Int("instanceCount", len(instances)),
-		)
 	}
 
 	return regionToInstanceMap, nil
 }
 
+// createRegionSpotInstancesFromPrices creates a region's spot instances from
+// its spot prices and the spot advisor info, returning nil if the spot advisor
+// has no info for the region.
+func createRegionSpotInstancesFromPrices(
+	config *config.AwsApiConfig,
+	region types.Region,
+	advisorInfo *spotInstancesInfo,
+	regionSpotPrices []ec2Types.SpotPrice,
+	logger *zap.Logger,
+) (
+	[]*instPkg.Instance,
+	error,
+) {
+	regionRevocationInfo, ok := advisorInfo.RegionPrices[region.CodeString()]
+	if !ok {
+		logger.Error("could not find region revocation info", zap.String("region", region.CodeString()))
+		return nil, nil
+	}
+	logger.Debug("fetched revocation info")
+
+	regionPriceMap := createInstancePriceMap(regionSpotPrices)
+
+	instances, err := createRegionSpotInstances(config, region, &regionRevocationInfo, regionPriceMap, advisorInfo.SpecsMap, logger)
+	if err != nil {
+		return nil, err
+	}
+	logger.Info(
+		"Finished creating spot instances for region",
+		zap.String("region", region.CodeString()),
+		zap.Int("instanceCount", len(instances)),
+	)
+	return instances, nil
+}
+
 func createInstancePriceMap(spotPrices []ec2Types.SpotPrice) map[string]ec2Types.SpotPrice {
 	instancePriceMap := make(map[string]ec2Types.SpotPrice)
 	for _, price := range spotPrices {
@@ -84,7 +107,7 @@ func createInstancePriceMap(spotPrices []ec2Types.SpotPrice) map[string]ec2Types
 func getSpotInstancePricesForRegion(
 	config *config.AwsApiConfig,
 	region types.Region,
-	creds credentials.StaticCredentialsProvider,
+	creds aws.CredentialsProvider,
 	logger *zap.Logger,
 ) ([]ec2Types.SpotPrice, error) {
 	awsConfig, err := createAwsConfig(region.CodeString(), creds)
@@ -114,6 +137,7 @@ func fetchSpotInstanceAvailabilityInfo(
 	total := 0
 	for (total < maxInstanceCount || maxInstanceCount <= 0) && (nextToken != "" || firstIter) {
 		resp, err := ec2Client.DescribeSpotPriceHistory(context.TODO(), &ec2.DescribeSpotPriceHistoryInput{})
+		recordAwsApiCall("ec2", "DescribeSpotPriceHistory", err)
 		if err != nil {
 			logger.Error("error calling DescribeSpotInstancePriceHistory to EC2 client", zap.Error(err))
 			return nil, err
@@ -148,30 +172,40 @@ func fetchSpotInstanceAvailabilityInfo(
 	return spotPrices, nil
 }
 
-func fetchSpotInstanceRevocationInfoAndSpecsMap(
+func fetchSpotAdvisorInfo(
 	config *config.AwsApiConfig,
 	logger *zap.Logger,
 ) (
-	map[string]regionSpotInstanceRevocationInfo,
-	map[string]spotInstanceSpecs,
+	*spotInstancesInfo,
 	error,
 ) {
-	cwd, err := utils.GetCallerPath()
+	infoFile, err := downloadSpotAdvisorInfo(config, logger)
 	if err != nil {
-		logger.Error("failed to fetch current working directory", zap.Error(err))
-		return nil, nil, err
+		return nil, err
 	}
+	return parseSpotAdvisorInfo(infoFile)
+}
 
-	filepath, err := utils.CreateFilepath(cwd, config.DownloadsDir, "spot-instance-info.json")
+// downloadSpotAdvisorInfo downloads the spot advisor's revocation
+// probabilities and instance specifications, returning the file's contents.
+func downloadSpotAdvisorInfo(
+	config *config.AwsApiConfig,
+	logger *zap.Logger,
+) (
+	[]byte,
+	error,
+) {
+	filepath, err := utils.CreateFilepath(config.DownloadsDir, "spot-instance-info.json")
 	if err != nil {
 		logger.Error("failed to create filepath", zap.Error(err))
-		return nil, nil, err
+		return nil, err
 	}
 
 	err = utils.DownloadFile(config.Endpoints.AwsSpotInstanceInfoUrl, filepath)
+	recordAwsApiCall("spotAdvisor", "DownloadSpotInstanceInfo", err)
 	if err != nil {
 		logger.Error("failed to download file", zap.String("getUrl", config.Endpoints.AwsSpotInstanceInfoUrl), zap.Error(err))
-		return nil, nil, err
+		return nil, err
 	}
 	logger.Info("downloaded spot instance revocation data",
 		zap.String("getUrl", config.Endpoints.AwsSpotInstanceInfoUrl),
@@ -181,12 +215,18 @@ func fetchSpotInstanceRevocationInfoAndSpecsMap(
 	infoFile, err := utils.FileToBytes(filepath)
 	if err != nil {
 		logger.Error("failed to parse file to bytes", zap.String("file", filepath), zap.Error(err))
-		return nil, nil, err
+		return nil, err
 	}
+	return infoFile, nil
+}
 
+func parseSpotAdvisorInfo(infoFile []byte) (*spotInstancesInfo, error) {
 	var info spotInstancesInfo
-	json.Unmarshal(infoFile, &info)
-	return info.RegionPrices, info.SpecsMap, nil
+	err := json.Unmarshal(infoFile, &info)
+	if err != nil {
+		return nil, utils.PrependToError(err, "could not parse spot advisor info")
+	}
+	return &info, nil
 }
 
 func createRegionSpotInstances(
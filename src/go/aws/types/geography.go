@@ -0,0 +1,81 @@
+package types
+
+import "fmt"
+
+// A Geography is a broad area of the world containing Regions, used to
+// restrict advice to Regions in particular places (e.g. Europe only).
+type Geography string
+
+const (
+	NorthAmerica Geography = "north-america"
+	SouthAmerica Geography = "south-america"
+	Europe       Geography = "europe"
+	AsiaPacific  Geography = "asia-pacific"
+)
+
+// The Geography of each Region, by Region code
+var REGION_GEOGRAPHIES = map[string]Geography{
+	"us-east-1":      NorthAmerica,
+	"us-east-2":      NorthAmerica,
+	"us-west-1":      NorthAmerica,
+	"us-west-2":      NorthAmerica,
+	"ap-south-1":     AsiaPacific,
+	"ap-northeast-3": AsiaPacific,
+	"ap-northeast-2": AsiaPacific,
+	"ap-southeast-1": AsiaPacific,
+	"ap-southeast-2": AsiaPacific,
+	"ap-northeast-1": AsiaPacific,
+	"ca-central-1":   NorthAmerica,
+	"eu-central-1":   Europe,
+	"eu-west-1":      Europe,
+	"eu-west-2":      Europe,
+	"eu-west-3":      Europe,
+	"eu-north-1":     Europe,
+	"sa-east-1":      SouthAmerica,
+}
+
+// Geography returns the Geography a Region is in.
+func (region Region) Geography() Geography {
+	return REGION_GEOGRAPHIES[region.CodeString()]
+}
+
+// NewGeography creates a Geography from its string representation.
+//
+// An error is returned if the string does not match any Geography.
+func NewGeography(value string) (Geography, error) {
+	switch Geography(value) {
+	case NorthAmerica, SouthAmerica, Europe, AsiaPacific:
+		return Geography(value), nil
+	}
+	return "", fmt.Errorf("provided value of \"%s\" does not match any geography", value)
+}
+
+// NewGeographies creates multiple Geographies from a slice of string values.
+//
+// An error is returned if any value does not match a Geography.
+func NewGeographies(values []string) ([]Geography, error) {
+	geographies := []Geography{}
+	for _, value := range values {
+		g, err := NewGeography(value)
+		if err != nil {
+			return nil, err
+		}
+		geographies = append(geographies, g)
+	}
+	return geographies, nil
+}
+
+// RegionsInGeographies returns the Regions which are in any of the given
+// Geographies, keeping their order.
+func RegionsInGeographies(regions []Region, geographies []Geography) []Region {
+	inGeographies := []Region{}
+	for _, region := range regions {
+		for _, geography := range geographies {
+			if region.Geography() == geography {
+				inGeographies = append(inGeographies, region)
+				break
+			}
+		}
+	}
+	return inGeographies
+}
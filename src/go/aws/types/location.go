@@ -0,0 +1,78 @@
+package types
+
+import (
+	"math"
+	"time"
+)
+
+const (
+	EARTH_RADIUS_KM = 6371
+
+	// How far light travels through fibre in a millisecond
+	FIBRE_KM_PER_MS = 200
+
+	// How much longer network routes are than the great-circle distance
+	ROUTE_DISTANCE_FACTOR = 1.4
+
+	// The latency added by switching and routing on any round trip
+	BASE_LATENCY = 2 * time.Millisecond
+)
+
+// A Location is a point on the Earth, in degrees.
+type Location struct {
+	Latitude  float64
+	Longitude float64
+}
+
+// Approximate locations of each Region's datacentres, by Region code
+var REGION_LOCATIONS = map[string]Location{
+	"us-east-1":      {Latitude: 39.0, Longitude: -77.5},
+	"us-east-2":      {Latitude: 40.0, Longitude: -83.0},
+	"us-west-1":      {Latitude: 37.4, Longitude: -121.9},
+	"us-west-2":      {Latitude: 45.8, Longitude: -119.7},
+	"ap-south-1":     {Latitude: 19.1, Longitude: 72.9},
+	"ap-northeast-3": {Latitude: 34.7, Longitude: 135.5},
+	"ap-northeast-2": {Latitude: 37.6, Longitude: 127.0},
+	"ap-southeast-1": {Latitude: 1.4, Longitude: 103.8},
+	"ap-southeast-2": {Latitude: -33.9, Longitude: 151.2},
+	"ap-northeast-1": {Latitude: 35.7, Longitude: 139.7},
+	"ca-central-1":   {Latitude: 45.5, Longitude: -73.6},
+	"eu-central-1":   {Latitude: 50.1, Longitude: 8.7},
+	"eu-west-1":      {Latitude: 53.3, Longitude: -6.3},
+	"eu-west-2":      {Latitude: 51.5, Longitude: -0.1},
+	"eu-west-3":      {Latitude: 48.9, Longitude: 2.4},
+	"eu-north-1":     {Latitude: 59.3, Longitude: 18.1},
+	"sa-east-1":      {Latitude: -23.5, Longitude: -46.6},
+}
+
+// Location returns the approximate location of a Region's datacentres.
+func (region Region) Location() Location {
+	return REGION_LOCATIONS[region.CodeString()]
+}
+
+// DistanceKm returns the great-circle distance between two Locations,
+// in kilometres.
+func (l Location) DistanceKm(other Location) float64 {
+	lat1, lat2 := toRadians(l.Latitude), toRadians(other.Latitude)
+	deltaLat := lat2 - lat1
+	deltaLong := toRadians(other.Longitude - l.Longitude)
+
+	a := math.Pow(math.Sin(deltaLat/2), 2) +
+		math.Cos(lat1)*math.Cos(lat2)*math.Pow(math.Sin(deltaLong/2), 2)
+	return 2 * EARTH_RADIUS_KM * math.Asin(math.Sqrt(a))
+}
+
+// EstimatedLatency returns an estimate of the round-trip network latency
+// between two Regions, from the great-circle distance between them.
+func EstimatedLatency(from Region, to Region) time.Duration {
+	if from == to {
+		return BASE_LATENCY
+	}
+	routeKm := from.Location().DistanceKm(to.Location()) * ROUTE_DISTANCE_FACTOR
+	roundTripMs := 2 * routeKm / FIBRE_KM_PER_MS
+	return BASE_LATENCY + time.Duration(roundTripMs*float64(time.Millisecond))
+}
+
+func toRadians(degrees float64) float64 {
+	return degrees * math.Pi / 180
+}
@@ -0,0 +1,124 @@
+package batch
+
+import (
+	"aws-blended-instances-advisor/api/schema"
+	"aws-blended-instances-advisor/utils"
+	"context"
+	"runtime"
+	"sort"
+	"sync"
+
+	"go.uber.org/zap"
+)
+
+// An AdviseFunc generates Advice for a single AdviseRequest.
+//
+// All calls made by Run should use the same instance data so that
+// Scenarios can be compared fairly.
+type AdviseFunc func(ctx context.Context, req schema.AdviseRequest) (*schema.Advice, error)
+
+type scenarioResult struct {
+	advice *schema.Advice
+	err    error
+}
+
+// Run generates Advice for each Scenario concurrently, returning the Advice
+// and a comparison of each Scenario's regions.
+//
+// Scenarios which fail are reported in the response's errors, rather than
+// causing the whole batch to fail.
+func Run(
+	ctx context.Context,
+	scenarios []schema.Scenario,
+	advise AdviseFunc,
+	logger *zap.Logger,
+) *schema.BatchAdviseResponse {
+	results := make([]scenarioResult, len(scenarios))
+
+	limiter := make(chan bool, runtime.NumCPU())
+	var wg sync.WaitGroup
+
+	for index := range scenarios {
+		wg.Add(1)
+		go func(index int) {
+			defer wg.Done()
+			limiter <- true
+			defer func() { <-limiter }()
+
+			scenario := scenarios[index]
+			logger.Info("advising for scenario", zap.String("scenario", scenario.Name))
+
+			advice, err := advise(ctx, scenario.Request)
+			results[index] = scenarioResult{advice: advice, err: err}
+
+			if err != nil {
+				logger.Error("failed to advise for scenario", zap.String("scenario", scenario.Name), zap.Error(err))
+			}
+		}(index)
+	}
+
+	wg.Wait()
+
+	return createResponse(scenarios, results)
+}
+
+func createResponse(scenarios []schema.Scenario, results []scenarioResult) *schema.BatchAdviseResponse {
+	resp := &schema.BatchAdviseResponse{
+		Comparison: []schema.ScenarioComparison{},
+		Advice:     make(map[string]schema.Advice),
+		Errors:     make(map[string]string),
+	}
+
+	for index, scenario := range scenarios {
+		result := results[index]
+		if result.err != nil {
+			resp.Errors[scenario.Name] = result.err.Error()
+			continue
+		}
+
+		advice := *result.advice
+		resp.Advice[scenario.Name] = advice
+
+		for _, region := range adviceRegions(scenario.Request, advice) {
+			resp.Comparison = append(resp.Comparison, CompareRegionAdvice(scenario.Name, region, advice[region]))
+		}
+	}
+
+	return resp
+}
+
+// adviceRegions returns the regions of advice in the order they were
+// requested, followed by any others in alphabetical order.
+func adviceRegions(req schema.AdviseRequest, advice schema.Advice) []string {
+	regions := []string{}
+	seen := make(utils.StringSet)
+	for _, region := range req.Options.GetRegionCodes() {
+		if _, ok := advice[region]; ok && !seen.Contains(region) {
+			regions = append(regions, region)
+			seen.Add(region)
+		}
+	}
+
+	others := []string{}
+	for region := range advice {
+		if !seen.Contains(region) {
+			others = append(others, region)
+		}
+	}
+	sort.Strings(others)
+
+	return append(regions, others...)
+}
+
+// CompareRegionAdvice summarises a RegionAdvice as a ScenarioComparison.
+func CompareRegionAdvice(scenarioName string, region string, advice schema.RegionAdvice) schema.ScenarioComparison {
+	return schema.ScenarioComparison{
+		Scenario:            scenarioName,
+		Region:              region,
+		Score:               advice.Score,
+		PricePerHour:        advice.PricePerHour(),
+		ExpectedRevocations: advice.ExpectedRevocations(),
+		InstanceCount:       len(advice.Instances),
+		Rank:                advice.Rank,
+	}
+}
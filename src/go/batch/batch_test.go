@@ -0,0 +1,128 @@
+package batch
+
+import (
+	"aws-blended-instances-advisor/api/schema"
+	"aws-blended-instances-advisor/utils"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestRun(t *testing.T) {
+	logger, err := utils.CreateMockLogger()
+	if err != nil {
+		t.Fatalf("Failed to create mock logger: %s", err.Error())
+	}
+
+	scenarios := []schema.Scenario{
+		{Name: "cheap", Request: schema.AdviseRequest{Options: schema.Options{Regions: []string{"us-east-1", "eu-west-1"}}}},
+		{Name: "broken", Request: schema.AdviseRequest{Options: schema.Options{Regions: []string{"us-east-1"}}}},
+	}
+
+	advise := func(ctx context.Context, req schema.AdviseRequest) (*schema.Advice, error) {
+		if len(req.Options.Regions) == 1 {
+			return nil, errors.New("TEST ERROR")
+		}
+		advice := make(schema.Advice)
+		for _, region := range req.Options.Regions {
+			ra := schema.RegionAdvice{Score: 0.5}
+			ra.AddAssignment("svc", &schema.Instance{Id: "a", PricePerHour: 1.5, RevocationProbability: 0.1})
+			ra.AddAssignment("svc", &schema.Instance{Id: "b", PricePerHour: 0.5, RevocationProbability: 0.2})
+			advice[region] = ra
+		}
+		return &advice, nil
+	}
+
+	resp := Run(context.Background(), scenarios, advise, logger)
+
+	if resp.Errors["broken"] != "TEST ERROR" {
+		t.Fatalf("Expected error for broken scenario, got: %+v", resp.Errors)
+	}
+	if _, ok := resp.Advice["broken"]; ok {
+		t.Fatalf("Broken scenario should not have advice")
+	}
+	if len(resp.Advice["cheap"]) != 2 {
+		t.Fatalf("Expected advice for 2 regions, got: %+v", resp.Advice["cheap"])
+	}
+
+	expected := []schema.ScenarioComparison{
+		{Scenario: "cheap", Region: "us-east-1", Score: 0.5, PricePerHour: 2.0, ExpectedRevocations: 0.3, InstanceCount: 2},
+		{Scenario: "cheap", Region: "eu-west-1", Score: 0.5, PricePerHour: 2.0, ExpectedRevocations: 0.3, InstanceCount: 2},
+	}
+	if len(resp.Comparison) != len(expected) {
+		t.Fatalf("Wrong comparison length. Wanted: %+v, got: %+v", expected, resp.Comparison)
+	}
+	for i := range expected {
+		got := resp.Comparison[i]
+		if got.Scenario != expected[i].Scenario || got.Region != expected[i].Region ||
+			got.InstanceCount != expected[i].InstanceCount ||
+			!utils.FloatsEqual(got.PricePerHour, expected[i].PricePerHour) ||
+			!utils.FloatsEqual(got.ExpectedRevocations, expected[i].ExpectedRevocations) {
+			t.Fatalf("Wrong comparison %d. Wanted: %+v, got: %+v", i, expected[i], got)
+		}
+	}
+}
+
+func TestBatchRequestValidate(t *testing.T) {
+	valid := schema.Scenario{
+		Name: "valid",
+		Request: schema.AdviseRequest{
+			Services: []schema.Service{{Name: "svc", MinMemory: 1, MaxVcpu: 1, MinInstances: 1, MaxInstances: 1}},
+			Options:  schema.Options{Regions: []string{"us-east-1"}},
+		},
+	}
+
+	tests := map[string]struct {
+		req     schema.BatchAdviseRequest
+		isValid bool
+	}{
+		"valid":          {req: schema.BatchAdviseRequest{Scenarios: []schema.Scenario{valid}}, isValid: true},
+		"no scenarios":   {req: schema.BatchAdviseRequest{}, isValid: false},
+		"duplicate name": {req: schema.BatchAdviseRequest{Scenarios: []schema.Scenario{valid, valid}}, isValid: false},
+		"empty name": {
+			req:     schema.BatchAdviseRequest{Scenarios: []schema.Scenario{{Request: valid.Request}}},
+			isValid: false,
+		},
+	}
+
+	for name, test := range tests {
+		err := test.req.Validate()
+		if (err == nil) != test.isValid {
+			t.Fatalf("Wrong validation result for \"%s\". Wanted valid: %t, got error: %v", name, test.isValid, err)
+		}
+	}
+}
+
+func TestAdviceRegions(t *testing.T) {
+	advice := schema.Advice{"us-east-1": {}, "eu-west-1": {}, "eu-central-1": {}}
+
+	tests := map[string]struct {
+		options  schema.Options
+		expected []string
+	}{
+		"requested order": {
+			options:  schema.Options{Regions: []string{"us-east-1", "eu-west-1", "eu-central-1"}},
+			expected: []string{"us-east-1", "eu-west-1", "eu-central-1"},
+		},
+		"regions without advice": {
+			options:  schema.Options{Regions: []string{"us-west-2", "eu-west-1", "us-east-1", "eu-central-1"}},
+			expected: []string{"eu-west-1", "us-east-1", "eu-central-1"},
+		},
+		"advice for unrequested regions": {
+			options:  schema.Options{Regions: []string{"us-east-1"}},
+			expected: []string{"us-east-1", "eu-central-1", "eu-west-1"},
+		},
+		"geographies": {
+			options:  schema.Options{Geographies: []string{"europe"}},
+			expected: []string{"eu-central-1", "eu-west-1", "us-east-1"},
+		},
+	}
+
+	for name, test := range tests {
+		got := adviceRegions(schema.AdviseRequest{Options: test.options}, advice)
+		if strings.Join(got, ",") != strings.Join(test.expected, ",") {
+			t.Fatalf("Wrong regions for \"%s\". Wanted: %v, got: %v", name, test.expected, got)
+		}
+	}
+}
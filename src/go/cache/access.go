@@ -0,0 +1,72 @@
+package cache
+
+import (
+	"sync"
+	"time"
+)
+
+// accessTimes records when entries were last read, so that reading an entry
+// does not write to the cache. The times are written in a batch when the
+// cache is flushed.
+type accessTimes struct {
+	mu    sync.Mutex
+	times map[string]time.Time
+}
+
+// record notes that file was read now.
+func (a *accessTimes) record(file string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.times == nil {
+		a.times = make(map[string]time.Time)
+	}
+	a.times[file] = time.Now()
+}
+
+// apply sets the AccessDate of entry to when file was last read, if that is
+// later than the AccessDate already stored.
+func (a *accessTimes) apply(file string, entry *CacheEntry) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if accessed, exists := a.times[file]; exists && accessed.After(entry.AccessDate) {
+		entry.AccessDate = accessed
+	}
+}
+
+// isEmpty returns true if no times have been recorded since take was last
+// called, and false otherwise.
+func (a *accessTimes) isEmpty() bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return len(a.times) == 0
+}
+
+// take returns the times recorded since it was last called, no longer
+// holding them.
+func (a *accessTimes) take() map[string]time.Time {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	times := a.times
+	a.times = nil
+	return times
+}
+
+// restore holds times which could not be written again, unless their files
+// have been read since.
+func (a *accessTimes) restore(times map[string]time.Time) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.times == nil {
+		a.times = make(map[string]time.Time)
+	}
+	for file, accessed := range times {
+		if accessed.After(a.times[file]) {
+			a.times[file] = accessed
+		}
+	}
+}
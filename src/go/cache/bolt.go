@@ -0,0 +1,392 @@
+package cache
+
+import (
+	"aws-blended-instances-advisor/utils"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+const (
+	BOLT_FILENAME     = "cache.db"
+	BOLT_OPEN_TIMEOUT = 5 * time.Second
+)
+
+var (
+	entriesBucket  = []byte("entries")
+	contentsBucket = []byte("contents")
+)
+
+var _ Cache = &BoltCache{}
+
+// A BoltCache is a Cache which stores entries in a single embedded
+// key-value database file.
+//
+// Writes are atomic, and any number of readers may use a BoltCache
+// concurrently. The database file is locked while open, so only one
+// process may use it at a time.
+type BoltCache struct {
+	Dirpath  string
+	db       *bolt.DB
+	options  Options
+	accessed accessTimes // Written to the database when the cache is flushed
+}
+
+// OpenBolt opens the BoltCache stored in cacheDirpath, storing entries as
+// described by options, creating it if it does not exist. Any cache using the file backend in cacheDirpath is migrated into
+// the BoltCache, after which its files are removed.
+//
+// Returns an error if the database could not be opened or migrated, including
+// when another process has held it open for longer than BOLT_OPEN_TIMEOUT.
+func OpenBolt(cacheDirpath string, options Options) (*BoltCache, error) {
+	err := os.MkdirAll(cacheDirpath, os.ModePerm)
+	if err != nil {
+		return nil, utils.PrependToError(err, "failed to create cache directory")
+	}
+
+	path, err := utils.CreateFilepath(cacheDirpath, BOLT_FILENAME)
+	if err != nil {
+		return nil, utils.PrependToError(err, "failed to generate cache filepath")
+	}
+
+	db, err := bolt.Open(path, utils.WRITE_PERMISSION_CODE, &bolt.Options{Timeout: BOLT_OPEN_TIMEOUT})
+	if err != nil {
+		return nil, utils.PrependToError(err, fmt.Sprintf("failed to open cache database %s", path))
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(entriesBucket)
+		if err != nil {
+			return err
+		}
+		_, err = tx.CreateBucketIfNotExists(contentsBucket)
+		return err
+	})
+	if err != nil {
+		db.Close()
+		return nil, utils.PrependToError(err, "failed to create cache buckets")
+	}
+
+	c := &BoltCache{Dirpath: cacheDirpath, db: db, options: options}
+
+	err = c.migrateFileCache()
+	if err != nil {
+		db.Close()
+		return nil, utils.PrependToError(err, "failed to migrate file cache")
+	}
+
+	return c, nil
+}
+
+// Contains returns true if the given file is in the cache,
+// returning false otherwise.
+func (c *BoltCache) Contains(file string) bool {
+	_, err := c.GetEntry(file)
+	return err == nil
+}
+
+// GetEntry returns the CacheEntry for the file if it's in the cache.
+// Returns an error otherwise.
+func (c *BoltCache) GetEntry(file string) (*CacheEntry, error) {
+	var entry *CacheEntry
+	err := c.db.View(func(tx *bolt.Tx) error {
+		var err error
+		entry, err = getBoltEntry(tx, file)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	c.accessed.apply(file, entry)
+	return entry, nil
+}
+
+// IsValid returns true if the given file is in the cache and is valid.
+// Returns false otherwise.
+func (c *BoltCache) IsValid(file string) bool {
+	entry, err := c.GetEntry(file)
+	if err != nil {
+		return false
+	}
+	return entry.InvalidationDate.After(time.Now())
+}
+
+// Get returns the cached file's contents if the given file is in the cache and is valid.
+// Returns an error otherwise or if an error occurs when reading the database.
+func (c *BoltCache) Get(file string) (string, error) {
+	if !c.IsValid(file) {
+		cacheMisses.Inc(file)
+		return "", errors.New("cache entry is invalid")
+	}
+	cacheHits.Inc(file)
+
+	return c.GetIgnoringValidity(file)
+}
+
+// GetIgnoringValidity returns the cached file's contents if the given file
+// is in the cache, regardless of its validity.
+// Returns an error otherwise or if an error occurs when reading the database.
+func (c *BoltCache) GetIgnoringValidity(file string) (string, error) {
+	var entry *CacheEntry
+	var stored []byte
+	err := c.db.View(func(tx *bolt.Tx) error {
+		var err error
+		entry, err = getBoltEntry(tx, file)
+		if err != nil {
+			return fmt.Errorf("cache does not contain file: %s", file)
+		}
+
+		value := tx.Bucket(contentsBucket).Get([]byte(file))
+		stored = append([]byte(nil), value...) // Copied, as value is only valid within the transaction
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	contents, err := entry.decode(stored)
+	if err != nil {
+		return "", err
+	}
+
+	c.accessed.record(file)
+	return contents, nil
+}
+
+// Set stores fileContent under filename, valid for a duration of lifetime.
+// The entry and its contents are written in a single transaction.
+func (c *BoltCache) Set(filename string, fileContent string, lifetime time.Duration) error {
+	stored, entry, err := c.options.encode(filename, fileContent, lifetime)
+	if err != nil {
+		return utils.PrependToError(err, "failed to compress cache entry")
+	}
+
+	err = c.db.Update(func(tx *bolt.Tx) error {
+		return putBoltEntry(tx, entry, stored)
+	})
+	if err != nil {
+		return utils.PrependToError(err, "failed to write cache entry")
+	}
+
+	if c.options.MaxSizeBytes > 0 {
+		_, err = evictToSize(c, c.options.MaxSizeBytes, filename)
+		if err != nil {
+			return utils.PrependToError(err, "failed to evict entries over maximum size")
+		}
+	}
+	return nil
+}
+
+// Invalidate marks the given file's entry as invalid from now, keeping its contents.
+// Returns an error if the file is not in the cache.
+func (c *BoltCache) Invalidate(file string) error {
+	return c.updateEntry(file, func(entry *CacheEntry) {
+		entry.InvalidationDate = time.Now()
+	})
+}
+
+// Delete removes the given file from the cache.
+// Returns an error if the file is not in the cache.
+func (c *BoltCache) Delete(file string) error {
+	return c.db.Update(func(tx *bolt.Tx) error {
+		_, err := getBoltEntry(tx, file)
+		if err != nil {
+			return err
+		}
+
+		err = tx.Bucket(entriesBucket).Delete([]byte(file))
+		if err != nil {
+			return err
+		}
+		return tx.Bucket(contentsBucket).Delete([]byte(file))
+	})
+}
+
+// List returns a copy of every entry in the cache, keyed by filename.
+func (c *BoltCache) List() (map[string]CacheEntry, error) {
+	entries := make(map[string]CacheEntry)
+	err := c.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(entriesBucket).ForEach(func(k, v []byte) error {
+			var entry CacheEntry
+			err := json.Unmarshal(v, &entry)
+			if err != nil {
+				return utils.PrependToError(err, fmt.Sprintf("failed to parse entry %s", k))
+			}
+			c.accessed.apply(string(k), &entry)
+			entries[string(k)] = entry
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// Clear removes every entry from the cache.
+func (c *BoltCache) Clear() error {
+	return c.db.Update(func(tx *bolt.Tx) error {
+		for _, name := range [][]byte{entriesBucket, contentsBucket} {
+			err := tx.DeleteBucket(name)
+			if err != nil {
+				return err
+			}
+			_, err = tx.CreateBucket(name)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Flush writes when entries were last read to the database, in a single
+// transaction.
+func (c *BoltCache) Flush() error {
+	accessed := c.accessed.take()
+	if len(accessed) == 0 {
+		return nil
+	}
+
+	err := c.db.Update(func(tx *bolt.Tx) error {
+		for file, accessedAt := range accessed {
+			entry, err := getBoltEntry(tx, file)
+			if err != nil || !accessedAt.After(entry.AccessDate) {
+				continue // Deleted since it was read, or already up to date
+			}
+			entry.AccessDate = accessedAt
+
+			entryBytes, err := json.Marshal(entry)
+			if err != nil {
+				return err
+			}
+			err = tx.Bucket(entriesBucket).Put([]byte(file), entryBytes)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		c.accessed.restore(accessed)
+		return utils.PrependToError(err, "failed to write access times")
+	}
+	return nil
+}
+
+// Close flushes the cache and closes the database, releasing its lock.
+func (c *BoltCache) Close() error {
+	err := c.Flush()
+	if err != nil {
+		c.db.Close()
+		return err
+	}
+	return c.db.Close()
+}
+
+// migrateFileCache moves the entries of any FileCache in the BoltCache's
+// directory into the BoltCache, keeping their set and invalidation dates.
+// The FileCache's files are only removed once all entries are committed.
+func (c *BoltCache) migrateFileCache() error {
+	indexFilepath, err := getCacheFileFilepath(c.Dirpath)
+	if err != nil {
+		return err
+	}
+
+	exists, err := utils.FileExists(indexFilepath)
+	if err != nil || !exists {
+		return err
+	}
+
+	fileCache, err := ParseCache(c.Dirpath)
+	if err != nil {
+		return err
+	}
+
+	err = c.db.Update(func(tx *bolt.Tx) error {
+		for file, entry := range fileCache.Entries {
+			contents, err := fileCache.GetIgnoringValidity(file)
+			if err != nil {
+				return utils.PrependToError(err, fmt.Sprintf("failed to read entry %s", file))
+			}
+			stored, err := compress([]byte(contents), c.options.Compression)
+			if err != nil {
+				return err
+			}
+			entry.Checksum = checksum(stored)
+			entry.SizeBytes = int64(len(stored))
+			entry.Compression = c.options.Compression
+			err = putBoltEntry(tx, entry, stored)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	err = fileCache.Clear()
+	if err != nil {
+		return err
+	}
+	err = os.Remove(indexFilepath)
+	if err != nil {
+		return err
+	}
+
+	lockFilepath, err := utils.CreateFilepath(c.Dirpath, LOCK_FILENAME)
+	if err != nil {
+		return err
+	}
+	return os.Remove(lockFilepath)
+}
+
+// updateEntry applies change to the given file's entry in a single transaction.
+func (c *BoltCache) updateEntry(file string, change func(entry *CacheEntry)) error {
+	return c.db.Update(func(tx *bolt.Tx) error {
+		entry, err := getBoltEntry(tx, file)
+		if err != nil {
+			return err
+		}
+		change(entry)
+
+		entryBytes, err := json.Marshal(entry)
+		if err != nil {
+			return err
+		}
+		return tx.Bucket(entriesBucket).Put([]byte(file), entryBytes)
+	})
+}
+
+func getBoltEntry(tx *bolt.Tx, file string) (*CacheEntry, error) {
+	value := tx.Bucket(entriesBucket).Get([]byte(file))
+	if value == nil {
+		return nil, fmt.Errorf("entry does not exist: %s", file)
+	}
+
+	var entry CacheEntry
+	err := json.Unmarshal(value, &entry)
+	if err != nil {
+		return nil, utils.PrependToError(err, fmt.Sprintf("failed to parse entry %s", file))
+	}
+	return &entry, nil
+}
+
+func putBoltEntry(tx *bolt.Tx, entry CacheEntry, contents []byte) error {
+	entryBytes, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	err = tx.Bucket(entriesBucket).Put([]byte(entry.Filename), entryBytes)
+	if err != nil {
+		return err
+	}
+	return tx.Bucket(contentsBucket).Put([]byte(entry.Filename), contents)
+}
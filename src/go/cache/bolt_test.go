@@ -0,0 +1,222 @@
+package cache
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestBoltSetAndGet(t *testing.T) {
+	c, err := OpenBolt(t.TempDir(), Options{})
+	if err != nil {
+		t.Fatalf("Failed to open cache: %s", err.Error())
+	}
+	defer c.Close()
+
+	err = c.Set(TEST_FILE_FILENAME, TEST_FILE_CONTENT, time.Hour)
+	if err != nil {
+		t.Fatalf("Failed to set entry: %s", err.Error())
+	}
+	err = c.Set("expired.txt", "EXPIRED", -time.Hour)
+	if err != nil {
+		t.Fatalf("Failed to set entry: %s", err.Error())
+	}
+
+	content, err := c.Get(TEST_FILE_FILENAME)
+	if err != nil || content != TEST_FILE_CONTENT {
+		t.Fatalf("Wanted %s, got: %s (error: %v)", TEST_FILE_CONTENT, content, err)
+	}
+
+	_, err = c.Get("expired.txt")
+	if err == nil {
+		t.Fatalf("Expected error getting expired entry")
+	}
+	content, err = c.GetIgnoringValidity("expired.txt")
+	if err != nil || content != "EXPIRED" {
+		t.Fatalf("Wanted EXPIRED ignoring validity, got: %s (error: %v)", content, err)
+	}
+
+	_, err = c.GetIgnoringValidity("missing.txt")
+	if err == nil {
+		t.Fatalf("Expected error getting missing entry")
+	}
+
+	entries, err := c.List()
+	if err != nil {
+		t.Fatalf("Failed to list entries: %s", err.Error())
+	}
+	if len(entries) != 2 || entries[TEST_FILE_FILENAME].Filename != TEST_FILE_FILENAME {
+		t.Fatalf("Unexpected entries listed: %v", entries)
+	}
+
+	err = c.Clear()
+	if err != nil {
+		t.Fatalf("Failed to clear cache: %s", err.Error())
+	}
+	if c.Contains(TEST_FILE_FILENAME) {
+		t.Fatalf("Cache contains entry after being cleared")
+	}
+}
+
+func TestBoltPersists(t *testing.T) {
+	dirpath := t.TempDir()
+
+	c, err := OpenBolt(dirpath, Options{})
+	if err != nil {
+		t.Fatalf("Failed to open cache: %s", err.Error())
+	}
+	err = c.Set(TEST_FILE_FILENAME, TEST_FILE_CONTENT, time.Hour)
+	if err != nil {
+		t.Fatalf("Failed to set entry: %s", err.Error())
+	}
+	c.Close()
+
+	c, err = OpenBolt(dirpath, Options{})
+	if err != nil {
+		t.Fatalf("Failed to reopen cache: %s", err.Error())
+	}
+	defer c.Close()
+
+	content, err := c.Get(TEST_FILE_FILENAME)
+	if err != nil || content != TEST_FILE_CONTENT {
+		t.Fatalf("Wanted %s after reopening, got: %s (error: %v)", TEST_FILE_CONTENT, content, err)
+	}
+}
+
+func TestBoltConcurrentUse(t *testing.T) {
+	c, err := OpenBolt(t.TempDir(), Options{})
+	if err != nil {
+		t.Fatalf("Failed to open cache: %s", err.Error())
+	}
+	defer c.Close()
+
+	err = c.Set(TEST_FILE_FILENAME, TEST_FILE_CONTENT, time.Hour)
+	if err != nil {
+		t.Fatalf("Failed to set entry: %s", err.Error())
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 20)
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			errs <- c.Set(fmt.Sprintf("file-%d.txt", i), TEST_FILE_CONTENT, time.Hour)
+		}(i)
+		go func() {
+			defer wg.Done()
+			content, err := c.Get(TEST_FILE_FILENAME)
+			if err == nil && content != TEST_FILE_CONTENT {
+				err = fmt.Errorf("read unexpected content: %s", content)
+			}
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("Concurrent use failed: %s", err.Error())
+		}
+	}
+
+	entries, _ := c.List()
+	if len(entries) != 11 {
+		t.Fatalf("Wanted 11 entries, got: %d", len(entries))
+	}
+}
+
+func TestBoltMigratesFileCache(t *testing.T) {
+	dirpath := t.TempDir()
+
+	fileCache, err := New(dirpath)
+	if err != nil {
+		t.Fatalf("Failed to create file cache: %s", err.Error())
+	}
+	err = fileCache.Set(TEST_FILE_FILENAME, TEST_FILE_CONTENT, time.Hour)
+	if err != nil {
+		t.Fatalf("Failed to set file cache entry: %s", err.Error())
+	}
+	fileEntry, _ := fileCache.GetEntry(TEST_FILE_FILENAME)
+
+	c, err := Open(dirpath, Options{Backend: BoltBackend}, false)
+	if err != nil {
+		t.Fatalf("Failed to open cache: %s", err.Error())
+	}
+	defer c.Close()
+
+	entry, err := c.GetEntry(TEST_FILE_FILENAME)
+	if err != nil {
+		t.Fatalf("Migrated entry not found: %s", err.Error())
+	}
+	if !entry.SetDate.Equal(fileEntry.SetDate) || !entry.InvalidationDate.Equal(fileEntry.InvalidationDate) {
+		t.Fatalf("Migrated entry dates changed. Wanted: %v, got: %v", fileEntry, entry)
+	}
+
+	content, err := c.Get(TEST_FILE_FILENAME)
+	if err != nil || content != TEST_FILE_CONTENT {
+		t.Fatalf("Wanted migrated content %s, got: %s (error: %v)", TEST_FILE_CONTENT, content, err)
+	}
+
+	_, err = ParseCache(dirpath)
+	if err == nil {
+		t.Fatalf("Expected file cache to be removed after migration")
+	}
+}
+
+func TestBoltConcurrentGetDoesNotWrite(t *testing.T) {
+	dirpath := t.TempDir()
+	c, err := OpenBolt(dirpath, Options{})
+	if err != nil {
+		t.Fatalf("Failed to open cache: %s", err.Error())
+	}
+
+	err = c.Set(TEST_FILE_FILENAME, TEST_FILE_CONTENT, time.Hour)
+	if err != nil {
+		t.Fatalf("Failed to set entry: %s", err.Error())
+	}
+
+	// Gets which update the database would wait for this transaction to end
+	tx, err := c.db.Begin(true)
+	if err != nil {
+		t.Fatalf("Failed to begin transaction: %s", err.Error())
+	}
+	done := make(chan error)
+	for i := 0; i < 10; i++ {
+		go func() {
+			_, err := c.Get(TEST_FILE_FILENAME)
+			done <- err
+		}()
+	}
+	for i := 0; i < 10; i++ {
+		select {
+		case err := <-done:
+			if err != nil {
+				t.Fatalf("Concurrent get failed: %s", err.Error())
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("Concurrent get blocked on a write transaction")
+		}
+	}
+	tx.Rollback()
+
+	if entry, _ := c.GetEntry(TEST_FILE_FILENAME); entry.AccessDate.IsZero() {
+		t.Fatalf("Access not recorded by get")
+	}
+
+	err = c.Close()
+	if err != nil {
+		t.Fatalf("Failed to close cache: %s", err.Error())
+	}
+	c, err = OpenBolt(dirpath, Options{})
+	if err != nil {
+		t.Fatalf("Failed to reopen cache: %s", err.Error())
+	}
+	defer c.Close()
+
+	if entry, _ := c.GetEntry(TEST_FILE_FILENAME); entry.AccessDate.IsZero() {
+		t.Fatalf("Access not written when cache closed")
+	}
+}
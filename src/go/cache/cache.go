@@ -1,221 +1,231 @@
 package cache
 
 import (
-	"aws-blended-instances-advisor/utils"
-	"encoding/json"
-	"errors"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"time"
 )
 
+// DEFAULT_LIFETIME can be given to Cache.Set to use the lifetime
+// given by the cache's Options.
+const DEFAULT_LIFETIME time.Duration = 0
+
+// A Backend is a way of storing cached files.
+type Backend string
+
 const (
-	CACHE_FILENAME = "cache.json"
+	FileBackend Backend = "file" // A file per entry, plus an index file
+	BoltBackend Backend = "bolt" // A single embedded key-value database file
 )
 
-// A Cache is an in-memory representation of cached files.
-type Cache struct {
-	Dirpath string                `json:"-"`
-	Entries map[string]CacheEntry `json:"entries"`
-}
+// A Cache stores the contents of files along with how long they remain valid.
+type Cache interface {
+	// Contains returns true if the given file is in the cache,
+	// returning false otherwise.
+	Contains(file string) bool
 
-// A CacheEntry is a representation of a single cached file.
-type CacheEntry struct {
-	SetDate          time.Time `json:"setAt"`
-	InvalidationDate time.Time `json:"invalidFrom"`
-	Filename         string    `json:"file"`
-}
+	// GetEntry returns the CacheEntry for the file if it's in the cache.
+	// Returns an error otherwise.
+	GetEntry(file string) (*CacheEntry, error)
 
-// New creates and returns a new cache.
-//
-// The given cacheDirpath is used as the directory to store cached files and cache metadata.
-// Previous caches using the same cacheDirpath are overwritten.
-//
-// Returns an error if an error occurred when writing to the given filepath.
-func New(cacheDirpath string) (*Cache, error) {
-	cacheFilepath, err := getCacheFileFilepath(cacheDirpath)
-	if err != nil {
-		return nil, utils.PrependToError(err, "failed to generate cache filepath")
-	}
+	// IsValid returns true if the given file is in the cache and is valid.
+	// Returns false otherwise.
+	IsValid(file string) bool
 
-	err = utils.WriteBytesToFile(make([]byte, 0), cacheFilepath)
-	if err != nil {
-		return nil, utils.PrependToError(err, "failed to write to cache file")
-	}
-	return &Cache{
-		Dirpath: cacheDirpath,
-		Entries: make(map[string]CacheEntry),
-	}, nil
-}
+	// Get returns the cached file's contents if the given file is in the cache and is valid.
+	// Returns an error otherwise or if an error occurs during the reading process.
+	Get(file string) (string, error)
 
-// ParseCache parses and returns a cache created at the given cacheDirpath.
-//
-// Returns an error if no cache exists at cacheDirpath or if there was an issue reading files.
-func ParseCache(cacheDirpath string) (*Cache, error) {
-	cacheFilepath, err := getCacheFileFilepath(cacheDirpath)
-	if err != nil {
-		return nil, utils.PrependToError(err, "failed to generate cache filepath")
-	}
+	// GetIgnoringValidity returns the cached file's contents if the given file
+	// is in the cache, regardless of its validity.
+	GetIgnoringValidity(file string) (string, error)
 
-	cacheFileBytes, err := utils.FileToBytes(cacheFilepath)
-	if err != nil {
-		return nil, utils.PrependToError(
-			err,
-			fmt.Sprintf("failed to read cache file from filepath %s", cacheFilepath),
-		)
-	}
+	// Set stores fileContent under filename, valid for a duration of lifetime
+	// (or the cache's default lifetime, if lifetime is DEFAULT_LIFETIME).
+	//
+	// The least recently used other entries are removed if the cache grows
+	// larger than its maximum size.
+	Set(filename string, fileContent string, lifetime time.Duration) error
 
-	var c Cache
-	err = json.Unmarshal(cacheFileBytes, &c)
-	if err != nil {
-		return nil, err
-	}
-	c.Dirpath = cacheDirpath
+	// Invalidate marks the given file's entry as invalid from now, keeping its contents.
+	// Returns an error if the file is not in the cache.
+	Invalidate(file string) error
 
-	return &c, nil
-}
+	// Delete removes the given file from the cache.
+	// Returns an error if the file is not in the cache.
+	Delete(file string) error
 
-// ParseIfExistsElseNew [arses and returns a cache if one exists at the given cacheDirpath.
-// Creates and returns a new cache otherwise.
-//
-// Returns an error if an error occurs during the checking or creation process.
-func ParseIfExistsElseNew(cacheDirpath string) (*Cache, error) {
-	cacheFilepath, err := getCacheFileFilepath(cacheDirpath)
-	if err != nil {
-		return nil, utils.PrependToError(err, "failed to generate cache filepath")
-	}
+	// List returns a copy of every entry in the cache, keyed by filename.
+	List() (map[string]CacheEntry, error)
 
-	exists, err := utils.FileExists(cacheFilepath)
-	if err != nil {
-		return nil, err
-	}
+	// Clear removes every entry from the cache.
+	Clear() error
 
-	if !exists {
-		return New(cacheDirpath)
-	}
-	return ParseCache(cacheDirpath)
-}
+	// Flush writes any state held in memory, such as when entries were last
+	// read, to the cache's storage.
+	Flush() error
 
-// Contains returns true if the given file is in the cache,
-// returning false otherwise.
-func (cache Cache) Contains(file string) bool {
-	_, exists := cache.Entries[file]
-	return exists
+	// Close flushes the cache and releases any resources held by it.
+	Close() error
 }
 
-// GetEntry returns the CacheEntry for the file if it's in the cache.
-// Returns an error otherwise.
-func (cache Cache) GetEntry(file string) (*CacheEntry, error) {
-	if !cache.Contains(file) {
-		return nil, fmt.Errorf("entry does not exist: %s", file)
-	}
-	entry := cache.Entries[file]
-	return &entry, nil
+// Options describe how a Cache stores its entries.
+type Options struct {
+	Backend         Backend
+	Compression     Compression   // Applied to entries when they are set
+	DefaultLifetime time.Duration // Used when entries are set with DEFAULT_LIFETIME
+	MaxSizeBytes    int64         // The maximum total size of stored entries (0 for no limit)
 }
 
-// IsValid returns true if the given file is in the cache and is valid.
-// Returns false otherwise.
-func (cache Cache) IsValid(file string) bool {
-	if !cache.Contains(file) {
-		return false
-	}
-	entry, err := cache.GetEntry(file)
-	if err != nil {
-		return false
-	}
-	return entry.InvalidationDate.After(time.Now())
+// A CacheEntry is a representation of a single cached file.
+type CacheEntry struct {
+	SetDate          time.Time   `json:"setAt"`
+	InvalidationDate time.Time   `json:"invalidFrom"`
+	AccessDate       time.Time   `json:"accessedAt,omitempty"` // When the file was last read
+	Filename         string      `json:"file"`
+	SizeBytes        int64       `json:"size,omitempty"`        // The size of the file as stored
+	Compression      Compression `json:"compression,omitempty"` // How the file is stored
+	Checksum         string      `json:"checksum,omitempty"`    // SHA-256 of the file as stored
 }
 
-// Get returns the cached file's contents if the given file is in the cache and is valid.
-// Returns an error otherwise or if an error occurs during the file reading process.
-func (cache Cache) Get(file string) (string, error) {
-	if !cache.IsValid(file) {
-		return "", errors.New("cache entry is invalid")
+// Validate returns an error if the Backend is not supported.
+// An empty Backend is valid, and is treated as FileBackend.
+func (b Backend) Validate() error {
+	switch b {
+	case "", FileBackend, BoltBackend:
+		return nil
+	default:
+		return fmt.Errorf("unknown cache backend: %s", b)
 	}
-	contents, err := cache.GetIgnoringValidity(file)
-	if err != nil {
-		return "", err
-	}
-	return contents, nil
 }
 
-// GetIgnoringValidity returns the cached file's contents if the given file
-// is in the cache, regardless of its validity.
-// Returns an error otherwise or if an error occurs during the file reading process.
-func (cache Cache) GetIgnoringValidity(file string) (string, error) {
-	if !cache.Contains(file) {
-		return "", fmt.Errorf("cache does not contain file: %s", file)
-	}
-
-	path, err := cache.getFileFilepath(file)
+// Validate returns an error if the Options are not supported.
+func (o *Options) Validate() error {
+	err := o.Backend.Validate()
 	if err != nil {
-		return "", err
+		return err
 	}
-
-	return utils.FileToString(path)
-}
-
-// Set writes fileContent to a file named filename in the cache directory and creates a
-// representative entry in the cache, which is valid for a duration of lifetime.
-// Returns an error if the file could not be written or value could not be set.
-func (cache Cache) Set(filename string, fileContent string, lifetime time.Duration) error {
-	path, err := utils.CreateFilepath(cache.Dirpath, filename)
+	err = o.Compression.Validate()
 	if err != nil {
 		return err
 	}
+	if o.DefaultLifetime < 0 {
+		return fmt.Errorf("default lifetime cannot be negative")
+	}
+	if o.MaxSizeBytes < 0 {
+		return fmt.Errorf("maximum size cannot be negative")
+	}
+	return nil
+}
 
-	err = utils.WriteStringToFile(fileContent, path)
+// Open opens the cache stored in cacheDirpath as described by options,
+// creating it if it does not exist. All entries are removed when clear is true.
+//
+// When opening a BoltBackend cache in a directory holding a FileBackend
+// cache, the existing entries are migrated into the new cache.
+//
+// Returns an error if the cache could not be opened, created or migrated.
+func Open(cacheDirpath string, options Options, clear bool) (Cache, error) {
+	err := options.Validate()
 	if err != nil {
-		return utils.PrependToError(err, "failed to write cache entry to file")
+		return nil, err
 	}
 
-	oldEntry, oldEntryErr := cache.GetEntry(filename)
-
-	now := time.Now()
-	invalid := now.Add(lifetime)
+	switch options.Backend {
+	case "", FileBackend:
+		var c *FileCache
+		if clear {
+			c, err = New(cacheDirpath)
+		} else {
+			c, err = ParseIfExistsElseNew(cacheDirpath)
+		}
+		if err != nil {
+			return nil, err
+		}
+		c.options = options
+		return c, nil
 
-	cache.Entries[filename] = CacheEntry{
-		SetDate:          now,
-		InvalidationDate: invalid,
-		Filename:         filename,
-	}
+	default:
+		c, err := OpenBolt(cacheDirpath, options)
+		if err != nil {
+			return nil, err
+		}
 
-	err = cache.writeToFile()
-	if err != nil {
-		if oldEntryErr == nil {
-			cache.Entries[filename] = *oldEntry
+		if clear {
+			err = c.Clear()
+			if err != nil {
+				c.Close()
+				return nil, err
+			}
 		}
-		return utils.PrependToError(
-			err,
-			"failed to write cache to disk. WARNING: file data may be inconsistent with in-memory cache",
-		)
+		return c, nil
 	}
-
-	return nil
 }
 
-func (cache Cache) getFileFilepath(file string) (string, error) {
-	return utils.CreateFilepath(cache.Dirpath, file)
+// LastUsed returns when the entry's file was last read, or when it was set
+// if it has not been read since.
+func (e *CacheEntry) LastUsed() time.Time {
+	if e.AccessDate.After(e.SetDate) {
+		return e.AccessDate
+	}
+	return e.SetDate
 }
 
-func getCacheFileFilepath(cacheDirpath string) (string, error) {
-	return utils.CreateFilepath(cacheDirpath, CACHE_FILENAME)
+// newEntry returns a CacheEntry for a file stored under filename, set now
+// and valid for a duration of lifetime.
+func newEntry(filename string, stored []byte, compression Compression, lifetime time.Duration) CacheEntry {
+	now := time.Now()
+	return CacheEntry{
+		SetDate:          now,
+		InvalidationDate: now.Add(lifetime),
+		Filename:         filename,
+		SizeBytes:        int64(len(stored)),
+		Compression:      compression,
+		Checksum:         checksum(stored),
+	}
 }
 
-func (cache Cache) getCacheFileFilepath() (string, error) {
-	return getCacheFileFilepath(cache.Dirpath)
+// encode returns fileContent as it should be stored, along with the entry
+// which should be stored with it.
+func (o *Options) encode(filename string, fileContent string, lifetime time.Duration) ([]byte, CacheEntry, error) {
+	if lifetime == DEFAULT_LIFETIME {
+		lifetime = o.DefaultLifetime
+	}
+
+	stored, err := compress([]byte(fileContent), o.Compression)
+	if err != nil {
+		return nil, CacheEntry{}, err
+	}
+	return stored, newEntry(filename, stored, o.Compression, lifetime), nil
 }
 
-func (cache Cache) writeToFile() error {
-	cacheFilepath, err := cache.getCacheFileFilepath()
+// decode returns the contents of a file stored for the entry, checking them
+// against the entry's checksum.
+func (e *CacheEntry) decode(stored []byte) (string, error) {
+	err := e.verify(stored)
 	if err != nil {
-		return utils.PrependToError(err, "failed to generate cache filepath")
+		return "", err
 	}
 
-	cacheAsJsonBytes, err := json.Marshal(cache)
+	contents, err := decompress(stored, e.Compression)
 	if err != nil {
-		return err
+		return "", err
 	}
+	return string(contents), nil
+}
+
+// verify returns an error if stored does not match the entry's checksum.
+// Entries without a checksum, written before checksums were recorded, are
+// always verified.
+func (e *CacheEntry) verify(stored []byte) error {
+	if e.Checksum == "" || e.Checksum == checksum(stored) {
+		return nil
+	}
+	return fmt.Errorf("checksum of cached file %s does not match its entry", e.Filename)
+}
 
-	err = utils.WriteBytesToFile(cacheAsJsonBytes, cacheFilepath)
-	return err
+func checksum(stored []byte) string {
+	sum := sha256.Sum256(stored)
+	return hex.EncodeToString(sum[:])
 }
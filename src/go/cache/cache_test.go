@@ -2,7 +2,12 @@ package cache
 
 import (
 	"aws-blended-instances-advisor/utils"
+	"bytes"
 	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
 	"testing"
 	"time"
 )
@@ -69,6 +74,15 @@ func cleanup() error {
 			return utils.PrependToError(err, "failed to delete cache file")
 		}
 	}
+
+	lockFileFilepath, err := utils.CreateFilepath(TEST_CACHE_DIRPATH, LOCK_FILENAME)
+	if err != nil {
+		return utils.PrependToError(err, "could not create lock file's path")
+	}
+	err = os.Remove(lockFileFilepath)
+	if err != nil && !os.IsNotExist(err) {
+		return utils.PrependToError(err, "failed to delete lock file")
+	}
 	return nil
 }
 
@@ -374,3 +388,179 @@ func TestParse(t *testing.T) {
 		t.Fatalf("Cleanup failed: %s", err.Error())
 	}
 }
+
+func TestGetValidatesChecksum(t *testing.T) {
+	dirpath := t.TempDir()
+
+	cache, err := New(dirpath)
+	if err != nil {
+		t.Fatalf("Error creating cache: %s", err.Error())
+	}
+	err = cache.Set(TEST_FILE_FILENAME, TEST_FILE_CONTENT, time.Hour)
+	if err != nil {
+		t.Fatalf("Error when setting value in cache: %s", err.Error())
+	}
+
+	err = utils.WriteStringToFile("CORRUPTED", filepath.Join(dirpath, TEST_FILE_FILENAME))
+	if err != nil {
+		t.Fatalf("Failed to corrupt cached file: %s", err.Error())
+	}
+
+	_, err = cache.Get(TEST_FILE_FILENAME)
+	if err == nil {
+		t.Fatalf("Error not returned when getting entry whose file does not match its checksum")
+	}
+}
+
+func TestParseRecoversInterruptedWrites(t *testing.T) {
+	dirpath := t.TempDir()
+
+	cache, err := New(dirpath)
+	if err != nil {
+		t.Fatalf("Error creating cache: %s", err.Error())
+	}
+	for _, file := range []string{"kept.txt", "replaced.txt", "missing.txt"} {
+		err = cache.Set(file, TEST_FILE_CONTENT, time.Hour)
+		if err != nil {
+			t.Fatalf("Error when setting value in cache: %s", err.Error())
+		}
+	}
+
+	// Simulate crashes: before a temp file was renamed, after a file was
+	// replaced but before the index was written, and a file lost entirely
+	tempFilepath := filepath.Join(dirpath, ".kept.txt.tmp-123")
+	err = utils.WriteStringToFile("PARTIAL", tempFilepath)
+	if err != nil {
+		t.Fatalf("Failed to write temp file: %s", err.Error())
+	}
+	err = utils.WriteStringToFile("NEW CONTENT", filepath.Join(dirpath, "replaced.txt"))
+	if err != nil {
+		t.Fatalf("Failed to replace file: %s", err.Error())
+	}
+	err = os.Remove(filepath.Join(dirpath, "missing.txt"))
+	if err != nil {
+		t.Fatalf("Failed to remove file: %s", err.Error())
+	}
+
+	cache, err = ParseCache(dirpath)
+	if err != nil {
+		t.Fatalf("Error when parsing cache: %s", err.Error())
+	}
+
+	exists, _ := utils.FileExists(tempFilepath)
+	if exists {
+		t.Fatalf("Temp file not removed when parsing cache")
+	}
+	if !cache.IsValid("kept.txt") {
+		t.Fatalf("Intact entry not valid after parsing cache")
+	}
+	if cache.Contains("missing.txt") {
+		t.Fatalf("Entry with missing file not removed when parsing cache")
+	}
+	if cache.IsValid("replaced.txt") {
+		t.Fatalf("Entry with replaced file still valid after parsing cache")
+	}
+	content, err := cache.GetIgnoringValidity("replaced.txt")
+	if err != nil || content != "NEW CONTENT" {
+		t.Fatalf("Wanted replaced content NEW CONTENT, got: %s (error: %v)", content, err)
+	}
+}
+
+func TestConcurrentSet(t *testing.T) {
+	dirpath := t.TempDir()
+
+	cache, err := New(dirpath)
+	if err != nil {
+		t.Fatalf("Error creating cache: %s", err.Error())
+	}
+	other, err := ParseCache(dirpath) // As if used by another process
+	if err != nil {
+		t.Fatalf("Error parsing cache: %s", err.Error())
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 20)
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			errs <- cache.Set(fmt.Sprintf("file-%d.txt", i), TEST_FILE_CONTENT, time.Hour)
+		}(i)
+		go func(i int) {
+			defer wg.Done()
+			errs <- other.Set(fmt.Sprintf("other-%d.txt", i), TEST_FILE_CONTENT, time.Hour)
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("Concurrent set failed: %s", err.Error())
+		}
+	}
+
+	parsed, err := ParseCache(dirpath)
+	if err != nil {
+		t.Fatalf("Error parsing cache: %s", err.Error())
+	}
+	entries, _ := parsed.List()
+	if len(entries) != 20 {
+		t.Fatalf("Wanted 20 entries after concurrent sets, got: %d", len(entries))
+	}
+}
+
+func TestConcurrentGetDoesNotWrite(t *testing.T) {
+	dirpath := t.TempDir()
+
+	cache, err := New(dirpath)
+	if err != nil {
+		t.Fatalf("Error creating cache: %s", err.Error())
+	}
+	err = cache.Set(TEST_FILE_FILENAME, TEST_FILE_CONTENT, time.Hour)
+	if err != nil {
+		t.Fatalf("Error setting entry: %s", err.Error())
+	}
+	indexFilepath, _ := cache.getCacheFileFilepath()
+	index, _ := utils.FileToBytes(indexFilepath)
+
+	// Gets which write the index would wait for the read lock to be released
+	cache.mu.RLock()
+	done := make(chan error)
+	for i := 0; i < 10; i++ {
+		go func() {
+			_, err := cache.Get(TEST_FILE_FILENAME)
+			done <- err
+		}()
+	}
+	for i := 0; i < 10; i++ {
+		select {
+		case err := <-done:
+			if err != nil {
+				t.Fatalf("Concurrent get failed: %s", err.Error())
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("Concurrent get blocked on the cache's write lock")
+		}
+	}
+	cache.mu.RUnlock()
+
+	if written, _ := utils.FileToBytes(indexFilepath); !bytes.Equal(written, index) {
+		t.Fatalf("Index written by get")
+	}
+	if entry, _ := cache.GetEntry(TEST_FILE_FILENAME); entry.AccessDate.IsZero() {
+		t.Fatalf("Access not recorded by get")
+	}
+
+	err = cache.Close()
+	if err != nil {
+		t.Fatalf("Error closing cache: %s", err.Error())
+	}
+	parsed, err := ParseCache(dirpath)
+	if err != nil {
+		t.Fatalf("Error parsing cache: %s", err.Error())
+	}
+	if entry, _ := parsed.GetEntry(TEST_FILE_FILENAME); entry.AccessDate.IsZero() {
+		t.Fatalf("Access not written when cache closed")
+	}
+}
@@ -0,0 +1,107 @@
+package cache
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// A Compression is a way of compressing cached files.
+type Compression string
+
+const (
+	NoCompression   Compression = "none"
+	GzipCompression Compression = "gzip"
+	ZstdCompression Compression = "zstd"
+)
+
+// Validate returns an error if the Compression is not supported.
+// An empty Compression is valid, and is treated as NoCompression.
+func (c Compression) Validate() error {
+	switch c {
+	case "", NoCompression, GzipCompression, ZstdCompression:
+		return nil
+	default:
+		return fmt.Errorf("unknown cache compression: %s", c)
+	}
+}
+
+var (
+	gzipMagic = []byte{0x1f, 0x8b}
+	zstdMagic = []byte{0x28, 0xb5, 0x2f, 0xfd}
+)
+
+// detectCompression returns the Compression used for data, based on the
+// magic number it begins with.
+func detectCompression(data []byte) Compression {
+	switch {
+	case bytes.HasPrefix(data, gzipMagic):
+		return GzipCompression
+	case bytes.HasPrefix(data, zstdMagic):
+		return ZstdCompression
+	default:
+		return NoCompression
+	}
+}
+
+// compress returns data compressed using the given Compression.
+func compress(data []byte, compression Compression) ([]byte, error) {
+	switch compression {
+	case "", NoCompression:
+		return data, nil
+
+	case GzipCompression:
+		var buf bytes.Buffer
+		writer := gzip.NewWriter(&buf)
+		_, err := writer.Write(data)
+		if err != nil {
+			return nil, err
+		}
+		err = writer.Close()
+		if err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+
+	case ZstdCompression:
+		encoder, err := zstd.NewWriter(nil)
+		if err != nil {
+			return nil, err
+		}
+		defer encoder.Close()
+		return encoder.EncodeAll(data, nil), nil
+
+	default:
+		return nil, compression.Validate()
+	}
+}
+
+// decompress returns data, compressed using the given Compression, decompressed.
+func decompress(data []byte, compression Compression) ([]byte, error) {
+	switch compression {
+	case "", NoCompression:
+		return data, nil
+
+	case GzipCompression:
+		reader, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+		defer reader.Close()
+		return io.ReadAll(reader)
+
+	case ZstdCompression:
+		decoder, err := zstd.NewReader(nil)
+		if err != nil {
+			return nil, err
+		}
+		defer decoder.Close()
+		return decoder.DecodeAll(data, nil)
+
+	default:
+		return nil, compression.Validate()
+	}
+}
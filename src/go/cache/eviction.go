@@ -0,0 +1,128 @@
+package cache
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// EvictInvalid removes every entry from the cache which has been invalid for
+// longer than grace, returning the files removed.
+//
+// Invalid entries are kept for a grace period as their contents can still be
+// used, such as when fresh contents cannot be fetched.
+func EvictInvalid(c Cache, grace time.Duration) ([]string, error) {
+	entries, err := c.List()
+	if err != nil {
+		return nil, err
+	}
+
+	cutoff := time.Now().Add(-grace)
+	evicted := []string{}
+	for file, entry := range entries {
+		if entry.InvalidationDate.After(cutoff) {
+			continue
+		}
+
+		err = c.Delete(file)
+		if err != nil {
+			return evicted, err
+		}
+		cacheEvictions.Inc("invalid")
+		evicted = append(evicted, file)
+	}
+
+	return evicted, nil
+}
+
+// EvictToSize removes the least recently used entries from the cache until
+// the total size of the entries is at most maxSizeBytes, returning the files
+// removed.
+func EvictToSize(c Cache, maxSizeBytes int64) ([]string, error) {
+	return evictToSize(c, maxSizeBytes, "")
+}
+
+// RunEviction flushes the cache, then removes invalid entries and entries over
+// the maximum size, every interval until ctx is done.
+func RunEviction(
+	ctx context.Context,
+	c Cache,
+	interval time.Duration,
+	grace time.Duration,
+	maxSizeBytes int64,
+	logger *zap.Logger,
+) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+
+		case <-ticker.C:
+			err := c.Flush()
+			if err != nil {
+				logger.Warn("failed to flush cache", zap.Error(err))
+			}
+
+			evicted, err := EvictInvalid(c, grace)
+			if err != nil {
+				logger.Warn("failed to evict invalid cache entries", zap.Error(err))
+			} else if len(evicted) > 0 {
+				logger.Info("evicted invalid cache entries", zap.Strings("files", evicted))
+			}
+
+			if maxSizeBytes == 0 {
+				continue
+			}
+			evicted, err = EvictToSize(c, maxSizeBytes)
+			if err != nil {
+				logger.Warn("failed to evict cache entries over maximum size", zap.Error(err))
+			} else if len(evicted) > 0 {
+				logger.Info("evicted cache entries over maximum size", zap.Strings("files", evicted))
+			}
+		}
+	}
+}
+
+// evictToSize removes the least recently used entries other than keep until
+// the total size of the entries is at most maxSizeBytes.
+func evictToSize(c Cache, maxSizeBytes int64, keep string) ([]string, error) {
+	entries, err := c.List()
+	if err != nil {
+		return nil, err
+	}
+
+	var totalSize int64
+	candidates := []CacheEntry{}
+	for file, entry := range entries {
+		totalSize += entry.SizeBytes
+		if file != keep {
+			candidates = append(candidates, entry)
+		}
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].LastUsed().Before(candidates[j].LastUsed())
+	})
+
+	evicted := []string{}
+	for _, entry := range candidates {
+		if totalSize <= maxSizeBytes {
+			break
+		}
+
+		err = c.Delete(entry.Filename)
+		if err != nil {
+			return evicted, err
+		}
+		cacheEvictions.Inc("size")
+		evicted = append(evicted, entry.Filename)
+		totalSize -= entry.SizeBytes
+	}
+
+	return evicted, nil
+}
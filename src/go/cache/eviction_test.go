@@ -0,0 +1,118 @@
+package cache
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEvictInvalid(t *testing.T) {
+	for _, backend := range []Backend{FileBackend, BoltBackend} {
+		c, err := Open(t.TempDir(), Options{Backend: backend}, false)
+		if err != nil {
+			t.Fatalf("Failed to open %s cache: %s", backend, err.Error())
+		}
+
+		c.Set("valid.txt", TEST_FILE_CONTENT, time.Hour)
+		c.Set("recently-invalid.txt", TEST_FILE_CONTENT, -time.Minute)
+		c.Set("long-invalid.txt", TEST_FILE_CONTENT, -2*time.Hour)
+
+		evicted, err := EvictInvalid(c, time.Hour)
+		if err != nil {
+			t.Fatalf("Failed to evict from %s cache: %s", backend, err.Error())
+		}
+		if len(evicted) != 1 || evicted[0] != "long-invalid.txt" {
+			t.Fatalf("Wanted only long-invalid.txt evicted from %s cache, got: %v", backend, evicted)
+		}
+		if !c.Contains("recently-invalid.txt") || !c.Contains("valid.txt") {
+			t.Fatalf("Entries within grace period evicted from %s cache", backend)
+		}
+
+		c.Close()
+	}
+}
+
+func TestEvictToSizeRemovesLeastRecentlyUsed(t *testing.T) {
+	for _, backend := range []Backend{FileBackend, BoltBackend} {
+		content := strings.Repeat("x", 100)
+		c, err := Open(t.TempDir(), Options{Backend: backend, MaxSizeBytes: 250}, false)
+		if err != nil {
+			t.Fatalf("Failed to open %s cache: %s", backend, err.Error())
+		}
+
+		for _, file := range []string{"first.txt", "second.txt"} {
+			err = c.Set(file, content, time.Hour)
+			if err != nil {
+				t.Fatalf("Failed to set entry in %s cache: %s", backend, err.Error())
+			}
+			time.Sleep(5 * time.Millisecond)
+		}
+
+		_, err = c.Get("first.txt") // Now used more recently than second.txt
+		if err != nil {
+			t.Fatalf("Failed to get entry from %s cache: %s", backend, err.Error())
+		}
+		time.Sleep(5 * time.Millisecond)
+
+		err = c.Set("third.txt", content, time.Hour)
+		if err != nil {
+			t.Fatalf("Failed to set entry in %s cache: %s", backend, err.Error())
+		}
+
+		if c.Contains("second.txt") {
+			t.Fatalf("Least recently used entry not evicted from %s cache", backend)
+		}
+		if !c.Contains("first.txt") || !c.Contains("third.txt") {
+			t.Fatalf("Recently used entries evicted from %s cache", backend)
+		}
+
+		c.Close()
+	}
+}
+
+func TestCompression(t *testing.T) {
+	content := strings.Repeat(TEST_FILE_CONTENT, 100)
+
+	for _, compression := range []Compression{NoCompression, GzipCompression, ZstdCompression} {
+		for _, backend := range []Backend{FileBackend, BoltBackend} {
+			c, err := Open(t.TempDir(), Options{Backend: backend, Compression: compression}, false)
+			if err != nil {
+				t.Fatalf("Failed to open %s cache: %s", backend, err.Error())
+			}
+
+			err = c.Set(TEST_FILE_FILENAME, content, time.Hour)
+			if err != nil {
+				t.Fatalf("Failed to set %s entry in %s cache: %s", compression, backend, err.Error())
+			}
+
+			got, err := c.Get(TEST_FILE_FILENAME)
+			if err != nil || got != content {
+				t.Fatalf("%s entry in %s cache not read back (error: %v)", compression, backend, err)
+			}
+
+			entry, _ := c.GetEntry(TEST_FILE_FILENAME)
+			if compression != NoCompression && entry.SizeBytes >= int64(len(content)) {
+				t.Fatalf("%s entry in %s cache not compressed: %d bytes", compression, backend, entry.SizeBytes)
+			}
+
+			c.Close()
+		}
+	}
+}
+
+func TestDefaultLifetime(t *testing.T) {
+	c, err := Open(t.TempDir(), Options{DefaultLifetime: time.Hour}, false)
+	if err != nil {
+		t.Fatalf("Failed to open cache: %s", err.Error())
+	}
+
+	err = c.Set(TEST_FILE_FILENAME, TEST_FILE_CONTENT, DEFAULT_LIFETIME)
+	if err != nil {
+		t.Fatalf("Failed to set entry: %s", err.Error())
+	}
+
+	entry, _ := c.GetEntry(TEST_FILE_FILENAME)
+	if lifetime := entry.InvalidationDate.Sub(entry.SetDate); lifetime != time.Hour {
+		t.Fatalf("Wanted default lifetime of 1h, got: %s", lifetime)
+	}
+}
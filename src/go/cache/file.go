@@ -0,0 +1,535 @@
+package cache
+
+import (
+	"aws-blended-instances-advisor/utils"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+const (
+	CACHE_FILENAME = "cache.json"
+	LOCK_FILENAME  = "cache.lock"
+
+	// Matches temporary files left behind by interrupted atomic writes
+	TEMP_FILE_PATTERN = ".*.tmp-*"
+)
+
+var _ Cache = &FileCache{}
+
+// A FileCache is a Cache which stores each entry as a separate file in a
+// directory, alongside a JSON index of the entries.
+//
+// Files and the index are replaced atomically, and each entry records a
+// checksum of its file which is validated when the file is read. A FileCache
+// is safe for concurrent use, and a lock file in its directory allows several
+// processes to share the same directory.
+type FileCache struct {
+	Dirpath string                `json:"-"`
+	Entries map[string]CacheEntry `json:"entries"`
+
+	mu       sync.RWMutex
+	options  Options
+	accessed accessTimes // Written to the index when it is next written
+}
+
+// New creates and returns a new cache.
+//
+// The given cacheDirpath is used as the directory to store cached files and cache metadata,
+// and is created if it does not exist. Previous caches using the same cacheDirpath are overwritten, and their files removed.
+//
+// Returns an error if an error occurred when writing to the given filepath.
+func New(cacheDirpath string) (*FileCache, error) {
+	err := os.MkdirAll(cacheDirpath, os.ModePerm)
+	if err != nil {
+		return nil, utils.PrependToError(err, "failed to create cache directory")
+	}
+
+	c := &FileCache{
+		Dirpath: cacheDirpath,
+		Entries: make(map[string]CacheEntry),
+	}
+
+	lock, err := c.lock(true)
+	if err != nil {
+		return nil, err
+	}
+	defer lock.unlock()
+
+	// Best effort, as a previous cache which cannot be read has no files to remove
+	if c.reload() == nil {
+		c.removeFiles()
+	}
+	c.Entries = make(map[string]CacheEntry)
+
+	err = c.writeToFile()
+	if err != nil {
+		return nil, utils.PrependToError(err, "failed to write to cache file")
+	}
+	return c, nil
+}
+
+// ParseCache parses and returns a cache created at the given cacheDirpath.
+//
+// Files left behind by writes which were interrupted, such as by a crash, are
+// recovered: temporary files are removed, entries whose files are missing are
+// dropped, and entries whose files do not match their checksum are kept with
+// the files' contents but marked invalid.
+//
+// Returns an error if no cache exists at cacheDirpath or if there was an issue reading files.
+func ParseCache(cacheDirpath string) (*FileCache, error) {
+	c := &FileCache{Dirpath: cacheDirpath}
+
+	lock, err := c.lock(true)
+	if err != nil {
+		return nil, err
+	}
+	defer lock.unlock()
+
+	err = c.reload()
+	if err != nil {
+		return nil, err
+	}
+
+	err = c.recover()
+	if err != nil {
+		return nil, utils.PrependToError(err, "failed to recover cache")
+	}
+
+	return c, nil
+}
+
+// ParseIfExistsElseNew parses and returns a cache if one exists at the given cacheDirpath.
+// Creates and returns a new cache otherwise.
+//
+// Returns an error if an error occurs during the checking or creation process.
+func ParseIfExistsElseNew(cacheDirpath string) (*FileCache, error) {
+	cacheFilepath, err := getCacheFileFilepath(cacheDirpath)
+	if err != nil {
+		return nil, utils.PrependToError(err, "failed to generate cache filepath")
+	}
+
+	exists, err := utils.FileExists(cacheFilepath)
+	if err != nil {
+		return nil, err
+	}
+
+	if !exists {
+		return New(cacheDirpath)
+	}
+	return ParseCache(cacheDirpath)
+}
+
+// Contains returns true if the given file is in the cache,
+// returning false otherwise.
+func (cache *FileCache) Contains(file string) bool {
+	cache.mu.RLock()
+	defer cache.mu.RUnlock()
+
+	_, exists := cache.Entries[file]
+	return exists
+}
+
+// GetEntry returns the CacheEntry for the file if it's in the cache.
+// Returns an error otherwise.
+func (cache *FileCache) GetEntry(file string) (*CacheEntry, error) {
+	cache.mu.RLock()
+	defer cache.mu.RUnlock()
+
+	entry, exists := cache.Entries[file]
+	if !exists {
+		return nil, fmt.Errorf("entry does not exist: %s", file)
+	}
+	cache.accessed.apply(file, &entry)
+	return &entry, nil
+}
+
+// IsValid returns true if the given file is in the cache and is valid.
+// Returns false otherwise.
+func (cache *FileCache) IsValid(file string) bool {
+	entry, err := cache.GetEntry(file)
+	if err != nil {
+		return false
+	}
+	return entry.InvalidationDate.After(time.Now())
+}
+
+// Get returns the cached file's contents if the given file is in the cache and is valid.
+// Returns an error otherwise or if an error occurs during the file reading process.
+func (cache *FileCache) Get(file string) (string, error) {
+	if !cache.IsValid(file) {
+		cacheMisses.Inc(file)
+		return "", errors.New("cache entry is invalid")
+	}
+	cacheHits.Inc(file)
+
+	contents, err := cache.GetIgnoringValidity(file)
+	if err != nil {
+		return "", err
+	}
+	return contents, nil
+}
+
+// GetIgnoringValidity returns the cached file's contents if the given file
+// is in the cache, regardless of its validity.
+//
+// If the file does not match its entry's checksum, the index is re-read in
+// case another process has replaced the file, before returning an error.
+func (cache *FileCache) GetIgnoringValidity(file string) (string, error) {
+	entry, stored, err := cache.readEntryFile(file)
+	if err == errChecksumMismatch {
+		err = cache.reloadShared()
+		if err != nil {
+			return "", err
+		}
+		entry, stored, err = cache.readEntryFile(file)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	contents, err := entry.decode(stored)
+	if err != nil {
+		return "", err
+	}
+
+	cache.accessed.record(file)
+	return contents, nil
+}
+
+// Set atomically writes fileContent to a file named filename in the cache directory and
+// creates a representative entry in the cache, which is valid for a duration of lifetime.
+//
+// Entries set by other processes are read before the index is written, so none are lost.
+// Returns an error if the file could not be written or value could not be set.
+func (cache *FileCache) Set(filename string, fileContent string, lifetime time.Duration) error {
+	path, err := cache.getFileFilepath(filename)
+	if err != nil {
+		return err
+	}
+
+	stored, entry, err := cache.options.encode(filename, fileContent, lifetime)
+	if err != nil {
+		return utils.PrependToError(err, "failed to compress cache entry")
+	}
+
+	err = cache.update(func() error {
+		err := utils.WriteBytesToFileAtomically(stored, path)
+		if err != nil {
+			return utils.PrependToError(err, "failed to write cache entry to file")
+		}
+		cache.Entries[filename] = entry
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if cache.options.MaxSizeBytes > 0 {
+		_, err = evictToSize(cache, cache.options.MaxSizeBytes, filename)
+		if err != nil {
+			return utils.PrependToError(err, "failed to evict entries over maximum size")
+		}
+	}
+	return nil
+}
+
+// Invalidate marks the given file's entry as invalid from now, keeping its file.
+// Returns an error if the file is not in the cache.
+func (cache *FileCache) Invalidate(file string) error {
+	return cache.update(func() error {
+		entry, exists := cache.Entries[file]
+		if !exists {
+			return fmt.Errorf("entry does not exist: %s", file)
+		}
+		entry.InvalidationDate = time.Now()
+		cache.Entries[file] = entry
+		return nil
+	})
+}
+
+// Delete removes the given file's entry from the cache, deleting its file.
+// Returns an error if the file is not in the cache.
+func (cache *FileCache) Delete(file string) error {
+	return cache.update(func() error {
+		_, exists := cache.Entries[file]
+		if !exists {
+			return fmt.Errorf("entry does not exist: %s", file)
+		}
+
+		path, err := cache.getFileFilepath(file)
+		if err != nil {
+			return err
+		}
+		err = os.Remove(path)
+		if err != nil && !os.IsNotExist(err) {
+			return utils.PrependToError(err, "failed to delete cache entry file")
+		}
+		delete(cache.Entries, file)
+		return nil
+	})
+}
+
+// List returns a copy of every entry in the cache, keyed by filename.
+func (cache *FileCache) List() (map[string]CacheEntry, error) {
+	cache.mu.RLock()
+	defer cache.mu.RUnlock()
+
+	entries := make(map[string]CacheEntry, len(cache.Entries))
+	for file, entry := range cache.Entries {
+		cache.accessed.apply(file, &entry)
+		entries[file] = entry
+	}
+	return entries, nil
+}
+
+// Clear removes every entry from the cache, deleting their files.
+// Returns an error if a file could not be deleted or the cache could not be written.
+func (cache *FileCache) Clear() error {
+	return cache.update(cache.removeFiles)
+}
+
+// Flush writes when entries were last read to the index, if any have been
+// read since the index was last written.
+func (cache *FileCache) Flush() error {
+	if cache.accessed.isEmpty() {
+		return nil
+	}
+	return cache.update(func() error { return nil })
+}
+
+// Close flushes the cache, as a FileCache holds no open resources.
+func (cache *FileCache) Close() error {
+	return cache.Flush()
+}
+
+var errChecksumMismatch = errors.New("cached file does not match its checksum")
+
+// readEntryFile reads the file of an entry as stored, checking it against the entry's checksum.
+func (cache *FileCache) readEntryFile(file string) (*CacheEntry, []byte, error) {
+	entry, err := cache.GetEntry(file)
+	if err != nil {
+		return nil, nil, fmt.Errorf("cache does not contain file: %s", file)
+	}
+
+	path, err := cache.getFileFilepath(file)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	lock, err := cache.lock(false)
+	if err != nil {
+		return nil, nil, err
+	}
+	stored, err := utils.FileToBytes(path)
+	lock.unlock()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if entry.verify(stored) != nil {
+		return nil, nil, errChecksumMismatch
+	}
+	return entry, stored, nil
+}
+
+// update applies change to the entries set by every process, then writes the index
+// along with when entries were last read. If change fails, or the index cannot be
+// written, the index is left unchanged.
+func (cache *FileCache) update(change func() error) error {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	lock, err := cache.lock(true)
+	if err != nil {
+		return err
+	}
+	defer lock.unlock()
+
+	err = cache.reload()
+	if err != nil {
+		return utils.PrependToError(err, "failed to read entries set by other processes")
+	}
+
+	accessed := cache.accessed.take()
+	for file, accessedAt := range accessed {
+		entry, exists := cache.Entries[file]
+		if exists && accessedAt.After(entry.AccessDate) {
+			entry.AccessDate = accessedAt
+			cache.Entries[file] = entry
+		}
+	}
+
+	err = change()
+	if err == nil {
+		err = cache.writeToFile()
+	}
+	if err != nil {
+		cache.accessed.restore(accessed)
+		cache.reload() // Best effort, to match the unchanged index
+		return err
+	}
+	return nil
+}
+
+// reload replaces the in-memory entries with those in the index file, if it exists.
+// The caller must hold the cache's lock file.
+func (cache *FileCache) reload() error {
+	cacheFilepath, err := cache.getCacheFileFilepath()
+	if err != nil {
+		return utils.PrependToError(err, "failed to generate cache filepath")
+	}
+
+	cacheFileBytes, err := utils.FileToBytes(cacheFilepath)
+	if err != nil {
+		return utils.PrependToError(
+			err,
+			fmt.Sprintf("failed to read cache file from filepath %s", cacheFilepath),
+		)
+	}
+
+	var index FileCache
+	err = json.Unmarshal(cacheFileBytes, &index)
+	if err != nil {
+		return err
+	}
+	if index.Entries == nil {
+		index.Entries = make(map[string]CacheEntry)
+	}
+
+	cache.Entries = index.Entries
+	return nil
+}
+
+// reloadShared reloads the in-memory entries while holding a shared lock file.
+func (cache *FileCache) reloadShared() error {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	lock, err := cache.lock(false)
+	if err != nil {
+		return err
+	}
+	defer lock.unlock()
+
+	return cache.reload()
+}
+
+// recover repairs the cache after interrupted writes, writing the index if
+// any entries changed. The caller must hold the cache's lock file exclusively.
+func (cache *FileCache) recover() error {
+	tempFilepaths, err := filepath.Glob(filepath.Join(cache.Dirpath, TEMP_FILE_PATTERN))
+	if err != nil {
+		return err
+	}
+	for _, path := range tempFilepaths {
+		err = os.Remove(path)
+		if err != nil {
+			return utils.PrependToError(err, "failed to remove temporary file")
+		}
+	}
+
+	changed := false
+	for file, entry := range cache.Entries {
+		path, err := cache.getFileFilepath(file)
+		if err != nil {
+			return err
+		}
+
+		info, err := os.Stat(path)
+		if os.IsNotExist(err) {
+			delete(cache.Entries, file)
+			changed = true
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		contents, err := utils.FileToBytes(path)
+		if err != nil {
+			return err
+		}
+
+		if entry.Checksum == "" {
+			entry.Checksum = checksum(contents)
+			entry.SizeBytes = int64(len(contents))
+			cache.Entries[file] = entry
+			changed = true
+		} else if entry.verify(contents) != nil {
+			// The file was replaced but its entry was not, so when it is valid until is unknown
+			entry.Checksum = checksum(contents)
+			entry.SizeBytes = int64(len(contents))
+			entry.Compression = detectCompression(contents)
+			entry.SetDate = info.ModTime()
+			entry.InvalidationDate = info.ModTime()
+			cache.Entries[file] = entry
+			changed = true
+		}
+	}
+
+	if !changed {
+		return nil
+	}
+	return cache.writeToFile()
+}
+
+// removeFiles deletes the files of every entry, removing the entries.
+func (cache *FileCache) removeFiles() error {
+	for file := range cache.Entries {
+		path, err := cache.getFileFilepath(file)
+		if err != nil {
+			return err
+		}
+		err = os.Remove(path)
+		if err != nil && !os.IsNotExist(err) {
+			return utils.PrependToError(err, "failed to delete cache entry file")
+		}
+		delete(cache.Entries, file)
+	}
+	return nil
+}
+
+// lock acquires the cache's lock file, exclusively if exclusive is true.
+func (cache *FileCache) lock(exclusive bool) (*fileLock, error) {
+	path, err := utils.CreateFilepath(cache.Dirpath, LOCK_FILENAME)
+	if err != nil {
+		return nil, err
+	}
+
+	lock, err := lockFile(path, exclusive)
+	if err != nil {
+		return nil, utils.PrependToError(err, "failed to lock cache")
+	}
+	return lock, nil
+}
+
+func (cache *FileCache) getFileFilepath(file string) (string, error) {
+	return utils.CreateFilepath(cache.Dirpath, file)
+}
+
+func getCacheFileFilepath(cacheDirpath string) (string, error) {
+	return utils.CreateFilepath(cacheDirpath, CACHE_FILENAME)
+}
+
+func (cache *FileCache) getCacheFileFilepath() (string, error) {
+	return getCacheFileFilepath(cache.Dirpath)
+}
+
+func (cache *FileCache) writeToFile() error {
+	cacheFilepath, err := cache.getCacheFileFilepath()
+	if err != nil {
+		return utils.PrependToError(err, "failed to generate cache filepath")
+	}
+
+	cacheAsJsonBytes, err := json.Marshal(cache)
+	if err != nil {
+		return err
+	}
+
+	return utils.WriteBytesToFileAtomically(cacheAsJsonBytes, cacheFilepath)
+}
@@ -0,0 +1,46 @@
+//go:build !windows
+// +build !windows
+
+package cache
+
+import (
+	"os"
+	"syscall"
+)
+
+// A fileLock is an advisory lock on a file, shared between processes.
+type fileLock struct {
+	file *os.File
+}
+
+// lockFile acquires a lock on the file at path, creating the file if it does
+// not exist. The lock is exclusive if exclusive is true, and shared otherwise.
+// Blocks until the lock is acquired.
+func lockFile(path string, exclusive bool) (*fileLock, error) {
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, err
+	}
+
+	how := syscall.LOCK_SH
+	if exclusive {
+		how = syscall.LOCK_EX
+	}
+
+	err = syscall.Flock(int(file.Fd()), how)
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	return &fileLock{file: file}, nil
+}
+
+// unlock releases the lock.
+func (l *fileLock) unlock() error {
+	err := syscall.Flock(int(l.file.Fd()), syscall.LOCK_UN)
+	closeErr := l.file.Close()
+	if err != nil {
+		return err
+	}
+	return closeErr
+}
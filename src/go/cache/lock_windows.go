@@ -0,0 +1,30 @@
+//go:build windows
+// +build windows
+
+package cache
+
+import (
+	"os"
+)
+
+// A fileLock is an advisory lock on a file, shared between processes.
+//
+// Locks are not supported on Windows, so a fileLock only holds the file open
+// and FileCaches are only safe for use by one process at a time.
+type fileLock struct {
+	file *os.File
+}
+
+// lockFile opens the file at path, creating it if it does not exist.
+func lockFile(path string, exclusive bool) (*fileLock, error) {
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, err
+	}
+	return &fileLock{file: file}, nil
+}
+
+// unlock closes the file.
+func (l *fileLock) unlock() error {
+	return l.file.Close()
+}
@@ -0,0 +1,23 @@
+package cache
+
+import (
+	"aws-blended-instances-advisor/metrics"
+)
+
+var (
+	cacheHits = metrics.NewCounterVec(
+		"cache_hits_total",
+		"Number of cache lookups which found a valid entry.",
+		"file",
+	)
+	cacheMisses = metrics.NewCounterVec(
+		"cache_misses_total",
+		"Number of cache lookups which found no valid entry.",
+		"file",
+	)
+	cacheEvictions = metrics.NewCounterVec(
+		"cache_evictions_total",
+		"Number of entries removed from the cache.",
+		"reason",
+	)
+)
@@ -0,0 +1,233 @@
+package catalogue
+
+import (
+	instPkg "aws-blended-instances-advisor/instances"
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+var (
+	ErrNotLoaded = errors.New("instance catalogue has not been loaded")
+	ErrTooStale  = errors.New("instance catalogue is too stale to be used")
+	ErrNoHistory = errors.New("historical snapshots of instances are not enabled")
+)
+
+// A Loader fetches fresh instance information, returning it along with when
+// it was fetched and when it stops being valid.
+type Loader func() (info *instPkg.GlobalInfo, fetchedAt time.Time, validUntil time.Time, err error)
+
+// A History provides instance information as it was at a past time.
+type History interface {
+	// Load returns the most recent GlobalInfo fetched at or before asOf,
+	// along with when it was fetched.
+	Load(asOf time.Time) (*instPkg.GlobalInfo, time.Time, error)
+}
+
+// A Catalogue holds the GlobalInfo used to generate advice, allowing it to be
+// replaced safely while requests are being served.
+//
+// Callers should take a snapshot with Get and use it for the duration of a
+// request, so that all advice in a request is generated from the same data.
+//
+// Once its GlobalInfo is no longer valid, a Catalogue continues to serve it
+// as stale until it is replaced, or until it has been stale for longer than
+// the Catalogue's maximum staleness.
+type Catalogue struct {
+	mu           sync.RWMutex
+	info         *instPkg.GlobalInfo
+	loadedAt     time.Time
+	fetchedAt    time.Time
+	validUntil   time.Time     // Zero if the GlobalInfo never becomes stale
+	maxStaleness time.Duration // 0 to serve stale GlobalInfo indefinitely
+	lastError    error
+	lastErrorAt  time.Time
+	history      History
+}
+
+// Status describes the state of a Catalogue.
+type Status struct {
+	Ready       bool
+	Stale       bool
+	LoadedAt    time.Time
+	FetchedAt   time.Time
+	ValidUntil  time.Time
+	LastError   error
+	LastErrorAt time.Time
+}
+
+// New creates an empty Catalogue.
+func New() *Catalogue {
+	return &Catalogue{}
+}
+
+// Set replaces the GlobalInfo held by the Catalogue, recording the time at
+// which the GlobalInfo's data was fetched from AWS. The GlobalInfo never
+// becomes stale.
+func (c *Catalogue) Set(info *instPkg.GlobalInfo, fetchedAt time.Time) {
+	c.SetWithValidity(info, fetchedAt, time.Time{})
+}
+
+// SetWithValidity replaces the GlobalInfo held by the Catalogue, recording the
+// time at which the GlobalInfo's data was fetched from AWS and the time from
+// which it is stale. Any error recorded by SetError is cleared, as the
+// Catalogue has recovered from it.
+func (c *Catalogue) SetWithValidity(info *instPkg.GlobalInfo, fetchedAt time.Time, validUntil time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.info = info
+	c.loadedAt = time.Now()
+	c.fetchedAt = fetchedAt
+	c.validUntil = validUntil
+	c.lastError = nil
+	c.lastErrorAt = time.Time{}
+}
+
+// SetMaxStaleness sets how long stale GlobalInfo is served for before
+// requests for it are rejected (0 to serve it indefinitely).
+func (c *Catalogue) SetMaxStaleness(maxStaleness time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.maxStaleness = maxStaleness
+}
+
+// SetError records an error encountered while fetching a GlobalInfo
+// for the Catalogue, which is reported until a GlobalInfo is next set.
+func (c *Catalogue) SetError(err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.lastError = err
+	c.lastErrorAt = time.Now()
+}
+
+// Get returns the GlobalInfo currently held by the Catalogue, which may be stale.
+//
+// Returns ErrNotLoaded if no GlobalInfo has been loaded, or ErrTooStale if
+// the GlobalInfo has been stale for longer than the maximum staleness.
+func (c *Catalogue) Get() (*instPkg.GlobalInfo, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if c.info == nil {
+		return nil, ErrNotLoaded
+	}
+	if c.isTooStale(time.Now()) {
+		return nil, ErrTooStale
+	}
+	return c.info, nil
+}
+
+// SetHistory sets the History used to answer requests for past instances.
+func (c *Catalogue) SetHistory(history History) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.history = history
+}
+
+// GetAsOf returns the GlobalInfo as it was at asOf, or the GlobalInfo
+// currently held by the Catalogue when asOf is nil.
+//
+// Returns ErrNoHistory if asOf is given but the Catalogue has no History,
+// or the History's error if it cannot provide instances at asOf.
+func (c *Catalogue) GetAsOf(asOf *time.Time) (*instPkg.GlobalInfo, error) {
+	if asOf == nil {
+		return c.Get()
+	}
+
+	c.mu.RLock()
+	history := c.history
+	c.mu.RUnlock()
+
+	if history == nil {
+		return nil, ErrNoHistory
+	}
+
+	info, _, err := history.Load(*asOf)
+	return info, err
+}
+
+// IsReady returns true if the Catalogue holds a GlobalInfo which can be
+// used to generate advice, and false otherwise.
+func (c *Catalogue) IsReady() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.info != nil && !c.isTooStale(time.Now())
+}
+
+// IsStale returns true if the Catalogue holds a GlobalInfo which is no longer
+// valid, and false otherwise.
+func (c *Catalogue) IsStale() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.info != nil && c.isStale(time.Now())
+}
+
+// Revalidate loads a GlobalInfo using load whenever the Catalogue holds no
+// GlobalInfo or a stale one, checking immediately and then every interval
+// until ctx is done. Stale GlobalInfo continues to be served while loading,
+// and if loading fails.
+func (c *Catalogue) Revalidate(ctx context.Context, load Loader, interval time.Duration, logger *zap.Logger) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		c.mu.RLock()
+		needsLoad := c.info == nil || c.isStale(time.Now())
+		c.mu.RUnlock()
+
+		if needsLoad {
+			info, fetchedAt, validUntil, err := load()
+			if err != nil {
+				logger.Warn("failed to revalidate instance catalogue", zap.Error(err))
+				c.SetError(err)
+			} else {
+				c.SetWithValidity(info, fetchedAt, validUntil)
+				logger.Info(
+					"instance catalogue revalidated",
+					zap.Time("fetchedAt", fetchedAt),
+					zap.Time("validUntil", validUntil),
+				)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Status returns the current Status of the Catalogue.
+func (c *Catalogue) Status() Status {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	now := time.Now()
+	return Status{
+		Ready:       c.info != nil && !c.isTooStale(now),
+		Stale:       c.info != nil && c.isStale(now),
+		LoadedAt:    c.loadedAt,
+		FetchedAt:   c.fetchedAt,
+		ValidUntil:  c.validUntil,
+		LastError:   c.lastError,
+		LastErrorAt: c.lastErrorAt,
+	}
+}
+
+func (c *Catalogue) isStale(now time.Time) bool {
+	return !c.validUntil.IsZero() && !now.Before(c.validUntil)
+}
+
+func (c *Catalogue) isTooStale(now time.Time) bool {
+	return c.maxStaleness > 0 && c.isStale(now) && now.Sub(c.validUntil) > c.maxStaleness
+}
@@ -0,0 +1,160 @@
+package catalogue
+
+import (
+	instPkg "aws-blended-instances-advisor/instances"
+	"aws-blended-instances-advisor/utils"
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestGetBeforeSet(t *testing.T) {
+	c := New()
+
+	if c.IsReady() {
+		t.Fatalf("Empty catalogue should not be ready")
+	}
+	_, err := c.Get()
+	if err == nil {
+		t.Fatalf("Expected error when getting from empty catalogue")
+	}
+}
+
+func TestSetAndGet(t *testing.T) {
+	c := New()
+	info := &instPkg.GlobalInfo{}
+	fetchedAt := time.Date(2000, 1, 1, 13, 0, 0, 0, time.UTC)
+
+	c.SetError(errors.New("TEST ERROR"))
+	if status := c.Status(); status.LastError == nil || status.LastError.Error() != "TEST ERROR" || status.LastErrorAt.IsZero() {
+		t.Fatalf("Wrong last error before set: %v", status.LastError)
+	}
+	c.Set(info, fetchedAt)
+
+	got, err := c.Get()
+	if err != nil {
+		t.Fatalf("Failed to get from catalogue: %s", err.Error())
+	}
+	if got != info {
+		t.Fatalf("Wrong info returned. Wanted: %p, got: %p", info, got)
+	}
+
+	status := c.Status()
+	if !status.Ready {
+		t.Fatalf("Catalogue should be ready after set")
+	}
+	if !status.FetchedAt.Equal(fetchedAt) {
+		t.Fatalf("Wrong fetch time. Wanted: %s, got: %s", fetchedAt, status.FetchedAt)
+	}
+	if status.LastError != nil || !status.LastErrorAt.IsZero() {
+		t.Fatalf("Last error not cleared after set: %v", status.LastError)
+	}
+}
+
+type mockHistory struct {
+	info *instPkg.GlobalInfo
+}
+
+func (h mockHistory) Load(asOf time.Time) (*instPkg.GlobalInfo, time.Time, error) {
+	return h.info, asOf, nil
+}
+
+func TestGetAsOf(t *testing.T) {
+	c := New()
+	current := &instPkg.GlobalInfo{}
+	past := &instPkg.GlobalInfo{}
+	asOf := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	c.Set(current, time.Now())
+	if _, err := c.GetAsOf(&asOf); err != ErrNoHistory {
+		t.Fatalf("Expected ErrNoHistory without history, got: %v", err)
+	}
+
+	c.SetHistory(mockHistory{info: past})
+	if got, _ := c.GetAsOf(&asOf); got != past {
+		t.Fatalf("Wrong info returned for past time. Wanted: %p, got: %p", past, got)
+	}
+	if got, _ := c.GetAsOf(nil); got != current {
+		t.Fatalf("Wrong info returned without time. Wanted: %p, got: %p", current, got)
+	}
+}
+
+func TestStaleness(t *testing.T) {
+	c := New()
+	info := &instPkg.GlobalInfo{}
+	now := time.Now()
+
+	c.SetWithValidity(info, now.Add(-2*time.Hour), now.Add(time.Hour))
+	if c.IsStale() {
+		t.Fatalf("Catalogue should not be stale before its info is invalid")
+	}
+
+	c.SetMaxStaleness(time.Hour)
+	c.SetWithValidity(info, now.Add(-2*time.Hour), now.Add(-30*time.Minute))
+	if !c.IsStale() || !c.Status().Stale {
+		t.Fatalf("Catalogue should be stale once its info is invalid")
+	}
+	got, err := c.Get()
+	if err != nil || got != info {
+		t.Fatalf("Stale info should be served within max staleness (error: %v)", err)
+	}
+
+	c.SetWithValidity(info, now.Add(-3*time.Hour), now.Add(-2*time.Hour))
+	_, err = c.Get()
+	if err != ErrTooStale {
+		t.Fatalf("Wanted ErrTooStale beyond max staleness, got: %v", err)
+	}
+	if c.IsReady() {
+		t.Fatalf("Catalogue should not be ready beyond max staleness")
+	}
+}
+
+func TestRevalidate(t *testing.T) {
+	logger, err := utils.CreateMockLogger()
+	if err != nil {
+		t.Fatalf("Failed to create logger: %s", err.Error())
+	}
+
+	c := New()
+	stale := &instPkg.GlobalInfo{}
+	fresh := &instPkg.GlobalInfo{}
+	now := time.Now()
+	c.SetWithValidity(stale, now.Add(-2*time.Hour), now.Add(-time.Hour))
+
+	loads := make(chan bool, 10)
+	attempt := 0
+	load := func() (*instPkg.GlobalInfo, time.Time, time.Time, error) {
+		defer func() { loads <- true }()
+		attempt++
+		if attempt == 1 {
+			return nil, time.Time{}, time.Time{}, errors.New("TEST ERROR")
+		}
+		return fresh, time.Now(), time.Now().Add(time.Hour), nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go c.Revalidate(ctx, load, 10*time.Millisecond, logger)
+
+	<-loads // Failed, so stale info is still served
+	<-loads
+
+	deadline := time.Now().Add(time.Second)
+	for c.IsStale() && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+
+	got, err := c.Get()
+	if err != nil || got != fresh {
+		t.Fatalf("Wanted fresh info after revalidation (error: %v)", err)
+	}
+	if c.Status().LastError != nil {
+		t.Fatalf("Failed load still reported after revalidation: %v", c.Status().LastError)
+	}
+
+	time.Sleep(30 * time.Millisecond)
+	if len(loads) != 0 {
+		t.Fatalf("Catalogue revalidated while its info was valid")
+	}
+}
@@ -0,0 +1,112 @@
+package catalogue
+
+import (
+	"aws-blended-instances-advisor/api/schema"
+	instPkg "aws-blended-instances-advisor/instances"
+	"aws-blended-instances-advisor/instances/filter"
+	instSort "aws-blended-instances-advisor/instances/sort"
+	"aws-blended-instances-advisor/utils"
+	"fmt"
+)
+
+const (
+	DEFAULT_PAGE_SIZE = 50
+	MAX_PAGE_SIZE     = 1000
+)
+
+var instanceSortFuncs = map[string]func([]*instPkg.Instance, int, int){
+	"price":     instSort.SortInstancesByPrice,
+	"memory":    instSort.SortInstancesByMemory,
+	"vcpu":      instSort.SortInstancesByVcpu,
+	"revocProb": instSort.SortInstancesByRevocationProbability,
+	"region":    instSort.SortInstancesByRegion,
+}
+
+// A Query describes which Instances to select from a GlobalInfo, and how
+// they should be ordered and paginated.
+type Query struct {
+	Filter     filter.Filter
+	SortBy     string // Empty for no sorting
+	Descending bool
+	Page       int // Starts at 1
+	PageSize   int
+}
+
+// NewQuery creates a Query which selects the first page of all Instances.
+func NewQuery() Query {
+	return Query{
+		Page:     1,
+		PageSize: DEFAULT_PAGE_SIZE,
+	}
+}
+
+// Validate checks that a Query can be run, returning an error
+// describing the problem otherwise.
+func (q *Query) Validate() error {
+	if _, ok := instanceSortFuncs[q.SortBy]; q.SortBy != "" && !ok {
+		return fmt.Errorf("cannot sort by %s", q.SortBy)
+	}
+	if q.Page < 1 {
+		return fmt.Errorf("page must be at least 1")
+	}
+	if q.PageSize < 1 || q.PageSize > MAX_PAGE_SIZE {
+		return fmt.Errorf("pageSize must be between 1 and %d", MAX_PAGE_SIZE)
+	}
+	return nil
+}
+
+// QueryInstances runs a Query against a GlobalInfo, returning the requested
+// page of Instances. The Query should be validated beforehand.
+func QueryInstances(info instPkg.GlobalInfo, query Query) schema.InstancesResponse {
+	instances, markets := query.Filter.Select(info)
+
+	if query.SortBy != "" {
+		instanceSortFuncs[query.SortBy](instances, 0, len(instances))
+		if query.Descending {
+			reverseInstances(instances)
+		}
+	}
+
+	start := len(instances)
+	if query.Page-1 <= len(instances)/query.PageSize { // Avoids overflow for large pages
+		start = utils.MinOfInts((query.Page-1)*query.PageSize, len(instances))
+	}
+	end := utils.MinOfInts(start+query.PageSize, len(instances))
+
+	page := []schema.CatalogueInstance{}
+	for _, inst := range instances[start:end] {
+		page = append(page, schema.CatalogueInstance{
+			Instance: *inst.ToApiSchemaInstance(),
+			Market:   string(markets[inst.Id]),
+		})
+	}
+
+	return schema.InstancesResponse{
+		Instances: page,
+		Total:     len(instances),
+		Page:      query.Page,
+		PageSize:  query.PageSize,
+	}
+}
+
+// GetAggregates returns the global and per-region Aggregates of a GlobalInfo.
+func GetAggregates(info instPkg.GlobalInfo) schema.AggregatesResponse {
+	resp := schema.AggregatesResponse{
+		Global:  info.GlobalAggregates.ToApiSchemaAggregates(),
+		Regions: make(map[string]schema.RegionAggregates),
+	}
+	for region, regionInfo := range info.RegionInfoMap {
+		resp.Regions[region.CodeString()] = schema.RegionAggregates{
+			OnDemand: regionInfo.PermanentAggregates.ToApiSchemaAggregates(),
+			Spot:     regionInfo.TransientAggregates.ToApiSchemaAggregates(),
+			Region:   regionInfo.RegionAggregates.ToApiSchemaAggregates(),
+		}
+	}
+	return resp
+}
+
+func reverseInstances(instances []*instPkg.Instance) {
+	for i, j := 0, len(instances)-1; i < j; i, j = i+1, j-1 {
+		instances[i], instances[j] = instances[j], instances[i]
+	}
+}
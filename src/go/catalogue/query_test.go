@@ -0,0 +1,76 @@
+package catalogue
+
+import (
+	awsTypes "aws-blended-instances-advisor/aws/types"
+	instPkg "aws-blended-instances-advisor/instances"
+	"aws-blended-instances-advisor/instances/filter"
+	"math"
+	"testing"
+)
+
+func TestQueryValidate(t *testing.T) {
+	tests := map[string]struct {
+		query   Query
+		isValid bool
+	}{
+		"default":        {query: NewQuery(), isValid: true},
+		"sorted":         {query: Query{SortBy: "price", Page: 1, PageSize: 1}, isValid: true},
+		"unknown sort":   {query: Query{SortBy: "colour", Page: 1, PageSize: 1}, isValid: false},
+		"zero page":      {query: Query{Page: 0, PageSize: 1}, isValid: false},
+		"zero page size": {query: Query{Page: 1, PageSize: 0}, isValid: false},
+		"large page":     {query: Query{Page: 1, PageSize: MAX_PAGE_SIZE + 1}, isValid: false},
+	}
+
+	for name, test := range tests {
+		err := test.query.Validate()
+		if (err == nil) != test.isValid {
+			t.Fatalf("Wrong validation result for \"%s\". Wanted valid: %t, got error: %v", name, test.isValid, err)
+		}
+	}
+}
+
+func TestQueryInstances(t *testing.T) {
+	info := instPkg.GlobalInfo{
+		RegionInfoMap: instPkg.RegionInfoMap{
+			awsTypes.UsEast1: {
+				PermanentInstances: []*instPkg.Instance{
+					{Id: "0", Region: awsTypes.UsEast1, PricePerHour: 0.3},
+					{Id: "1", Region: awsTypes.UsEast1, PricePerHour: 0.1},
+				},
+				TransientInstances: []*instPkg.Instance{
+					{Id: "2", Region: awsTypes.UsEast1, PricePerHour: 0.2},
+				},
+			},
+		},
+	}
+
+	query := Query{SortBy: "price", Descending: true, Page: 1, PageSize: 2}
+	resp := QueryInstances(info, query)
+	if resp.Total != 3 {
+		t.Fatalf("Wrong total. Wanted: 3, got: %d", resp.Total)
+	}
+	if len(resp.Instances) != 2 || resp.Instances[0].Id != "0" || resp.Instances[1].Id != "2" {
+		t.Fatalf("Wrong first page returned: %+v", resp.Instances)
+	}
+	if resp.Instances[1].Market != string(filter.SpotMarket) {
+		t.Fatalf("Wrong market. Wanted: %s, got: %s", filter.SpotMarket, resp.Instances[1].Market)
+	}
+
+	query.Page = 2
+	resp = QueryInstances(info, query)
+	if len(resp.Instances) != 1 || resp.Instances[0].Id != "1" {
+		t.Fatalf("Wrong second page returned: %+v", resp.Instances)
+	}
+
+	query.Page = 3
+	resp = QueryInstances(info, query)
+	if len(resp.Instances) != 0 {
+		t.Fatalf("Expected empty page past end, got: %+v", resp.Instances)
+	}
+
+	query = Query{Page: math.MaxInt64/3 + 2, PageSize: 3}
+	resp = QueryInstances(info, query)
+	if len(resp.Instances) != 0 {
+		t.Fatalf("Expected empty page for overflowing page, got: %+v", resp.Instances)
+	}
+}
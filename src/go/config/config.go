@@ -1,18 +1,36 @@
 package config
 
 import (
+	"aws-blended-instances-advisor/api/schema"
+	"aws-blended-instances-advisor/cache"
 	"aws-blended-instances-advisor/utils"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"os"
+	"path/filepath"
+	"time"
 )
 
 const (
-	DEFAULT_API_PORT                       = 12021
-	DEFAULT_AWS_API_SPOT_INSTANCE_INFO_URL = "https://spot-bid-advisor.s3.amazonaws.com/spot-advisor-data.json"
-	DEFAULT_AWS_API_MAX_INSTANCES_TO_FETCH = 0
-	DEFAULT_AWS_API_DOWNLOADS_DIR          = "../../temp/downloads"
-	DEFAULT_CACHE_DIR                      = "../../temp/cache"
-	DEFAULT_CACHE_DEFAULT_LIFETIME         = 96
+	DEFAULT_API_HOST                        = "127.0.0.1"
+	DEFAULT_API_PORT                        = 12021
+	DEFAULT_API_SHUTDOWN_TIMEOUT_SECONDS    = 30
+	DEFAULT_API_JOB_RETENTION_MINUTES       = 60
+	DEFAULT_AWS_API_SPOT_INSTANCE_INFO_URL  = "https://spot-bid-advisor.s3.amazonaws.com/spot-advisor-data.json"
+	DEFAULT_AWS_API_MAX_INSTANCES_TO_FETCH  = 0
+	DEFAULT_AWS_API_DOWNLOADS_DIR           = "../../temp/downloads"
+	DEFAULT_AWS_API_SPOT_PRICES_MINUTES     = 60
+	DEFAULT_AWS_API_ON_DEMAND_HOURS         = 24
+	DEFAULT_AWS_API_SPOT_ADVISOR_HOURS      = 24
+	DEFAULT_CACHE_DIR                       = "../../temp/cache"
+	DEFAULT_CACHE_DEFAULT_LIFETIME          = 96
+	DEFAULT_CACHE_EVICTION_INTERVAL_MINUTES = 60
+	DEFAULT_CACHE_EVICTION_GRACE_HOURS      = 168
+	DEFAULT_CACHE_MAX_STALENESS_HOURS       = 168
+
+	BYTES_PER_MB = 1024 * 1024
 )
 
 // Config contains information on how the application should run.
@@ -20,19 +38,83 @@ const (
 // A Config object should be parsed using ParseConfig and should be
 // injected into packages as appropriate.
 type Config struct {
-	ApiConfig    ApiConfig    `json:"api"`
-	AwsApiConfig AwsApiConfig `json:"awsApi"`
-	CacheConfig  CacheConfig  `json:"cache"`
-	Credentials  Credentials  `json:"credentials"`
+	ApiConfig    ApiConfig     `json:"api"`
+	AwsApiConfig AwsApiConfig  `json:"awsApi"`
+	CacheConfig  CacheConfig   `json:"cache"`
+	Credentials  Credentials   `json:"credentials"`
+	Advisor      AdvisorConfig `json:"advisor"`
 }
 
 // ApiConfig contains details on how the API should be configured.
 type ApiConfig struct {
+	// The address on which the API should listen (empty for all interfaces)
+	Host string `json:"host"`
+
 	// The port on which the API should be run
 	Port int `json:"port"`
 
+	// The port on which the gRPC interface should be run (0 to disable)
+	GrpcPort int `json:"grpcPort"`
+
 	// The domains which are allowed to access the API
 	AllowedDomains []string `json:"allowedDomains"`
+
+	// The number of minutes finished advice jobs are kept for
+	JobRetentionMinutes int `json:"jobRetentionMinutes"`
+
+	// How API clients are authenticated and rate limited
+	Auth AuthConfig `json:"auth"`
+
+	// How TLS should be used by the API (no TLS when empty)
+	Tls TlsConfig `json:"tls"`
+
+	// The number of seconds in-flight requests are given to complete on shutdown
+	ShutdownTimeoutSeconds int `json:"shutdownTimeoutSeconds"`
+}
+
+// TlsConfig contains details on how the API should use TLS.
+type TlsConfig struct {
+	// The filepath of the PEM-encoded server certificate
+	CertFile string `json:"certFile"`
+
+	// The filepath of the PEM-encoded server private key
+	KeyFile string `json:"keyFile"`
+
+	// The filepath of PEM-encoded CA certificates used to verify client
+	// certificates. Client certificates are required when set.
+	ClientCaFile string `json:"clientCaFile"`
+}
+
+// Enabled returns true if TLS should be used, and false otherwise.
+func (c *TlsConfig) Enabled() bool {
+	return c.CertFile != "" || c.KeyFile != ""
+}
+
+// AuthConfig contains details on how API clients are authenticated.
+type AuthConfig struct {
+	// Whether API keys are required to access the API
+	Enabled bool `json:"enabled"`
+
+	// The API keys which can be used to access the API
+	Keys []ApiKeyConfig `json:"keys"`
+}
+
+// ApiKeyConfig describes a single API key and its limits.
+type ApiKeyConfig struct {
+	// A unique name for the key, used in logs
+	Name string `json:"name"`
+
+	// The hex-encoded SHA-256 hash of the key
+	Hash string `json:"hash"`
+
+	// The sustained number of requests per minute allowed (0 for unlimited)
+	RequestsPerMinute float64 `json:"requestsPerMinute"`
+
+	// The number of requests which can be made in a burst (defaults to one minute's worth)
+	Burst int `json:"burst"`
+
+	// The number of requests allowed per UTC day (0 for unlimited)
+	DailyQuota int `json:"dailyQuota"`
 }
 
 // AwsApiConfig contains information on how the AWS API/SDK
@@ -46,6 +128,23 @@ type AwsApiConfig struct {
 
 	// The maximum number of instances to fetch with each API call
 	MaxInstancesToFetch int `json:"maxInstancesToFetch"`
+
+	// How long each source of instance information is used before being fetched again
+	Refresh RefreshConfig `json:"refresh"`
+}
+
+// RefreshConfig contains how long each source of instance information,
+// which is cached separately for each region, remains valid.
+type RefreshConfig struct {
+	// How long spot prices are valid for, in minutes
+	SpotPricesMinutes int `json:"spotPricesMinutes"`
+
+	// How long on-demand instances and prices are valid for, in hours
+	OnDemandHours int `json:"onDemandHours"`
+
+	// How long spot advisor data (revocation probabilities and
+	// instance specifications) is valid for, in hours
+	SpotAdvisorHours int `json:"spotAdvisorHours"`
 }
 
 // Endpoints contains the endpoints used in the AWS package.
@@ -59,17 +158,147 @@ type CacheConfig struct {
 	// The directory path where cache files should be stored
 	Dirpath string `json:"dirpath"`
 
-	// The default lifetime for cache entries
+	// How cache entries are stored ("file" by default, or "bolt")
+	Backend cache.Backend `json:"backend"`
+
+	// The default lifetime for cache entries, in hours
 	DefaultLifetime int32 `json:"defaultLifetime"`
+
+	// How cached files are compressed ("none" by default, "gzip" or "zstd")
+	Compression cache.Compression `json:"compression"`
+
+	// The maximum total size of cached files in megabytes, above which the
+	// least recently used are removed (0 for no limit)
+	MaxSizeMb int `json:"maxSizeMb"`
+
+	// How often invalid entries are removed, in minutes (0 to never remove them)
+	EvictionIntervalMinutes int `json:"evictionIntervalMinutes"`
+
+	// How long entries are kept after becoming invalid, in hours, so that
+	// they can still be used when instances cannot be fetched
+	EvictionGraceHours int `json:"evictionGraceHours"`
+
+	// How long instances are served for after becoming invalid while fresh
+	// instances are fetched, in hours (0 for no limit)
+	MaxStalenessHours int `json:"maxStalenessHours"`
+
+	// How past instance information is kept (not kept when empty)
+	Snapshots SnapshotsConfig `json:"snapshots"`
+}
+
+// Options returns the cache.Options described by the CacheConfig.
+func (c *CacheConfig) Options() cache.Options {
+	return cache.Options{
+		Backend:         c.Backend,
+		Compression:     c.Compression,
+		DefaultLifetime: time.Duration(c.DefaultLifetime) * time.Hour,
+		MaxSizeBytes:    int64(c.MaxSizeMb) * BYTES_PER_MB,
+	}
+}
+
+// SnapshotsConfig contains details on how snapshots of fetched instance
+// information are kept for historical queries.
+type SnapshotsConfig struct {
+	// The directory path where snapshots should be stored
+	Dirpath string `json:"dirpath"`
+
+	// The number of days snapshots are kept for (0 to keep forever)
+	RetentionDays int `json:"retentionDays"`
+}
+
+// Enabled returns true if snapshots should be kept, and false otherwise.
+func (c *SnapshotsConfig) Enabled() bool {
+	return c.Dirpath != ""
+}
+
+// AdvisorConfig contains defaults for advice requests.
+type AdvisorConfig struct {
+	// The weights used by requests which give no weights (all zero)
+	DefaultWeights WeightsConfig `json:"defaultWeights"`
+
+	// Named defaults which requests can select, by name
+	Presets map[string]schema.Preset `json:"presets"`
+}
+
+// WeightsConfig contains the weights given to each property of an instance.
+type WeightsConfig struct {
+	Price        float64 `json:"price"`
+	Availability float64 `json:"availability"`
+	Performance  float64 `json:"performance"`
+}
+
+// IsZero returns true if no weight is given, and false otherwise.
+func (c *WeightsConfig) IsZero() bool {
+	return c.Price == 0 && c.Availability == 0 && c.Performance == 0
 }
 
 // Credentials contains AWS API/SDK credentials.
+//
+// When no key is given, the AWS SDK's default credential chain is used
+// (environment variables, shared config and credentials files, SSO,
+// and container or instance roles).
 type Credentials struct {
 	// The key ID to be used for AWS API authentication
 	AwsKeyId string `json:"awsKeyId"`
 
 	// The secret key to be used for AWS APU authentication
 	AwsSecretKey string `json:"awsSecretKey"`
+
+	// The session token to be used with temporary keys
+	AwsSessionToken string `json:"awsSessionToken"`
+
+	// The shared config profile used by the default credential chain
+	// (the default profile when empty)
+	Profile string `json:"profile"`
+
+	// The ARN of a role to assume using the other credentials (none when empty)
+	RoleArn string `json:"roleArn"`
+
+	// The session name used when assuming the role (generated when empty)
+	RoleSessionName string `json:"roleSessionName"`
+}
+
+// IsStatic returns true if a key is given, rather than using the
+// default credential chain, and false otherwise.
+func (c *Credentials) IsStatic() bool {
+	return c.AwsKeyId != ""
+}
+
+// paths returns pointers to each of the Config's fields which are filepaths.
+func (c *Config) paths() []*string {
+	return []*string{
+		&c.ApiConfig.Tls.CertFile,
+		&c.ApiConfig.Tls.KeyFile,
+		&c.ApiConfig.Tls.ClientCaFile,
+		&c.AwsApiConfig.DownloadsDir,
+		&c.CacheConfig.Dirpath,
+		&c.CacheConfig.Snapshots.Dirpath,
+	}
+}
+
+// resolveFilePaths makes each relative path given in a config file
+// relative to the config file's directory.
+func resolveFilePaths(cfg *Config, configJson []byte, configFilepath string) error {
+	var fileCfg Config
+	err := json.Unmarshal(configJson, &fileCfg)
+	if err != nil {
+		return err
+	}
+
+	absConfigFilepath, err := filepath.Abs(configFilepath)
+	if err != nil {
+		return err
+	}
+	configDirpath := filepath.Dir(absConfigFilepath)
+
+	cfgPaths := cfg.paths()
+	for i, filePath := range fileCfg.paths() {
+		if *filePath == "" || filepath.IsAbs(*filePath) {
+			continue
+		}
+		*cfgPaths[i] = filepath.Join(configDirpath, *filePath)
+	}
+	return nil
 }
 
 // String converts a Config into a printable string, suitable for
@@ -78,26 +307,48 @@ func (c *Config) String() string {
 	noCredsConfig := &Config{
 		ApiConfig:   c.ApiConfig,
 		CacheConfig: c.CacheConfig,
+		Advisor:     c.Advisor,
 	}
 
 	jsonBytes, _ := json.Marshal(noCredsConfig)
 	return string(jsonBytes)
 }
 
-// ParseConfig parses a Config from a file at the given filepath, filling in
-// missing fields with defaults where applicable.
+// ParseConfig parses a Config from a JSON, YAML or TOML file at the given
+// filepath (chosen by its extension), filling in missing fields with defaults
+// where applicable and then applying environment variable overrides.
+//
+// Relative paths given in the file are relative to the file's directory,
+// while default paths and those given by environment variables are relative
+// to the current working directory.
 //
 // Returns an error if an error is encountered when working with the filesystem,
-// or critical fields are missing.
-func ParseConfig(filepath string) (*Config, error) {
-	configBytes, err := utils.FileToBytes(filepath)
+// or critical fields are missing. A SchemaError describing each invalid field
+// is returned if the file does not match the config schema.
+func ParseConfig(configFilepath string) (*Config, error) {
+	format, err := FormatOf(configFilepath)
+	if err != nil {
+		return nil, err
+	}
+	configBytes, err := utils.FileToBytes(configFilepath)
+	if err != nil {
+		return nil, err
+	}
+	configJson, err := toJson(configBytes, format)
+	if err != nil {
+		return nil, err
+	}
+	err = validateSchema(configJson)
 	if err != nil {
 		return nil, err
 	}
 
 	cfg := Config{
 		ApiConfig: ApiConfig{
-			Port: DEFAULT_API_PORT,
+			Host:                   DEFAULT_API_HOST,
+			Port:                   DEFAULT_API_PORT,
+			JobRetentionMinutes:    DEFAULT_API_JOB_RETENTION_MINUTES,
+			ShutdownTimeoutSeconds: DEFAULT_API_SHUTDOWN_TIMEOUT_SECONDS,
 		},
 		AwsApiConfig: AwsApiConfig{
 			Endpoints: Endpoints{
@@ -105,14 +356,30 @@ func ParseConfig(filepath string) (*Config, error) {
 			},
 			DownloadsDir:        DEFAULT_AWS_API_DOWNLOADS_DIR,
 			MaxInstancesToFetch: DEFAULT_AWS_API_MAX_INSTANCES_TO_FETCH,
+			Refresh: RefreshConfig{
+				SpotPricesMinutes: DEFAULT_AWS_API_SPOT_PRICES_MINUTES,
+				OnDemandHours:     DEFAULT_AWS_API_ON_DEMAND_HOURS,
+				SpotAdvisorHours:  DEFAULT_AWS_API_SPOT_ADVISOR_HOURS,
+			},
 		},
 		CacheConfig: CacheConfig{
-			Dirpath:         DEFAULT_CACHE_DIR,
-			DefaultLifetime: DEFAULT_CACHE_DEFAULT_LIFETIME,
+			Dirpath:                 DEFAULT_CACHE_DIR,
+			DefaultLifetime:         DEFAULT_CACHE_DEFAULT_LIFETIME,
+			EvictionIntervalMinutes: DEFAULT_CACHE_EVICTION_INTERVAL_MINUTES,
+			EvictionGraceHours:      DEFAULT_CACHE_EVICTION_GRACE_HOURS,
+			MaxStalenessHours:       DEFAULT_CACHE_MAX_STALENESS_HOURS,
 		},
 	}
 
-	err = json.Unmarshal(configBytes, &cfg)
+	err = json.Unmarshal(configJson, &cfg)
+	if err != nil {
+		return nil, err
+	}
+	err = resolveFilePaths(&cfg, configJson, configFilepath)
+	if err != nil {
+		return nil, err
+	}
+	err = applyEnvironmentOverrides(&cfg, os.LookupEnv)
 	if err != nil {
 		return nil, err
 	}
@@ -148,6 +415,11 @@ func (c *Config) Validate() error {
 		return utils.PrependToError(err, "credentials are invalid")
 	}
 
+	err = c.Advisor.validate()
+	if err != nil {
+		return utils.PrependToError(err, "advisor config is invalid")
+	}
+
 	return nil
 }
 
@@ -155,11 +427,70 @@ func (c *ApiConfig) validate() error {
 	if c.Port <= 1023 {
 		return fmt.Errorf("port %d is within controller assignment range", c.Port)
 	}
+	if c.GrpcPort != 0 && c.GrpcPort <= 1023 {
+		return fmt.Errorf("grpcPort %d is within controller assignment range", c.GrpcPort)
+	}
+	if c.GrpcPort == c.Port {
+		return fmt.Errorf("grpcPort cannot be the same as port %d", c.Port)
+	}
 	if c.AllowedDomains == nil {
 		return fmt.Errorf(
 			"allowedDomains is not specified. Use an empty array (\"[]\") for no allowed domains",
 		)
 	}
+	if c.JobRetentionMinutes < 0 {
+		return fmt.Errorf("jobRetentionMinutes cannot be negative")
+	}
+	if c.ShutdownTimeoutSeconds < 0 {
+		return fmt.Errorf("shutdownTimeoutSeconds cannot be negative")
+	}
+	err := c.Auth.validate()
+	if err != nil {
+		return utils.PrependToError(err, "auth config invalid")
+	}
+	err = c.Tls.validate()
+	if err != nil {
+		return utils.PrependToError(err, "TLS config invalid")
+	}
+	return nil
+}
+
+func (c *TlsConfig) validate() error {
+	if (c.CertFile == "") != (c.KeyFile == "") {
+		return fmt.Errorf("certFile and keyFile must be specified together")
+	}
+	if c.ClientCaFile != "" && !c.Enabled() {
+		return fmt.Errorf("clientCaFile requires certFile and keyFile")
+	}
+	return nil
+}
+
+func (c *AuthConfig) validate() error {
+	if !c.Enabled {
+		return nil
+	}
+	if len(c.Keys) == 0 {
+		return fmt.Errorf("auth is enabled but no keys are specified")
+	}
+
+	names := make(utils.StringSet)
+	for _, key := range c.Keys {
+		if key.Name == "" {
+			return fmt.Errorf("key name is empty")
+		}
+		if names.Contains(key.Name) {
+			return fmt.Errorf("key name %s is not unique", key.Name)
+		}
+		names.Add(key.Name)
+
+		hash, err := hex.DecodeString(key.Hash)
+		if err != nil || len(hash) != sha256.Size {
+			return fmt.Errorf("hash for key %s is not a hex-encoded SHA-256 hash", key.Name)
+		}
+		if key.RequestsPerMinute < 0 || key.Burst < 0 || key.DailyQuota < 0 {
+			return fmt.Errorf("limits for key %s cannot be negative", key.Name)
+		}
+	}
 	return nil
 }
 
@@ -170,6 +501,19 @@ func (c *AwsApiConfig) validate() error {
 	if c.Endpoints.AwsSpotInstanceInfoUrl == "" {
 		return fmt.Errorf("awsSpotInstanceInfoUrl is empty")
 	}
+	return c.Refresh.validate()
+}
+
+func (c *RefreshConfig) validate() error {
+	if c.SpotPricesMinutes <= 0 {
+		return fmt.Errorf("spotPricesMinutes must be positive")
+	}
+	if c.OnDemandHours <= 0 {
+		return fmt.Errorf("onDemandHours must be positive")
+	}
+	if c.SpotAdvisorHours <= 0 {
+		return fmt.Errorf("spotAdvisorHours must be positive")
+	}
 	return nil
 }
 
@@ -180,15 +524,54 @@ func (c *CacheConfig) validate() error {
 	if c.Dirpath == "" {
 		return fmt.Errorf("dirpath is empty")
 	}
+	options := c.Options()
+	err := options.Validate()
+	if err != nil {
+		return err
+	}
+	if c.EvictionIntervalMinutes < 0 {
+		return fmt.Errorf("evictionIntervalMinutes cannot be negative")
+	}
+	if c.EvictionGraceHours < 0 {
+		return fmt.Errorf("evictionGraceHours cannot be negative")
+	}
+	if c.MaxStalenessHours < 0 {
+		return fmt.Errorf("maxStalenessHours cannot be negative")
+	}
+	if c.Snapshots.RetentionDays < 0 {
+		return fmt.Errorf("snapshots retentionDays cannot be negative")
+	}
 	return nil
 }
 
 func (c *Credentials) validate() error {
-	if c.AwsKeyId == "" {
+	if c.AwsKeyId == "" && c.AwsSecretKey != "" {
 		return fmt.Errorf("awsKeyId is empty")
 	}
-	if c.AwsSecretKey == "" {
+	if c.AwsKeyId != "" && c.AwsSecretKey == "" {
 		return fmt.Errorf("awsSecretKey is empty")
 	}
+	if c.AwsSessionToken != "" && !c.IsStatic() {
+		return fmt.Errorf("awsSessionToken cannot be given without awsKeyId")
+	}
+	if c.Profile != "" && c.IsStatic() {
+		return fmt.Errorf("profile cannot be given with awsKeyId")
+	}
+	if c.RoleSessionName != "" && c.RoleArn == "" {
+		return fmt.Errorf("roleSessionName cannot be given without roleArn")
+	}
+	return nil
+}
+
+func (c *AdvisorConfig) validate() error {
+	for name, preset := range c.Presets {
+		if name == "" {
+			return fmt.Errorf("preset name is empty")
+		}
+		err := preset.Options.Validate()
+		if err != nil {
+			return utils.PrependToError(err, fmt.Sprintf("preset %s is invalid", name))
+		}
+	}
 	return nil
 }
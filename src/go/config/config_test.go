@@ -1,6 +1,12 @@
 package config
 
 import (
+	"aws-blended-instances-advisor/api/schema"
+	"aws-blended-instances-advisor/cache"
+	"encoding/json"
+	"errors"
+	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -21,40 +27,119 @@ func TestParseConfigValid(t *testing.T) {
 			expected: Config{
 				Credentials: Credentials{AwsKeyId: "KEY_ID", AwsSecretKey: "SECRET_KEY"},
 				ApiConfig: ApiConfig{
-					AllowedDomains: []string{"https://test.com:3000"},
-					Port:           123456,
+					AllowedDomains:         []string{"https://test.com:3000"},
+					Host:                   DEFAULT_API_HOST,
+					Port:                   23456,
+					JobRetentionMinutes:    30,
+					ShutdownTimeoutSeconds: DEFAULT_API_SHUTDOWN_TIMEOUT_SECONDS,
 				},
 				AwsApiConfig: AwsApiConfig{
 					Endpoints: Endpoints{
 						AwsSpotInstanceInfoUrl: "TEST_URL",
 					},
 					MaxInstancesToFetch: 1000,
-					DownloadsDir:        "TEST_DOWNLOADS_DIR",
+					DownloadsDir:        validTestdataPath("TEST_DOWNLOADS_DIR"),
+					Refresh: RefreshConfig{
+						SpotPricesMinutes: DEFAULT_AWS_API_SPOT_PRICES_MINUTES,
+						OnDemandHours:     DEFAULT_AWS_API_ON_DEMAND_HOURS,
+						SpotAdvisorHours:  DEFAULT_AWS_API_SPOT_ADVISOR_HOURS,
+					},
 				},
 				CacheConfig: CacheConfig{
-					Dirpath:         "TEST_CACHE_DIRPATH",
-					DefaultLifetime: 100,
+					Dirpath:                 validTestdataPath("TEST_CACHE_DIRPATH"),
+					DefaultLifetime:         100,
+					EvictionIntervalMinutes: DEFAULT_CACHE_EVICTION_INTERVAL_MINUTES,
+					EvictionGraceHours:      DEFAULT_CACHE_EVICTION_GRACE_HOURS,
+					MaxStalenessHours:       DEFAULT_CACHE_MAX_STALENESS_HOURS,
 				},
 			},
 		},
 		{
 			filepath: "testdata/valid/config-2.json",
 			expected: Config{
-				Credentials: Credentials{AwsKeyId: "KEY_ID_2", AwsSecretKey: "SECRET_KEY_2"},
+				Credentials: Credentials{AwsKeyId: "KEY_ID", AwsSecretKey: "SECRET_KEY"},
+				ApiConfig: ApiConfig{
+					Host:                   "0.0.0.0",
+					Port:                   54321,
+					GrpcPort:               54322,
+					AllowedDomains:         []string{"http://some.domain.com"},
+					JobRetentionMinutes:    DEFAULT_API_JOB_RETENTION_MINUTES,
+					ShutdownTimeoutSeconds: 5,
+				},
+				AwsApiConfig: AwsApiConfig{
+					Endpoints: Endpoints{
+						AwsSpotInstanceInfoUrl: "TEST_URL",
+					},
+					DownloadsDir:        validTestdataPath("TEST_DOWNLOADS_DIR"),
+					MaxInstancesToFetch: 1000,
+					Refresh: RefreshConfig{
+						SpotPricesMinutes: 30,
+						OnDemandHours:     12,
+						SpotAdvisorHours:  48,
+					},
+				},
+				CacheConfig: CacheConfig{
+					Dirpath:                 validTestdataPath("TEST_CACHE_DIRPATH"),
+					Backend:                 cache.BoltBackend,
+					DefaultLifetime:         200,
+					Compression:             cache.ZstdCompression,
+					MaxSizeMb:               64,
+					EvictionIntervalMinutes: 10,
+					EvictionGraceHours:      24,
+					MaxStalenessHours:       12,
+					Snapshots: SnapshotsConfig{
+						Dirpath:       validTestdataPath("TEST_SNAPSHOTS_DIRPATH"),
+						RetentionDays: 30,
+					},
+				},
+				Advisor: AdvisorConfig{
+					DefaultWeights: WeightsConfig{Price: 1, Availability: 1, Performance: 1},
+					Presets: map[string]schema.Preset{
+						"cost-saver": {
+							Description: "TEST_DESCRIPTION",
+							Weights:     schema.AdvisorWeights{Price: 3, Availability: 1},
+							Options: schema.Options{
+								ConsiderFreeInstances: true,
+								Regions:               []string{"us-east-1"},
+								Filter:                schema.InstanceFilter{Family: "m5", MaxPrice: 0.5},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			filepath: "testdata/valid/config-3.json",
+			expected: Config{
+				Credentials: Credentials{
+					Profile: "TEST_PROFILE",
+					RoleArn: "arn:aws:iam::123456789012:role/TEST_ROLE",
+				},
 				ApiConfig: ApiConfig{
-					Port:           54321,
-					AllowedDomains: []string{"http://some.domain.com"},
+					AllowedDomains:         []string{"https://test.com:3000"},
+					Host:                   DEFAULT_API_HOST,
+					Port:                   23456,
+					JobRetentionMinutes:    DEFAULT_API_JOB_RETENTION_MINUTES,
+					ShutdownTimeoutSeconds: DEFAULT_API_SHUTDOWN_TIMEOUT_SECONDS,
 				},
 				AwsApiConfig: AwsApiConfig{
 					Endpoints: Endpoints{
 						AwsSpotInstanceInfoUrl: "TEST_URL",
 					},
-					DownloadsDir:        "TEST_DOWNLOADS_DIR",
 					MaxInstancesToFetch: 1000,
+					DownloadsDir:        validTestdataPath("TEST_DOWNLOADS_DIR"),
+					Refresh: RefreshConfig{
+						SpotPricesMinutes: DEFAULT_AWS_API_SPOT_PRICES_MINUTES,
+						OnDemandHours:     DEFAULT_AWS_API_ON_DEMAND_HOURS,
+						SpotAdvisorHours:  DEFAULT_AWS_API_SPOT_ADVISOR_HOURS,
+					},
 				},
 				CacheConfig: CacheConfig{
-					Dirpath:         "TEST_CACHE_DIRPATH",
-					DefaultLifetime: 200,
+					Dirpath:                 validTestdataPath("TEST_CACHE_DIRPATH"),
+					DefaultLifetime:         100,
+					EvictionIntervalMinutes: DEFAULT_CACHE_EVICTION_INTERVAL_MINUTES,
+					EvictionGraceHours:      DEFAULT_CACHE_EVICTION_GRACE_HOURS,
+					MaxStalenessHours:       DEFAULT_CACHE_MAX_STALENESS_HOURS,
 				},
 			},
 		},
@@ -73,16 +158,38 @@ func TestParseConfigValid(t *testing.T) {
 				test.expected.String(),
 			)
 		}
+		if config.Credentials != test.expected.Credentials {
+			t.Fatalf("Parsed credentials not equal to expected credentials. File %s", test.filepath)
+		}
 	}
 }
 
+// validTestdataPath returns the absolute path of a file relative to the valid
+// test configs, as relative paths in config files are resolved.
+func validTestdataPath(name string) string {
+	path, _ := filepath.Abs(filepath.Join("testdata", "valid", name))
+	return path
+}
+
 func TestParseConfigInvalid(t *testing.T) {
 
 	errorTests := map[string]invalidConfigTest{
 		"no AWS API config":       {filepath: "testdata/invalid/no-aws-api-config.json"},
-		"no credentials":          {filepath: "testdata/invalid/no-credentials.json"},
+		"key without secret":      {filepath: "testdata/invalid/key-without-secret-config.json"},
 		"no API config":           {filepath: "testdata/invalid/no-api-config.json"},
 		"invalid port API config": {filepath: "testdata/invalid/invalid-port-config.json"},
+		"no auth keys":            {filepath: "testdata/invalid/no-auth-keys-config.json"},
+		"invalid auth key hash":   {filepath: "testdata/invalid/invalid-auth-key-hash-config.json"},
+		"TLS key without cert":    {filepath: "testdata/invalid/tls-no-cert-config.json"},
+		"gRPC port same as port":  {filepath: "testdata/invalid/grpc-port-config.json"},
+		"negative retention":      {filepath: "testdata/invalid/snapshot-retention-config.json"},
+		"unknown cache backend":   {filepath: "testdata/invalid/cache-backend-config.json"},
+		"unknown compression":     {filepath: "testdata/invalid/cache-compression-config.json"},
+		"zero refresh interval":   {filepath: "testdata/invalid/refresh-interval-config.json"},
+		"JSON syntax error":       {filepath: "testdata/invalid/syntax-config.json"},
+		"unsupported format":      {filepath: "testdata/invalid/unsupported-format-config.ini"},
+		"schema mismatch":         {filepath: "testdata/invalid/schema-config.yaml"},
+		"invalid preset region":   {filepath: "testdata/invalid/preset-region-config.json"},
 	}
 
 	for name, test := range errorTests {
@@ -96,3 +203,59 @@ func TestParseConfigInvalid(t *testing.T) {
 		}
 	}
 }
+
+func TestParseConfigFormats(t *testing.T) {
+	expected, err := ParseConfig("testdata/valid/config-2.json")
+	if err != nil {
+		t.Fatalf("Failed to parse JSON config: %s", err.Error())
+	}
+	expectedJson, _ := json.Marshal(expected)
+
+	for _, filepath := range []string{"testdata/valid/config-2.yaml", "testdata/valid/config-2.toml"} {
+		config, err := ParseConfig(filepath)
+		if err != nil {
+			t.Fatalf("Failed to parse config. File: %s, error: %s", filepath, err.Error())
+		}
+		configJson, _ := json.Marshal(config)
+		if string(configJson) != string(expectedJson) {
+			t.Fatalf(
+				"Parsed config not equal to JSON config. File %s \n\nParsed:\n%s\n\nExpected:\n%s",
+				filepath,
+				configJson,
+				expectedJson,
+			)
+		}
+	}
+}
+
+func TestParseConfigSchemaErrors(t *testing.T) {
+	_, err := ParseConfig("testdata/invalid/schema-config.yaml")
+
+	var schemaErr *SchemaError
+	if !errors.As(err, &schemaErr) {
+		t.Fatalf("Expected schema error, but received: %v", err)
+	}
+
+	expectedFields := []string{"api.port", "api.unknownField", "cache.compression"}
+	for _, expectedField := range expectedFields {
+		found := false
+		for _, fieldErr := range schemaErr.Errors {
+			if fieldErr.Field == expectedField {
+				found = true
+			}
+		}
+		if !found {
+			t.Fatalf("Expected error for field %s, but received: %s", expectedField, schemaErr.Error())
+		}
+	}
+	if len(schemaErr.Errors) != len(expectedFields) {
+		t.Fatalf("Expected %d field errors, but received: %s", len(expectedFields), schemaErr.Error())
+	}
+}
+
+func TestParseConfigSyntaxErrorPosition(t *testing.T) {
+	_, err := ParseConfig("testdata/invalid/syntax-config.json")
+	if err == nil || !strings.Contains(err.Error(), "line 4") {
+		t.Fatalf("Expected error describing line 4, but received: %v", err)
+	}
+}
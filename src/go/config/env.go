@@ -0,0 +1,130 @@
+package config
+
+import (
+	"aws-blended-instances-advisor/utils"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// ENV_PREFIX begins the name of every environment variable which overrides a config field.
+const ENV_PREFIX = "ADVISOR"
+
+// EnvironmentVariableName returns the name of the environment variable which
+// overrides the config field at the given path of JSON keys,
+// e.g. ["credentials", "awsKeyId"] gives ADVISOR_CREDENTIALS_AWS_KEY_ID.
+func EnvironmentVariableName(path ...string) string {
+	parts := []string{ENV_PREFIX}
+	for _, key := range path {
+		parts = append(parts, toUpperSnakeCase(key))
+	}
+	return strings.Join(parts, "_")
+}
+
+// applyEnvironmentOverrides replaces each field of the Config which has
+// an environment variable set, named as given by EnvironmentVariableName.
+//
+// Strings, numbers and booleans are parsed from their usual text forms.
+// Lists of strings may be comma-separated, and every other field
+// (such as API keys) is parsed as JSON.
+func applyEnvironmentOverrides(cfg *Config, lookupEnv func(string) (string, bool)) error {
+	return applyEnvironmentOverridesToStruct(reflect.ValueOf(cfg).Elem(), nil, lookupEnv)
+}
+
+func applyEnvironmentOverridesToStruct(v reflect.Value, path []string, lookupEnv func(string) (string, bool)) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		key := strings.Split(field.Tag.Get("json"), ",")[0]
+		if key == "" || key == "-" {
+			continue
+		}
+		fieldPath := append(append([]string{}, path...), key)
+
+		if field.Type.Kind() == reflect.Struct {
+			err := applyEnvironmentOverridesToStruct(v.Field(i), fieldPath, lookupEnv)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		name := EnvironmentVariableName(fieldPath...)
+		value, ok := lookupEnv(name)
+		if !ok {
+			continue
+		}
+		err := setFieldFromString(v.Field(i), value)
+		if err != nil {
+			return utils.PrependToError(err, fmt.Sprintf("invalid value for %s", name))
+		}
+	}
+	return nil
+}
+
+func setFieldFromString(field reflect.Value, value string) error {
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(value)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		field.SetBool(b)
+	case reflect.Int, reflect.Int64:
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return err
+		}
+		field.SetInt(n)
+	case reflect.Float64:
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return err
+		}
+		field.SetFloat(f)
+	case reflect.Slice:
+		if field.Type().Elem().Kind() == reflect.String && !strings.HasPrefix(strings.TrimSpace(value), "[") {
+			field.Set(reflect.ValueOf(splitList(value)).Convert(field.Type()))
+			return nil
+		}
+		return json.Unmarshal([]byte(value), field.Addr().Interface())
+	default:
+		return json.Unmarshal([]byte(value), field.Addr().Interface())
+	}
+	return nil
+}
+
+// splitList splits a comma-separated list, ignoring empty items.
+func splitList(value string) []string {
+	items := []string{}
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// toUpperSnakeCase converts a camelCase JSON key to UPPER_SNAKE_CASE,
+// e.g. awsSpotInstanceInfoUrl to AWS_SPOT_INSTANCE_INFO_URL.
+func toUpperSnakeCase(key string) string {
+	var b strings.Builder
+	runes := []rune(key)
+	for i, r := range runes {
+		if i > 0 && unicode.IsUpper(r) {
+			previousIsLower := unicode.IsLower(runes[i-1]) || unicode.IsDigit(runes[i-1])
+			nextIsLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if previousIsLower || (unicode.IsUpper(runes[i-1]) && nextIsLower) {
+				b.WriteRune('_')
+			}
+		}
+		b.WriteRune(unicode.ToUpper(r))
+	}
+	return b.String()
+}
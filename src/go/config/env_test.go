@@ -0,0 +1,115 @@
+package config
+
+import (
+	"aws-blended-instances-advisor/cache"
+	"testing"
+)
+
+type environmentVariableNameTest struct {
+	path     []string
+	expected string
+}
+
+func TestEnvironmentVariableName(t *testing.T) {
+	tests := map[string]environmentVariableNameTest{
+		"top level field": {
+			path:     []string{"credentials", "awsKeyId"},
+			expected: "ADVISOR_CREDENTIALS_AWS_KEY_ID",
+		},
+		"nested field": {
+			path:     []string{"awsApi", "endpoints", "awsSpotInstanceInfoUrl"},
+			expected: "ADVISOR_AWS_API_ENDPOINTS_AWS_SPOT_INSTANCE_INFO_URL",
+		},
+		"abbreviation": {
+			path:     []string{"cache", "maxSizeMb"},
+			expected: "ADVISOR_CACHE_MAX_SIZE_MB",
+		},
+		"single word": {
+			path:     []string{"api", "port"},
+			expected: "ADVISOR_API_PORT",
+		},
+	}
+
+	for name, test := range tests {
+		got := EnvironmentVariableName(test.path...)
+		if got != test.expected {
+			t.Fatalf("Incorrect name for test \"%s\": %s (expected %s)", name, got, test.expected)
+		}
+	}
+}
+
+func TestApplyEnvironmentOverrides(t *testing.T) {
+	env := map[string]string{
+		"ADVISOR_CREDENTIALS_AWS_KEY_ID":          "ENV_KEY_ID",
+		"ADVISOR_CREDENTIALS_AWS_SECRET_KEY":      "ENV_SECRET_KEY",
+		"ADVISOR_API_PORT":                        "8080",
+		"ADVISOR_API_ALLOWED_DOMAINS":             "https://a.com, https://b.com",
+		"ADVISOR_API_AUTH_ENABLED":                "true",
+		"ADVISOR_API_AUTH_KEYS":                   `[{"name": "env", "hash": "HASH", "requestsPerMinute": 1.5}]`,
+		"ADVISOR_CACHE_BACKEND":                   "bolt",
+		"ADVISOR_AWS_API_REFRESH_ON_DEMAND_HOURS": "6",
+	}
+	lookupEnv := func(name string) (string, bool) {
+		value, ok := env[name]
+		return value, ok
+	}
+
+	cfg := Config{
+		ApiConfig: ApiConfig{Port: 1234, Host: DEFAULT_API_HOST},
+		CacheConfig: CacheConfig{
+			Dirpath: "TEST_CACHE_DIRPATH",
+		},
+	}
+	err := applyEnvironmentOverrides(&cfg, lookupEnv)
+	if err != nil {
+		t.Fatalf("Failed to apply environment overrides: %s", err.Error())
+	}
+
+	if cfg.Credentials.AwsKeyId != "ENV_KEY_ID" || cfg.Credentials.AwsSecretKey != "ENV_SECRET_KEY" {
+		t.Fatalf("Credentials not overridden: %+v", cfg.Credentials)
+	}
+	if cfg.ApiConfig.Port != 8080 {
+		t.Fatalf("Port not overridden: %d", cfg.ApiConfig.Port)
+	}
+	if cfg.ApiConfig.Host != DEFAULT_API_HOST {
+		t.Fatalf("Host overridden without an environment variable: %s", cfg.ApiConfig.Host)
+	}
+	if len(cfg.ApiConfig.AllowedDomains) != 2 || cfg.ApiConfig.AllowedDomains[1] != "https://b.com" {
+		t.Fatalf("Allowed domains not overridden: %v", cfg.ApiConfig.AllowedDomains)
+	}
+	if !cfg.ApiConfig.Auth.Enabled {
+		t.Fatalf("Auth not enabled")
+	}
+	if len(cfg.ApiConfig.Auth.Keys) != 1 || cfg.ApiConfig.Auth.Keys[0].RequestsPerMinute != 1.5 {
+		t.Fatalf("Auth keys not overridden: %+v", cfg.ApiConfig.Auth.Keys)
+	}
+	if cfg.CacheConfig.Backend != cache.BoltBackend {
+		t.Fatalf("Cache backend not overridden: %s", cfg.CacheConfig.Backend)
+	}
+	if cfg.CacheConfig.Dirpath != "TEST_CACHE_DIRPATH" {
+		t.Fatalf("Cache dirpath overridden without an environment variable: %s", cfg.CacheConfig.Dirpath)
+	}
+	if cfg.AwsApiConfig.Refresh.OnDemandHours != 6 {
+		t.Fatalf("Nested refresh config not overridden: %d", cfg.AwsApiConfig.Refresh.OnDemandHours)
+	}
+}
+
+func TestApplyEnvironmentOverridesInvalid(t *testing.T) {
+	tests := map[string]map[string]string{
+		"non-numeric int":  {"ADVISOR_API_PORT": "eighty"},
+		"non-boolean bool": {"ADVISOR_API_AUTH_ENABLED": "maybe"},
+		"invalid JSON":     {"ADVISOR_API_AUTH_KEYS": "[{"},
+	}
+
+	for name, env := range tests {
+		lookupEnv := func(name string) (string, bool) {
+			value, ok := env[name]
+			return value, ok
+		}
+		cfg := Config{}
+		err := applyEnvironmentOverrides(&cfg, lookupEnv)
+		if err == nil {
+			t.Fatalf("Expected error, but did not receive one for \"%s\"", name)
+		}
+	}
+}
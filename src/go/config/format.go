@@ -0,0 +1,97 @@
+package config
+
+import (
+	"aws-blended-instances-advisor/utils"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// A Format is a file format in which a Config can be written.
+type Format string
+
+const (
+	JsonFormat Format = "json"
+	YamlFormat Format = "yaml"
+	TomlFormat Format = "toml"
+)
+
+// FormatOf returns the Format of a config file from its extension,
+// returning an error if the extension is not supported.
+func FormatOf(configFilepath string) (Format, error) {
+	switch strings.ToLower(filepath.Ext(configFilepath)) {
+	case ".json":
+		return JsonFormat, nil
+	case ".yaml", ".yml":
+		return YamlFormat, nil
+	case ".toml":
+		return TomlFormat, nil
+	default:
+		return "", fmt.Errorf(
+			"unsupported config file extension \"%s\" (use .json, .yaml, .yml or .toml)",
+			filepath.Ext(configFilepath),
+		)
+	}
+}
+
+// toJson converts a config file's contents from the given Format to JSON,
+// so that all formats share the JSON field names and schema.
+func toJson(content []byte, format Format) ([]byte, error) {
+	var document interface{}
+
+	switch format {
+	case JsonFormat:
+		decoder := json.NewDecoder(bytes.NewReader(content))
+		err := decoder.Decode(&document)
+		if err != nil {
+			return nil, describeJsonError(content, err)
+		}
+		return content, nil
+	case YamlFormat:
+		err := yaml.Unmarshal(content, &document)
+		if err != nil {
+			return nil, utils.PrependToError(err, "invalid YAML")
+		}
+	case TomlFormat:
+		tomlDocument := make(map[string]interface{})
+		err := toml.Unmarshal(content, &tomlDocument)
+		if err != nil {
+			return nil, utils.PrependToError(err, "invalid TOML")
+		}
+		document = tomlDocument
+	default:
+		return nil, fmt.Errorf("unsupported config format: %s", format)
+	}
+
+	jsonContent, err := json.Marshal(document)
+	if err != nil {
+		return nil, utils.PrependToError(err, fmt.Sprintf("could not convert %s to JSON", format))
+	}
+	return jsonContent, nil
+}
+
+// describeJsonError adds the line and column at which a JSON syntax error
+// occurred to its description.
+func describeJsonError(content []byte, err error) error {
+	var syntaxErr *json.SyntaxError
+	if !errors.As(err, &syntaxErr) {
+		return utils.PrependToError(err, "invalid JSON")
+	}
+
+	line, column := 1, 1
+	for _, b := range content[:syntaxErr.Offset] {
+		if b == '\n' {
+			line += 1
+			column = 1
+		} else {
+			column += 1
+		}
+	}
+	return fmt.Errorf("invalid JSON at line %d, column %d: %s", line, column, syntaxErr.Error())
+}
@@ -0,0 +1,120 @@
+package config
+
+import (
+	"aws-blended-instances-advisor/api/schema"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// RELOADABLE_FIELDS are the JSON paths of the config fields which can be
+// changed while the program is running. Changes to any other field are
+// only applied after a restart.
+var RELOADABLE_FIELDS = []string{
+	"api.allowedDomains",
+	"awsApi.refresh",
+	"advisor.defaultWeights",
+	"advisor.presets",
+}
+
+// Live holds the fields of a Config which can be reloaded while the
+// program is running, and is safe for concurrent use.
+type Live struct {
+	mu             sync.RWMutex
+	allowedDomains []string
+	refresh        RefreshConfig
+	defaultWeights WeightsConfig
+	presets        map[string]schema.Preset
+}
+
+// NewLive creates a Live holding the reloadable fields of cfg.
+func NewLive(cfg *Config) *Live {
+	l := &Live{}
+	l.update(cfg)
+	return l
+}
+
+// AllowedDomains returns the domains which are allowed to access the API.
+func (l *Live) AllowedDomains() []string {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.allowedDomains
+}
+
+// Refresh returns how long each source of instance information remains valid.
+func (l *Live) Refresh() RefreshConfig {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.refresh
+}
+
+// DefaultWeights returns the weights used by requests which give no weights.
+func (l *Live) DefaultWeights() WeightsConfig {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.defaultWeights
+}
+
+// Presets returns the named presets which requests can select, which
+// must not be modified.
+func (l *Live) Presets() map[string]schema.Preset {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.presets
+}
+
+func (l *Live) update(cfg *Config) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.allowedDomains = append([]string{}, cfg.ApiConfig.AllowedDomains...)
+	l.refresh = cfg.AwsApiConfig.Refresh
+	l.defaultWeights = cfg.Advisor.DefaultWeights
+	l.presets = cfg.Advisor.Presets
+}
+
+// Reload applies the reloadable fields of next, which replaces previous.
+//
+// Returns the JSON paths of the fields which changed, split into those
+// which were reloaded and those which require a restart to take effect.
+func (l *Live) Reload(previous *Config, next *Config) (reloaded []string, restartRequired []string) {
+	for _, field := range changedFields(reflect.ValueOf(*previous), reflect.ValueOf(*next), "") {
+		if isReloadable(field) {
+			reloaded = append(reloaded, field)
+		} else {
+			restartRequired = append(restartRequired, field)
+		}
+	}
+	if len(reloaded) > 0 {
+		l.update(next)
+	}
+	return reloaded, restartRequired
+}
+
+func isReloadable(field string) bool {
+	for _, reloadable := range RELOADABLE_FIELDS {
+		if field == reloadable || strings.HasPrefix(field, reloadable+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// changedFields returns the JSON paths of the fields which differ between
+// two values of the same struct type, treating lists as single fields.
+func changedFields(a reflect.Value, b reflect.Value, path string) []string {
+	changed := []string{}
+	t := a.Type()
+	for i := 0; i < t.NumField(); i++ {
+		key := strings.Split(t.Field(i).Tag.Get("json"), ",")[0]
+		fieldPath := strings.TrimPrefix(path+"."+key, ".")
+
+		if t.Field(i).Type.Kind() == reflect.Struct {
+			changed = append(changed, changedFields(a.Field(i), b.Field(i), fieldPath)...)
+		} else if !reflect.DeepEqual(a.Field(i).Interface(), b.Field(i).Interface()) {
+			changed = append(changed, fieldPath)
+		}
+	}
+	sort.Strings(changed)
+	return changed
+}
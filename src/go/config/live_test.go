@@ -0,0 +1,56 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLiveReload(t *testing.T) {
+	previous := &Config{
+		ApiConfig:    ApiConfig{Port: 1234, AllowedDomains: []string{"https://a.com"}},
+		AwsApiConfig: AwsApiConfig{Refresh: RefreshConfig{SpotPricesMinutes: 60}},
+	}
+	live := NewLive(previous)
+
+	next := &Config{
+		ApiConfig:    ApiConfig{Port: 4321, AllowedDomains: []string{"https://b.com"}},
+		AwsApiConfig: AwsApiConfig{Refresh: RefreshConfig{SpotPricesMinutes: 30}},
+		Advisor:      AdvisorConfig{DefaultWeights: WeightsConfig{Price: 2}},
+	}
+	reloaded, restartRequired := live.Reload(previous, next)
+
+	expectedReloaded := []string{
+		"advisor.defaultWeights.price",
+		"api.allowedDomains",
+		"awsApi.refresh.spotPricesMinutes",
+	}
+	if !reflect.DeepEqual(reloaded, expectedReloaded) {
+		t.Fatalf("Incorrect reloaded fields: %v (expected %v)", reloaded, expectedReloaded)
+	}
+	if !reflect.DeepEqual(restartRequired, []string{"api.port"}) {
+		t.Fatalf("Incorrect restart required fields: %v (expected [api.port])", restartRequired)
+	}
+
+	if !reflect.DeepEqual(live.AllowedDomains(), next.ApiConfig.AllowedDomains) {
+		t.Fatalf("Allowed domains not reloaded: %v", live.AllowedDomains())
+	}
+	if live.Refresh() != next.AwsApiConfig.Refresh {
+		t.Fatalf("Refresh config not reloaded: %v", live.Refresh())
+	}
+	if live.DefaultWeights() != next.Advisor.DefaultWeights {
+		t.Fatalf("Default weights not reloaded: %v", live.DefaultWeights())
+	}
+}
+
+func TestLiveReloadWithoutReloadableChanges(t *testing.T) {
+	previous := &Config{ApiConfig: ApiConfig{Port: 1234, AllowedDomains: []string{"https://a.com"}}}
+	live := NewLive(previous)
+
+	next := *previous
+	next.ApiConfig.Port = 4321
+	reloaded, _ := live.Reload(previous, &next)
+
+	if len(reloaded) != 0 {
+		t.Fatalf("Unexpected reloaded fields: %v", reloaded)
+	}
+}
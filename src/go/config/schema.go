@@ -0,0 +1,68 @@
+package config
+
+import (
+	_ "embed"
+	"fmt"
+	"strings"
+
+	"github.com/xeipuuv/gojsonschema"
+)
+
+//go:embed schema.json
+var schemaJson []byte
+
+var configSchema = gojsonschema.NewBytesLoader(schemaJson)
+
+// Schema returns the JSON Schema which config files are validated against.
+func Schema() []byte {
+	return schemaJson
+}
+
+// A FieldError describes a config field which does not match the schema.
+type FieldError struct {
+	Field       string // The field's path of JSON keys, e.g. api.port
+	Description string
+}
+
+// A SchemaError lists each config field which does not match the schema.
+type SchemaError struct {
+	Errors []FieldError
+}
+
+func (e *SchemaError) Error() string {
+	descriptions := make([]string, 0, len(e.Errors))
+	for _, fieldErr := range e.Errors {
+		descriptions = append(descriptions, fmt.Sprintf("%s: %s", fieldErr.Field, fieldErr.Description))
+	}
+	return "config does not match schema: " + strings.Join(descriptions, "; ")
+}
+
+// validateSchema validates a JSON document against the config schema,
+// returning a SchemaError describing every mismatched field if it is invalid.
+func validateSchema(document []byte) error {
+	result, err := gojsonschema.Validate(configSchema, gojsonschema.NewBytesLoader(document))
+	if err != nil {
+		return err
+	}
+	if result.Valid() {
+		return nil
+	}
+
+	schemaErr := &SchemaError{}
+	for _, resultErr := range result.Errors() {
+		field := resultErr.Field()
+		if property, ok := resultErr.Details()["property"].(string); ok {
+			// Required and unknown properties are reported against their parent
+			if field == gojsonschema.STRING_CONTEXT_ROOT {
+				field = property
+			} else {
+				field += "." + property
+			}
+		}
+		schemaErr.Errors = append(schemaErr.Errors, FieldError{
+			Field:       field,
+			Description: resultErr.Description(),
+		})
+	}
+	return schemaErr
+}
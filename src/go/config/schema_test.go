@@ -0,0 +1,53 @@
+package config
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type schemaProperty struct {
+	Properties           map[string]schemaProperty `json:"properties"`
+	Items                *schemaProperty           `json:"items"`
+	AdditionalProperties json.RawMessage           `json:"additionalProperties"`
+}
+
+func TestSchemaDescribesEveryField(t *testing.T) {
+	var schema schemaProperty
+	err := json.Unmarshal(Schema(), &schema)
+	if err != nil {
+		t.Fatalf("Failed to parse schema: %s", err.Error())
+	}
+	assertSchemaDescribesStruct(t, reflect.TypeOf(Config{}), schema, "")
+}
+
+func assertSchemaDescribesStruct(t *testing.T, structType reflect.Type, schema schemaProperty, path string) {
+	for i := 0; i < structType.NumField(); i++ {
+		key := strings.Split(structType.Field(i).Tag.Get("json"), ",")[0]
+		fieldPath := strings.TrimPrefix(path+"."+key, ".")
+
+		property, ok := schema.Properties[key]
+		if !ok {
+			t.Fatalf("Schema does not describe config field %s", fieldPath)
+		}
+
+		fieldType := structType.Field(i).Type
+		if fieldType.Kind() == reflect.Slice && property.Items != nil {
+			fieldType = fieldType.Elem()
+			property = *property.Items
+		}
+		if fieldType.Kind() == reflect.Map {
+			var valueProperty schemaProperty
+			err := json.Unmarshal(property.AdditionalProperties, &valueProperty)
+			if err != nil {
+				t.Fatalf("Schema does not describe values of config field %s", fieldPath)
+			}
+			fieldType = fieldType.Elem()
+			property = valueProperty
+		}
+		if fieldType.Kind() == reflect.Struct {
+			assertSchemaDescribesStruct(t, fieldType, property, fieldPath)
+		}
+	}
+}
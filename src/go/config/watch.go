@@ -0,0 +1,73 @@
+package config
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// How often the config file is checked for changes
+const WATCH_INTERVAL = 2 * time.Second
+
+// Watch reloads the config file at configFilepath into live whenever the
+// file changes or a signal is received on reload, until ctx is done.
+//
+// current is the config which live was created from. A file which cannot
+// be parsed or is invalid is logged and ignored, keeping the previous
+// config, as are changes to fields which require a restart.
+func Watch(
+	ctx context.Context,
+	configFilepath string,
+	live *Live,
+	current *Config,
+	interval time.Duration,
+	reload <-chan os.Signal,
+	logger *zap.Logger,
+) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	lastInfo, _ := os.Stat(configFilepath)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+
+		case <-reload:
+			logger.Info("reloading config", zap.String("configFilepath", configFilepath))
+			lastInfo, _ = os.Stat(configFilepath)
+
+		case <-ticker.C:
+			info, err := os.Stat(configFilepath)
+			if err != nil || !hasChanged(lastInfo, info) {
+				continue
+			}
+			lastInfo = info
+		}
+
+		next, err := ParseConfig(configFilepath)
+		if err != nil {
+			logger.Error("failed to reload config, keeping previous config", zap.Error(err))
+			continue
+		}
+
+		reloaded, restartRequired := live.Reload(current, next)
+		if len(reloaded) > 0 {
+			logger.Info("config reloaded", zap.Strings("fields", reloaded))
+		}
+		if len(restartRequired) > 0 {
+			logger.Warn("config fields changed which require a restart", zap.Strings("fields", restartRequired))
+		}
+		current = next
+	}
+}
+
+func hasChanged(previous os.FileInfo, next os.FileInfo) bool {
+	if previous == nil {
+		return true
+	}
+	return !previous.ModTime().Equal(next.ModTime()) || previous.Size() != next.Size()
+}
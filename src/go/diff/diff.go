@@ -0,0 +1,201 @@
+// Package diff compares Advice documents, such as advice for the same request
+// generated before and after prices change.
+package diff
+
+import (
+	"aws-blended-instances-advisor/api/schema"
+	"math"
+	"sort"
+)
+
+const PRICE_TOLERANCE = 1e-9
+
+type instanceTypeKey struct {
+	name string
+	spot bool
+}
+
+type instanceTypeUsage struct {
+	count      int
+	totalPrice float64
+}
+
+// meanPrice returns the mean price per instance of a type, whose instances
+// can be priced differently in each availability zone.
+func (u instanceTypeUsage) meanPrice() float64 {
+	if u.count == 0 {
+		return 0
+	}
+	return u.totalPrice / float64(u.count)
+}
+
+// Compare returns the differences between two Advice documents, for every
+// region and service in either.
+func Compare(before *schema.Advice, after *schema.Advice) schema.AdviceDiff {
+	diff := schema.AdviceDiff{Regions: make(map[string]schema.RegionDiff)}
+
+	regions := make(map[string]bool)
+	for region := range *before {
+		regions[region] = true
+	}
+	for region := range *after {
+		regions[region] = true
+	}
+
+	for region := range regions {
+		beforeAdvice, inBefore := (*before)[region]
+		afterAdvice, inAfter := (*after)[region]
+
+		var beforePtr, afterPtr *schema.RegionAdvice
+		if inBefore {
+			beforePtr = &beforeAdvice
+		}
+		if inAfter {
+			afterPtr = &afterAdvice
+		}
+
+		diff.Regions[region] = CompareRegionAdvice(beforePtr, afterPtr)
+	}
+
+	return diff
+}
+
+// CompareRegionAdvice returns the differences between two RegionAdvices for
+// the same region, either of which may be nil if the region was not advised.
+func CompareRegionAdvice(before *schema.RegionAdvice, after *schema.RegionAdvice) schema.RegionDiff {
+	if before == nil {
+		before = &schema.RegionAdvice{}
+	}
+	if after == nil {
+		after = &schema.RegionAdvice{}
+	}
+
+	diff := schema.RegionDiff{
+		ScoreBefore: before.Score,
+		ScoreAfter:  after.Score,
+		ScoreDelta:  after.Score - before.Score,
+		PriceBefore: before.PricePerHour(),
+		PriceAfter:  after.PricePerHour(),
+		Services:    make(map[string]schema.ServiceDiff),
+	}
+	diff.PriceDelta = diff.PriceAfter - diff.PriceBefore
+
+	services := make(map[string]bool)
+	for svc := range before.Assignments.ServicesToInstances {
+		services[svc] = true
+	}
+	for svc := range after.Assignments.ServicesToInstances {
+		services[svc] = true
+	}
+
+	servicesChanged := false
+	for svc := range services {
+		serviceDiff := compareService(svc, before, after)
+		if serviceDiff.Status != schema.DiffUnchanged {
+			servicesChanged = true
+		}
+		diff.Services[svc] = serviceDiff
+	}
+
+	changed := servicesChanged || priceChanged(diff.PriceDelta)
+	diff.Status = getStatus(len(before.Instances) > 0, len(after.Instances) > 0, changed)
+	return diff
+}
+
+func compareService(svc string, before *schema.RegionAdvice, after *schema.RegionAdvice) schema.ServiceDiff {
+	beforeUsage, beforePrice := getInstanceTypeUsage(svc, before)
+	afterUsage, afterPrice := getInstanceTypeUsage(svc, after)
+
+	keys := make(map[instanceTypeKey]bool)
+	for key := range beforeUsage {
+		keys[key] = true
+	}
+	for key := range afterUsage {
+		keys[key] = true
+	}
+
+	diff := schema.ServiceDiff{
+		PriceBefore:   beforePrice,
+		PriceAfter:    afterPrice,
+		PriceDelta:    afterPrice - beforePrice,
+		InstanceTypes: []schema.InstanceTypeDiff{},
+	}
+
+	typesChanged := false
+	for key := range keys {
+		beforeType, inBefore := beforeUsage[key]
+		afterType, inAfter := afterUsage[key]
+
+		changed := beforeType.count != afterType.count || priceChanged(afterType.meanPrice()-beforeType.meanPrice())
+		typeDiff := schema.InstanceTypeDiff{
+			Status:      getStatus(inBefore, inAfter, changed),
+			Name:        key.name,
+			Spot:        key.spot,
+			CountBefore: beforeType.count,
+			CountAfter:  afterType.count,
+			PriceBefore: beforeType.meanPrice(),
+			PriceAfter:  afterType.meanPrice(),
+		}
+		if typeDiff.Status != schema.DiffUnchanged {
+			typesChanged = true
+		}
+		diff.InstanceTypes = append(diff.InstanceTypes, typeDiff)
+	}
+	sortInstanceTypeDiffs(diff.InstanceTypes)
+
+	_, inBefore := before.Assignments.ServicesToInstances[svc]
+	_, inAfter := after.Assignments.ServicesToInstances[svc]
+	diff.Status = getStatus(inBefore, inAfter, typesChanged || priceChanged(diff.PriceDelta))
+
+	return diff
+}
+
+// getInstanceTypeUsage counts the instances of each type assigned to a
+// service, returning the counts and the service's share of their price.
+func getInstanceTypeUsage(svc string, advice *schema.RegionAdvice) (map[instanceTypeKey]instanceTypeUsage, float64) {
+	usage := make(map[instanceTypeKey]instanceTypeUsage)
+	totalPrice := 0.0
+
+	for _, inst := range advice.GetAssignedInstancesForService(svc) {
+		key := instanceTypeKey{name: inst.Name, spot: inst.IsSpot()}
+		typeUsage := usage[key]
+		typeUsage.count += 1
+		typeUsage.totalPrice += inst.PricePerHour
+		usage[key] = typeUsage
+
+		sharingCount := len(advice.Assignments.InstancesToServices[inst.Id])
+		if sharingCount > 0 {
+			totalPrice += inst.PricePerHour / float64(sharingCount)
+		}
+	}
+
+	return usage, totalPrice
+}
+
+// priceChanged returns true if a price delta is larger than can be explained
+// by floating point error.
+func priceChanged(delta float64) bool {
+	return math.Abs(delta) > PRICE_TOLERANCE
+}
+
+func getStatus(inBefore bool, inAfter bool, changed bool) schema.DiffStatus {
+	switch {
+	case !inBefore && inAfter:
+		return schema.DiffAdded
+	case inBefore && !inAfter:
+		return schema.DiffRemoved
+	case changed:
+		return schema.DiffChanged
+	default:
+		return schema.DiffUnchanged
+	}
+}
+
+func sortInstanceTypeDiffs(diffs []schema.InstanceTypeDiff) {
+	sort.Slice(diffs, func(i, j int) bool {
+		if diffs[i].Name != diffs[j].Name {
+			return diffs[i].Name < diffs[j].Name
+		}
+		return !diffs[i].Spot && diffs[j].Spot
+	})
+}
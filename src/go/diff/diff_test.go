@@ -0,0 +1,154 @@
+package diff
+
+import (
+	"aws-blended-instances-advisor/api/schema"
+	"math"
+	"testing"
+)
+
+func createRegionAdvice(score float64, assignments map[string][]*schema.Instance) schema.RegionAdvice {
+	advice := schema.RegionAdvice{Score: score}
+	for svc, instances := range assignments {
+		for _, inst := range instances {
+			advice.AddAssignment(svc, inst)
+		}
+	}
+	return advice
+}
+
+func floatsEqual(a float64, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+func TestCompare(t *testing.T) {
+	shared := &schema.Instance{Id: "s", Name: "r5.large", PricePerHour: 0.2}
+
+	before := schema.Advice{
+		"us-east-1": createRegionAdvice(0.5, map[string][]*schema.Instance{
+			"web": {
+				{Id: "0", Name: "m5.large", PricePerHour: 0.1},
+				{Id: "1", Name: "m5.large", PricePerHour: 0.1},
+			},
+			"db":    {shared},
+			"cache": {shared},
+		}),
+		"eu-west-1": createRegionAdvice(0.3, map[string][]*schema.Instance{
+			"web": {{Id: "2", Name: "c5.large", PricePerHour: 0.1}},
+		}),
+	}
+	after := schema.Advice{
+		"us-east-1": createRegionAdvice(0.75, map[string][]*schema.Instance{
+			"web": {
+				{Id: "3", Name: "m5.large", PricePerHour: 0.1},
+				{Id: "4", Name: "m5.large", PricePerHour: 0.03, RevocationProbability: 0.05},
+			},
+			"db":    {shared},
+			"cache": {shared},
+		}),
+		"ap-south-1": createRegionAdvice(0.1, map[string][]*schema.Instance{
+			"web": {{Id: "5", Name: "t3.large", PricePerHour: 0.05}},
+		}),
+	}
+
+	diff := Compare(&before, &after)
+
+	expectedRegionStatuses := map[string]schema.DiffStatus{
+		"us-east-1":  schema.DiffChanged,
+		"eu-west-1":  schema.DiffRemoved,
+		"ap-south-1": schema.DiffAdded,
+	}
+	for region, expected := range expectedRegionStatuses {
+		if got := diff.Regions[region].Status; got != expected {
+			t.Fatalf("Wrong status for region %s. Wanted: %s, got: %s", region, expected, got)
+		}
+	}
+
+	us := diff.Regions["us-east-1"]
+	if !floatsEqual(us.ScoreDelta, 0.25) {
+		t.Fatalf("Wrong score delta. Wanted: 0.25, got: %f", us.ScoreDelta)
+	}
+	if !floatsEqual(us.PriceDelta, -0.07) {
+		t.Fatalf("Wrong price delta. Wanted: -0.07, got: %f", us.PriceDelta)
+	}
+
+	if status := us.Services["db"].Status; status != schema.DiffUnchanged {
+		t.Fatalf("Wrong status for unchanged service. Wanted: %s, got: %s", schema.DiffUnchanged, status)
+	}
+	if price := us.Services["db"].PriceAfter; !floatsEqual(price, 0.1) {
+		t.Fatalf("Wrong price for shared instance. Wanted: 0.1, got: %f", price)
+	}
+
+	web := us.Services["web"]
+	if web.Status != schema.DiffChanged {
+		t.Fatalf("Wrong status for web. Wanted: %s, got: %s", schema.DiffChanged, web.Status)
+	}
+	expectedTypes := []schema.InstanceTypeDiff{
+		{Status: schema.DiffChanged, Name: "m5.large", Spot: false, CountBefore: 2, CountAfter: 1, PriceBefore: 0.1, PriceAfter: 0.1},
+		{Status: schema.DiffAdded, Name: "m5.large", Spot: true, CountBefore: 0, CountAfter: 1, PriceBefore: 0, PriceAfter: 0.03},
+	}
+	if len(web.InstanceTypes) != len(expectedTypes) {
+		t.Fatalf("Wrong number of instance types. Wanted: %d, got: %d", len(expectedTypes), len(web.InstanceTypes))
+	}
+	for i, expected := range expectedTypes {
+		if web.InstanceTypes[i] != expected {
+			t.Fatalf("Wrong instance type diff %d. Wanted: %+v, got: %+v", i, expected, web.InstanceTypes[i])
+		}
+	}
+}
+
+func TestCompareIdentical(t *testing.T) {
+	advice := schema.Advice{
+		"us-east-1": createRegionAdvice(0.5, map[string][]*schema.Instance{
+			"web": {{Id: "0", Name: "m5.large", PricePerHour: 0.1}},
+		}),
+	}
+
+	diff := Compare(&advice, &advice)
+	if status := diff.Regions["us-east-1"].Status; status != schema.DiffUnchanged {
+		t.Fatalf("Wrong status for identical advice. Wanted: %s, got: %s", schema.DiffUnchanged, status)
+	}
+}
+
+func TestCompareInstanceTypePrices(t *testing.T) {
+	tests := map[string]struct {
+		before         []float64
+		after          []float64
+		expectedStatus schema.DiffStatus
+		expectedBefore float64
+		expectedAfter  float64
+	}{
+		"reordered zones":   {before: []float64{0.1, 0.3}, after: []float64{0.3, 0.1}, expectedStatus: schema.DiffUnchanged, expectedBefore: 0.2, expectedAfter: 0.2},
+		"rounding error":    {before: []float64{0.1 + 0.2}, after: []float64{0.3}, expectedStatus: schema.DiffUnchanged, expectedBefore: 0.3, expectedAfter: 0.3},
+		"one zone cheaper":  {before: []float64{0.1, 0.3}, after: []float64{0.1, 0.2}, expectedStatus: schema.DiffChanged, expectedBefore: 0.2, expectedAfter: 0.15},
+		"same last price":   {before: []float64{0.2, 0.1}, after: []float64{0.4, 0.1}, expectedStatus: schema.DiffChanged, expectedBefore: 0.15, expectedAfter: 0.25},
+		"one fewer in zone": {before: []float64{0.1, 0.1}, after: []float64{0.1}, expectedStatus: schema.DiffChanged, expectedBefore: 0.1, expectedAfter: 0.1},
+	}
+
+	createAdvice := func(prices []float64) schema.RegionAdvice {
+		instances := []*schema.Instance{}
+		for i, price := range prices {
+			instances = append(instances, &schema.Instance{Id: string(rune('a' + i)), Name: "m5.large", PricePerHour: price})
+		}
+		return createRegionAdvice(0.5, map[string][]*schema.Instance{"web": instances})
+	}
+
+	for name, test := range tests {
+		before := createAdvice(test.before)
+		after := createAdvice(test.after)
+
+		typeDiff := CompareRegionAdvice(&before, &after).Services["web"].InstanceTypes[0]
+		if typeDiff.Status != test.expectedStatus {
+			t.Fatalf("Wrong status for \"%s\". Wanted: %s, got: %s", name, test.expectedStatus, typeDiff.Status)
+		}
+		if !floatsEqual(typeDiff.PriceBefore, test.expectedBefore) || !floatsEqual(typeDiff.PriceAfter, test.expectedAfter) {
+			t.Fatalf(
+				"Wrong prices for \"%s\". Wanted: %f to %f, got: %f to %f",
+				name,
+				test.expectedBefore,
+				test.expectedAfter,
+				typeDiff.PriceBefore,
+				typeDiff.PriceAfter,
+			)
+		}
+	}
+}
@@ -3,13 +3,21 @@ module aws-blended-instances-advisor
 go 1.17
 
 require (
+	github.com/BurntSushi/toml v1.2.1
 	github.com/aws/aws-sdk-go-v2 v1.11.0
 	github.com/aws/aws-sdk-go-v2/config v1.10.0
 	github.com/aws/aws-sdk-go-v2/credentials v1.6.0
 	github.com/aws/aws-sdk-go-v2/service/ec2 v1.20.0
 	github.com/aws/aws-sdk-go-v2/service/pricing v1.8.0
+	github.com/aws/aws-sdk-go-v2/service/sts v1.9.0
 	github.com/google/uuid v1.3.0
+	github.com/klauspost/compress v1.15.15
+	github.com/xeipuuv/gojsonschema v1.2.0
+	go.etcd.io/bbolt v1.3.7
 	go.uber.org/zap v1.19.1
+	google.golang.org/grpc v1.55.0
+	google.golang.org/protobuf v1.30.0
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
@@ -19,9 +27,15 @@ require (
 	github.com/aws/aws-sdk-go-v2/internal/ini v1.3.0 // indirect
 	github.com/aws/aws-sdk-go-v2/service/internal/presigned-url v1.5.0 // indirect
 	github.com/aws/aws-sdk-go-v2/service/sso v1.6.0 // indirect
-	github.com/aws/aws-sdk-go-v2/service/sts v1.9.0 // indirect
 	github.com/aws/smithy-go v1.9.0 // indirect
+	github.com/golang/protobuf v1.5.3 // indirect
 	github.com/jmespath/go-jmespath v0.4.0 // indirect
+	github.com/xeipuuv/gojsonpointer v0.0.0-20180127040702-4e3ac2762d5f // indirect
+	github.com/xeipuuv/gojsonreference v0.0.0-20180127040603-bd5ef7bd5415 // indirect
 	go.uber.org/atomic v1.9.0 // indirect
 	go.uber.org/multierr v1.7.0 // indirect
+	golang.org/x/net v0.8.0 // indirect
+	golang.org/x/sys v0.6.0 // indirect
+	golang.org/x/text v0.8.0 // indirect
+	google.golang.org/genproto v0.0.0-20230306155012-7f2fa6fef1f4 // indirect
 )
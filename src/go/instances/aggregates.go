@@ -1,6 +1,7 @@
 package instances
 
 import (
+	"aws-blended-instances-advisor/api/schema"
 	"aws-blended-instances-advisor/utils"
 )
 
@@ -41,9 +42,35 @@ func (agg Aggregates) Copy() Aggregates {
 	}
 }
 
+// ToApiSchemaAggregates converts an Aggregates struct to Aggregates suitable
+// for the defined API specification.
+func (agg Aggregates) ToApiSchemaAggregates() schema.Aggregates {
+	return schema.Aggregates{
+		Count: agg.Count,
+
+		MinVcpu:  agg.MinVcpu,
+		MaxVcpu:  agg.MaxVcpu,
+		MeanVcpu: agg.MeanVcpu,
+
+		MinRevocationProbability:  agg.MinRevocationProbability,
+		MaxRevocationProbability:  agg.MaxRevocationProbability,
+		MeanRevocationProbability: agg.MeanRevocationProbability,
+
+		MinPricePerHour:  agg.MinPricePerHour,
+		MaxPricePerHour:  agg.MaxPricePerHour,
+		MeanPricePerHour: agg.MeanPricePerHour,
+	}
+}
+
 // CalculateAggregates calculates aggregates for a slice of instances,
 // returning information in an Aggregate struct.
+//
+// Returns empty aggregates if there are no instances.
 func CalculateAggregates(instances []*Instance) Aggregates {
+	if len(instances) == 0 {
+		return Aggregates{}
+	}
+
 	totalVcpu, minVcpu, maxVcpu := 0, instances[0].Vcpu, instances[0].Vcpu
 
 	totalPricePerHour := 0.0
@@ -0,0 +1,127 @@
+package filter
+
+import (
+	awsTypes "aws-blended-instances-advisor/aws/types"
+	instPkg "aws-blended-instances-advisor/instances"
+	"fmt"
+	"strings"
+)
+
+// A Market describes how an Instance is purchased.
+type Market string
+
+const (
+	AnyMarket      Market = ""
+	SpotMarket     Market = "spot"
+	OnDemandMarket Market = "onDemand"
+)
+
+// NewMarket creates a Market from its string representation.
+//
+// An empty string is treated as AnyMarket, and an error is returned
+// if the string does not match any Market.
+func NewMarket(value string) (Market, error) {
+	switch Market(value) {
+	case AnyMarket, SpotMarket, OnDemandMarket:
+		return Market(value), nil
+	}
+	return AnyMarket, fmt.Errorf("provided value of \"%s\" does not match any market", value)
+}
+
+// A Filter describes which Instances should be selected from a GlobalInfo.
+//
+// Zero values are treated as "no constraint".
+type Filter struct {
+	Regions         []awsTypes.Region
+	Market          Market
+	OperatingSystem string
+	Family          string
+	MinMemory       float64
+	MinVcpu         int
+	MaxVcpu         int
+	MinPrice        float64
+	MaxPrice        float64
+}
+
+// Select returns all Instances in a GlobalInfo which match the Filter, along
+// with a map from each selected Instance's ID to the Market it is offered in.
+func (f Filter) Select(info instPkg.GlobalInfo) ([]*instPkg.Instance, map[string]Market) {
+	regions := f.Regions
+	if len(regions) == 0 {
+		regions = awsTypes.GetAllRegions()
+	}
+
+	selected := []*instPkg.Instance{}
+	markets := make(map[string]Market)
+
+	for _, region := range regions {
+		regionInfo, ok := info.RegionInfoMap[region]
+		if !ok {
+			continue
+		}
+
+		if f.Market != SpotMarket {
+			for _, inst := range f.Apply(regionInfo.PermanentInstances) {
+				selected = append(selected, inst)
+				markets[inst.Id] = OnDemandMarket
+			}
+		}
+		if f.Market != OnDemandMarket {
+			for _, inst := range f.Apply(regionInfo.TransientInstances) {
+				selected = append(selected, inst)
+				markets[inst.Id] = SpotMarket
+			}
+		}
+	}
+
+	return selected, markets
+}
+
+// Apply returns the Instances which match the Filter's instance properties.
+//
+// The Filter's Regions and Market are not considered.
+func (f Filter) Apply(instances []*instPkg.Instance) []*instPkg.Instance {
+	filtered := []*instPkg.Instance{}
+	for _, inst := range instances {
+		if f.Matches(inst) {
+			filtered = append(filtered, inst)
+		}
+	}
+	return filtered
+}
+
+// Matches returns true if the Instance matches the Filter's instance properties,
+// and false otherwise.
+//
+// The Filter's Regions and Market are not considered.
+func (f Filter) Matches(inst *instPkg.Instance) bool {
+	if f.OperatingSystem != "" && !strings.EqualFold(inst.OperatingSystem, f.OperatingSystem) {
+		return false
+	}
+	if f.Family != "" && !strings.EqualFold(Family(inst.Name), f.Family) {
+		return false
+	}
+	if inst.MemoryGb < f.MinMemory {
+		return false
+	}
+	if inst.Vcpu < f.MinVcpu {
+		return false
+	}
+	if f.MaxVcpu > 0 && inst.Vcpu > f.MaxVcpu {
+		return false
+	}
+	if inst.PricePerHour < f.MinPrice {
+		return false
+	}
+	if f.MaxPrice > 0 && inst.PricePerHour > f.MaxPrice {
+		return false
+	}
+	return true
+}
+
+// Family returns the family of an instance type name.
+//
+// Example: m5 for m5.large
+func Family(name string) string {
+	return strings.SplitN(name, ".", 2)[0]
+}
@@ -0,0 +1,98 @@
+package filter
+
+import (
+	awsTypes "aws-blended-instances-advisor/aws/types"
+	. "aws-blended-instances-advisor/instances"
+	"reflect"
+	"testing"
+)
+
+func TestMatches(t *testing.T) {
+	inst := &Instance{
+		Name:            "m5.large",
+		MemoryGb:        8,
+		Vcpu:            2,
+		OperatingSystem: "Linux",
+		PricePerHour:    0.096,
+	}
+
+	tests := map[string]struct {
+		filter   Filter
+		expected bool
+	}{
+		"empty filter":        {filter: Filter{}, expected: true},
+		"matching OS":         {filter: Filter{OperatingSystem: "linux"}, expected: true},
+		"other OS":            {filter: Filter{OperatingSystem: "Windows"}, expected: false},
+		"matching family":     {filter: Filter{Family: "m5"}, expected: true},
+		"other family":        {filter: Filter{Family: "m5a"}, expected: false},
+		"enough memory":       {filter: Filter{MinMemory: 8}, expected: true},
+		"not enough memory":   {filter: Filter{MinMemory: 8.5}, expected: false},
+		"vCPU in range":       {filter: Filter{MinVcpu: 2, MaxVcpu: 4}, expected: true},
+		"vCPU below range":    {filter: Filter{MinVcpu: 4}, expected: false},
+		"vCPU above range":    {filter: Filter{MaxVcpu: 1}, expected: false},
+		"price in range":      {filter: Filter{MinPrice: 0.05, MaxPrice: 0.1}, expected: true},
+		"price below range":   {filter: Filter{MinPrice: 0.1}, expected: false},
+		"price above range":   {filter: Filter{MaxPrice: 0.09}, expected: false},
+		"all fields matching": {filter: Filter{OperatingSystem: "Linux", Family: "m5", MinMemory: 4, MinVcpu: 1, MaxVcpu: 2, MaxPrice: 1}, expected: true},
+	}
+
+	for name, test := range tests {
+		if got := test.filter.Matches(inst); got != test.expected {
+			t.Fatalf("Wrong result for \"%s\". Wanted: %t, got: %t", name, test.expected, got)
+		}
+	}
+}
+
+func TestSelect(t *testing.T) {
+	usOnDemand := &Instance{Id: "0", Name: "m5.large", Region: awsTypes.UsEast1, MemoryGb: 8}
+	usSpot := &Instance{Id: "1", Name: "m5.large", Region: awsTypes.UsEast1, MemoryGb: 8}
+	euOnDemand := &Instance{Id: "2", Name: "c5.large", Region: awsTypes.EuWest1, MemoryGb: 4}
+	euSpot := &Instance{Id: "3", Name: "c5.large", Region: awsTypes.EuWest1, MemoryGb: 4}
+
+	info := GlobalInfo{
+		RegionInfoMap: RegionInfoMap{
+			awsTypes.UsEast1: {PermanentInstances: []*Instance{usOnDemand}, TransientInstances: []*Instance{usSpot}},
+			awsTypes.EuWest1: {PermanentInstances: []*Instance{euOnDemand}, TransientInstances: []*Instance{euSpot}},
+		},
+	}
+
+	tests := map[string]struct {
+		filter   Filter
+		expected []*Instance
+	}{
+		"all":         {filter: Filter{}, expected: []*Instance{usOnDemand, usSpot, euOnDemand, euSpot}},
+		"one region":  {filter: Filter{Regions: []awsTypes.Region{awsTypes.EuWest1}}, expected: []*Instance{euOnDemand, euSpot}},
+		"spot only":   {filter: Filter{Market: SpotMarket}, expected: []*Instance{usSpot, euSpot}},
+		"on-demand":   {filter: Filter{Market: OnDemandMarket}, expected: []*Instance{usOnDemand, euOnDemand}},
+		"with memory": {filter: Filter{MinMemory: 6}, expected: []*Instance{usOnDemand, usSpot}},
+	}
+
+	for name, test := range tests {
+		got, markets := test.filter.Select(info)
+		if !reflect.DeepEqual(got, test.expected) {
+			t.Fatalf("Wrong instances for \"%s\". Wanted: %+v, got: %+v", name, test.expected, got)
+		}
+		for _, inst := range got {
+			market := OnDemandMarket
+			if inst == usSpot || inst == euSpot {
+				market = SpotMarket
+			}
+			if markets[inst.Id] != market {
+				t.Fatalf("Wrong market for instance %s in \"%s\". Wanted: %s, got: %s", inst.Id, name, market, markets[inst.Id])
+			}
+		}
+	}
+}
+
+func TestFamily(t *testing.T) {
+	tests := map[string]string{
+		"m5.large":      "m5",
+		"c6gn.16xlarge": "c6gn",
+		"unknown":       "unknown",
+	}
+	for name, expected := range tests {
+		if got := Family(name); got != expected {
+			t.Fatalf("Wrong family for %s. Wanted: %s, got: %s", name, expected, got)
+		}
+	}
+}
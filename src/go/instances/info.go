@@ -81,6 +81,54 @@ func CreateRegionInfo(permanentInstances []*Instance, transientInstances []*Inst
 	}
 }
 
+// WithPermanentInstances returns a copy of the RegionInfo with its permanent
+// instances replaced, recalculating only the aggregates they affect.
+func (info RegionInfo) WithPermanentInstances(permanentInstances []*Instance) RegionInfo {
+	info.PermanentInstances = permanentInstances
+	info.PermanentAggregates = CalculateAggregates(permanentInstances)
+	info.RegionAggregates = combineRegionAggregates(info.PermanentAggregates, info.TransientAggregates)
+	return info
+}
+
+// WithTransientInstances returns a copy of the RegionInfo with its transient
+// instances replaced, recalculating only the aggregates they affect.
+func (info RegionInfo) WithTransientInstances(transientInstances []*Instance) RegionInfo {
+	info.TransientInstances = transientInstances
+	info.TransientAggregates = CalculateAggregates(transientInstances)
+	info.RegionAggregates = combineRegionAggregates(info.PermanentAggregates, info.TransientAggregates)
+	return info
+}
+
+// combineRegionAggregates combines permanent and transient aggregates,
+// ignoring either if they describe no instances.
+func combineRegionAggregates(permanentAggs Aggregates, transientAggs Aggregates) Aggregates {
+	aggs := []Aggregates{}
+	for _, agg := range []Aggregates{permanentAggs, transientAggs} {
+		if agg.Count > 0 {
+			aggs = append(aggs, agg)
+		}
+	}
+	return CombineAggregates(aggs)
+}
+
+// WithRegions returns a copy of the GlobalInfo with the info for the given
+// regions replaced. Global aggregates are combined from each region's
+// existing aggregates, so unchanged regions' instances are not revisited.
+func (info *GlobalInfo) WithRegions(updated RegionInfoMap) GlobalInfo {
+	regionInfoMap := make(RegionInfoMap, len(info.RegionInfoMap)+len(updated))
+	for region, regionInfo := range info.RegionInfoMap {
+		regionInfoMap[region] = regionInfo
+	}
+	for region, regionInfo := range updated {
+		regionInfoMap[region] = regionInfo
+	}
+
+	return GlobalInfo{
+		RegionInfoMap:    regionInfoMap,
+		GlobalAggregates: CalculateGlobalAggregates(regionInfoMap),
+	}
+}
+
 // CalculateGlobalAggregates calculates aggregates for all instances in a RegionInfoMap.
 func CalculateGlobalAggregates(regionInfoMap RegionInfoMap) Aggregates {
 	allAggs := []Aggregates{}
@@ -0,0 +1,100 @@
+package instances
+
+import (
+	awsTypes "aws-blended-instances-advisor/aws/types"
+	"aws-blended-instances-advisor/utils"
+	"testing"
+)
+
+type withRegionsTest struct {
+	permanent map[awsTypes.Region][]*Instance
+	transient map[awsTypes.Region][]*Instance
+	updated   map[awsTypes.Region][]*Instance
+}
+
+func TestWithRegions(t *testing.T) {
+	regions := []awsTypes.Region{awsTypes.UsEast1, awsTypes.EuWest1}
+
+	tests := map[string]withRegionsTest{
+		"update one region": {
+			permanent: map[awsTypes.Region][]*Instance{
+				awsTypes.UsEast1: {{Vcpu: 4, PricePerHour: 0.1}},
+				awsTypes.EuWest1: {{Vcpu: 8, PricePerHour: 0.2}},
+			},
+			transient: map[awsTypes.Region][]*Instance{
+				awsTypes.UsEast1: {{Vcpu: 4, PricePerHour: 0.05, RevocationProbability: 0.1}},
+				awsTypes.EuWest1: {{Vcpu: 8, PricePerHour: 0.08, RevocationProbability: 0.2}},
+			},
+			updated: map[awsTypes.Region][]*Instance{
+				awsTypes.UsEast1: {
+					{Vcpu: 2, PricePerHour: 0.01, RevocationProbability: 0.05},
+					{Vcpu: 16, PricePerHour: 0.5, RevocationProbability: 0.3},
+				},
+			},
+		},
+		"update every region": {
+			permanent: map[awsTypes.Region][]*Instance{
+				awsTypes.UsEast1: {{Vcpu: 4, PricePerHour: 0.1}},
+				awsTypes.EuWest1: {{Vcpu: 8, PricePerHour: 0.2}},
+			},
+			transient: map[awsTypes.Region][]*Instance{
+				awsTypes.UsEast1: {{Vcpu: 4, PricePerHour: 0.05, RevocationProbability: 0.1}},
+				awsTypes.EuWest1: {{Vcpu: 8, PricePerHour: 0.08, RevocationProbability: 0.2}},
+			},
+			updated: map[awsTypes.Region][]*Instance{
+				awsTypes.UsEast1: {{Vcpu: 32, PricePerHour: 1.5, RevocationProbability: 0.15}},
+				awsTypes.EuWest1: {{Vcpu: 1, PricePerHour: 0.001, RevocationProbability: 0}},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		info := CreateGlobalInfo(test.permanent, test.transient, regions)
+
+		updatedRegions := make(RegionInfoMap)
+		expectedTransient := make(map[awsTypes.Region][]*Instance)
+		for region, instances := range test.transient {
+			expectedTransient[region] = instances
+		}
+		for region, instances := range test.updated {
+			updatedRegions[region] = info.RegionInfoMap[region].WithTransientInstances(instances)
+			expectedTransient[region] = instances
+		}
+
+		got := info.WithRegions(updatedRegions)
+		expected := CreateGlobalInfo(test.permanent, expectedTransient, regions)
+
+		err := got.Validate()
+		if err != nil {
+			t.Fatalf("updated info is invalid for test \"%s\": %s", name, err.Error())
+		}
+		assertAggregatesEqual(t, name+" (global)", got.GlobalAggregates, expected.GlobalAggregates)
+		for _, region := range regions {
+			assertAggregatesEqual(
+				t,
+				name+" ("+region.CodeString()+")",
+				got.RegionInfoMap[region].RegionAggregates,
+				expected.RegionInfoMap[region].RegionAggregates,
+			)
+		}
+
+		if len(info.RegionInfoMap[awsTypes.UsEast1].TransientInstances) != len(test.transient[awsTypes.UsEast1]) {
+			t.Fatalf("original info was modified for test \"%s\"", name)
+		}
+	}
+}
+
+func assertAggregatesEqual(t *testing.T, name string, got Aggregates, expected Aggregates) {
+	if got.Count != expected.Count ||
+		got.MinVcpu != expected.MinVcpu ||
+		got.MaxVcpu != expected.MaxVcpu ||
+		!utils.FloatsEqual(got.MeanVcpu, expected.MeanVcpu) ||
+		!utils.FloatsEqual(got.MinRevocationProbability, expected.MinRevocationProbability) ||
+		!utils.FloatsEqual(got.MaxRevocationProbability, expected.MaxRevocationProbability) ||
+		!utils.FloatsEqual(got.MeanRevocationProbability, expected.MeanRevocationProbability) ||
+		!utils.FloatsEqual(got.MinPricePerHour, expected.MinPricePerHour) ||
+		!utils.FloatsEqual(got.MaxPricePerHour, expected.MaxPricePerHour) ||
+		!utils.FloatsEqual(got.MeanPricePerHour, expected.MeanPricePerHour) {
+		t.Fatalf("incorrect aggregates for test \"%s\": %+v (expected %+v)", name, got, expected)
+	}
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"aws-blended-instances-advisor/api/schema"
+	awsApi "aws-blended-instances-advisor/aws/api"
+	"aws-blended-instances-advisor/cache"
+	"aws-blended-instances-advisor/config"
+	instPkg "aws-blended-instances-advisor/instances"
+	"aws-blended-instances-advisor/ranking"
+	"aws-blended-instances-advisor/render"
+	"aws-blended-instances-advisor/utils"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"go.uber.org/zap"
+)
+
+func runAdvise(args []string) error {
+	fs, clf := newFlagSet("advise", "advise -f request.json [flags]")
+	requestFilepath := fs.String("f", "", "the path to an advise request JSON file (\"-\" for stdin)")
+	format := fs.String("o", string(render.JsonFormat), "the output format (json, table or markdown)")
+	asOfValue := fs.String("as-of", "", "generate advice using the instances snapshot at this RFC 3339 time")
+	fs.Parse(args)
+
+	if *requestFilepath == "" {
+		fs.Usage()
+		return errors.New("no request file given")
+	}
+	outputFormat, err := render.NewFormat(*format)
+	if err != nil {
+		return err
+	}
+	asOf, err := parseAsOf(*asOfValue)
+	if err != nil {
+		return err
+	}
+
+	logger, syncLogger := createCliLogger(clf.DebugMode)
+	defer syncLogger()
+
+	var req schema.AdviseRequest
+	err = readJsonInput(*requestFilepath, &req)
+	if err != nil {
+		return utils.PrependToError(err, "could not read request")
+	}
+	if asOf != nil {
+		req.AsOf = asOf
+	}
+	cfg := parseAndLogConfig(clf.ConfigFilepath, logger)
+	err = applyConfigDefaults(&req, cfg)
+	if err != nil {
+		return utils.PrependToError(err, "invalid request")
+	}
+	err = req.Validate()
+	if err != nil {
+		return utils.PrependToError(err, "invalid request")
+	}
+	schema.OrderServicesByDecreasingMemory(req.Services)
+
+	info, err := loadInstances(cfg, req.AsOf, logger)
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGTERM, syscall.SIGINT)
+	defer stop()
+
+	advice, err := advise(ctx, *info, req.Advisor, req.Services, req.Options, nil, logger)
+	if err != nil {
+		return utils.PrependToError(err, "could not generate advice")
+	}
+
+	resp := &schema.AdviseResponse{Regions: *advice, Ranking: ranking.Summarise(advice, req.Options.RankBy)}
+	return render.Advice(os.Stdout, resp, outputFormat)
+}
+
+// loadCachedInstances returns the instances stored in the configured cache,
+// without contacting the AWS API.
+func loadCachedInstances(cfg *config.Config, logger *zap.Logger) (*instPkg.GlobalInfo, error) {
+	c, err := cache.Open(cfg.CacheConfig.Dirpath, cfg.CacheConfig.Options(), false)
+	if err != nil {
+		return nil, utils.PrependToError(err, "could not read cache (run fetch first)")
+	}
+	defer c.Close()
+
+	info, err := awsApi.GetInstancesAndInfoFromCache(c)
+	if err != nil {
+		return nil, utils.PrependToError(err, "could not load cached instances (run fetch first)")
+	}
+
+	return info, nil
+}
+
+// readJsonInput parses JSON from the file at filepath, or from stdin when
+// filepath is "-", into v.
+func readJsonInput(filepath string, v interface{}) error {
+	var body []byte
+	var err error
+
+	if filepath == "-" {
+		body, err = io.ReadAll(os.Stdin)
+	} else {
+		body, err = utils.FileToBytes(filepath)
+	}
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(body, v)
+}
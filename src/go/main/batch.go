@@ -0,0 +1,92 @@
+package main
+
+import (
+	"aws-blended-instances-advisor/api/schema"
+	"aws-blended-instances-advisor/batch"
+	instPkg "aws-blended-instances-advisor/instances"
+	"aws-blended-instances-advisor/render"
+	"aws-blended-instances-advisor/utils"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+func runBatch(args []string) error {
+	fs, clf := newFlagSet("batch", "batch -f batch.json [flags]")
+	requestFilepath := fs.String("f", "", "the path to a batch advise request JSON file (\"-\" for stdin)")
+	format := fs.String("o", string(render.JsonFormat), "the output format (json, table or markdown)")
+	fs.Parse(args)
+
+	if *requestFilepath == "" {
+		fs.Usage()
+		return errors.New("no request file given")
+	}
+	outputFormat, err := render.NewFormat(*format)
+	if err != nil {
+		return err
+	}
+
+	logger, syncLogger := createCliLogger(clf.DebugMode)
+	defer syncLogger()
+
+	var req schema.BatchAdviseRequest
+	err = readJsonInput(*requestFilepath, &req)
+	if err != nil {
+		return utils.PrependToError(err, "could not read request")
+	}
+	cfg := parseAndLogConfig(clf.ConfigFilepath, logger)
+	for i := range req.Scenarios {
+		err = applyConfigDefaults(&req.Scenarios[i].Request, cfg)
+		if err != nil {
+			return utils.PrependToError(err, fmt.Sprintf("invalid request: scenario %s", req.Scenarios[i].Name))
+		}
+	}
+	err = req.Validate()
+	if err != nil {
+		return utils.PrependToError(err, "invalid request")
+	}
+
+	// Instances are loaded before advising, so that each snapshot is read once
+	// rather than by scenarios concurrently
+	infos := make(map[string]*instPkg.GlobalInfo)
+	for _, scenario := range req.Scenarios {
+		schema.OrderServicesByDecreasingMemory(scenario.Request.Services)
+
+		key := instancesKey(scenario.Request.AsOf)
+		if _, ok := infos[key]; ok {
+			continue
+		}
+		info, err := loadInstances(cfg, scenario.Request.AsOf, logger)
+		if err != nil {
+			return utils.PrependToError(err, fmt.Sprintf("scenario %s", scenario.Name))
+		}
+		infos[key] = info
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGTERM, syscall.SIGINT)
+	defer stop()
+
+	resp := batch.Run(
+		ctx,
+		req.Scenarios,
+		func(ctx context.Context, scenarioReq schema.AdviseRequest) (*schema.Advice, error) {
+			return advise(ctx, *infos[instancesKey(scenarioReq.AsOf)], scenarioReq.Advisor, scenarioReq.Services, scenarioReq.Options, nil, logger)
+		},
+		logger,
+	)
+
+	return render.BatchAdvice(os.Stdout, resp, outputFormat)
+}
+
+// instancesKey identifies the instances used for advice as of asOf, which
+// are the latest cached instances when asOf is nil.
+func instancesKey(asOf *time.Time) string {
+	if asOf == nil {
+		return ""
+	}
+	return asOf.UTC().Format(time.RFC3339Nano)
+}
@@ -0,0 +1,166 @@
+package main
+
+import (
+	"aws-blended-instances-advisor/cache"
+	"aws-blended-instances-advisor/utils"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"text/tabwriter"
+	"time"
+)
+
+func runCache(args []string) error {
+	fs, clf := newFlagSet("cache", "cache <list|inspect FILE|invalidate FILE|purge|clear> [flags]")
+	showContent := fs.Bool("content", false, "inspect: also print the file's contents")
+	purgeAll := fs.Bool("all", false, "purge: remove every entry, not only invalid ones")
+	fs.Parse(args)
+
+	// Allow flags after the action and its arguments
+	positional := []string{}
+	for fs.NArg() > 0 {
+		positional = append(positional, fs.Arg(0))
+		fs.Parse(fs.Args()[1:])
+	}
+	if len(positional) == 0 {
+		fs.Usage()
+		return errors.New("no cache action given")
+	}
+	action, actionArgs := positional[0], positional[1:]
+
+	logger, syncLogger := createCliLogger(clf.DebugMode)
+	defer syncLogger()
+
+	config := parseAndLogConfig(clf.ConfigFilepath, logger)
+
+	c, err := cache.Open(config.CacheConfig.Dirpath, config.CacheConfig.Options(), false)
+	if err != nil {
+		return utils.PrependToError(err, "could not open cache")
+	}
+	defer c.Close()
+
+	switch action {
+	case "list":
+		return listCacheEntries(c)
+
+	case "inspect":
+		if len(actionArgs) != 1 {
+			return errors.New("inspect expects exactly one file")
+		}
+		return inspectCacheEntry(c, actionArgs[0], *showContent)
+
+	case "invalidate":
+		if len(actionArgs) == 0 {
+			return errors.New("invalidate expects at least one file")
+		}
+		for _, file := range actionArgs {
+			err = c.Invalidate(file)
+			if err != nil {
+				return utils.PrependToError(err, "could not invalidate cache entry")
+			}
+			fmt.Fprintf(os.Stdout, "Invalidated %s\n", file)
+		}
+		return nil
+
+	case "purge":
+		if *purgeAll {
+			return clearCache(c, config.CacheConfig.Dirpath)
+		}
+		evicted, err := cache.EvictInvalid(c, 0)
+		if err != nil {
+			return utils.PrependToError(err, "could not purge invalid cache entries")
+		}
+		fmt.Fprintf(os.Stdout, "Purged %d invalid entries from %s\n", len(evicted), config.CacheConfig.Dirpath)
+		return nil
+
+	case "clear":
+		return clearCache(c, config.CacheConfig.Dirpath)
+
+	default:
+		fs.Usage()
+		return fmt.Errorf("unknown cache action %s", action)
+	}
+}
+
+func listCacheEntries(c cache.Cache) error {
+	entries, err := c.List()
+	if err != nil {
+		return utils.PrependToError(err, "could not list cache entries")
+	}
+
+	files := []string{}
+	for file := range entries {
+		files = append(files, file)
+	}
+	sort.Strings(files)
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "FILE\tSET AT\tINVALID FROM\tLAST USED\tSIZE (BYTES)\tCOMPRESSION\tVALID")
+	for _, file := range files {
+		entry := entries[file]
+		fmt.Fprintf(
+			tw,
+			"%s\t%s\t%s\t%s\t%d\t%s\t%t\n",
+			file,
+			entry.SetDate.Format(time.RFC3339),
+			entry.InvalidationDate.Format(time.RFC3339),
+			entry.LastUsed().Format(time.RFC3339),
+			entry.SizeBytes,
+			formatCompression(entry.Compression),
+			c.IsValid(file),
+		)
+	}
+	return tw.Flush()
+}
+
+func inspectCacheEntry(c cache.Cache, file string, showContent bool) error {
+	entry, err := c.GetEntry(file)
+	if err != nil {
+		return err
+	}
+
+	// Reading the contents checks them against the entry's checksum
+	contents, readErr := c.GetIgnoringValidity(file)
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "File\t%s\n", entry.Filename)
+	fmt.Fprintf(tw, "Set at\t%s\n", entry.SetDate.Format(time.RFC3339))
+	fmt.Fprintf(tw, "Invalid from\t%s\n", entry.InvalidationDate.Format(time.RFC3339))
+	fmt.Fprintf(tw, "Last used\t%s\n", entry.LastUsed().Format(time.RFC3339))
+	fmt.Fprintf(tw, "Valid\t%t\n", c.IsValid(file))
+	fmt.Fprintf(tw, "Stored size (bytes)\t%d\n", entry.SizeBytes)
+	fmt.Fprintf(tw, "Compression\t%s\n", formatCompression(entry.Compression))
+	fmt.Fprintf(tw, "Checksum\t%s\n", entry.Checksum)
+	if readErr != nil {
+		fmt.Fprintf(tw, "Readable\tfalse (%s)\n", readErr.Error())
+	} else {
+		fmt.Fprintf(tw, "Readable\ttrue\n")
+		fmt.Fprintf(tw, "Size (bytes)\t%d\n", len(contents))
+	}
+	err = tw.Flush()
+	if err != nil {
+		return err
+	}
+
+	if showContent && readErr == nil {
+		fmt.Fprintf(os.Stdout, "\n%s\n", contents)
+	}
+	return nil
+}
+
+func clearCache(c cache.Cache, dirpath string) error {
+	err := c.Clear()
+	if err != nil {
+		return utils.PrependToError(err, "could not clear cache")
+	}
+	fmt.Fprintf(os.Stdout, "Cleared cache in %s\n", dirpath)
+	return nil
+}
+
+func formatCompression(compression cache.Compression) string {
+	if compression == "" {
+		return string(cache.NoCompression)
+	}
+	return string(compression)
+}
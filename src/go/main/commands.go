@@ -0,0 +1,60 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// A command is a subcommand of the program, run with the arguments
+// following its name.
+type command struct {
+	description string
+	run         func(args []string) error
+}
+
+func getCommands() map[string]command {
+	return map[string]command{
+		"serve":     {description: "run the HTTP (and gRPC) API", run: runServe},
+		"advise":    {description: "generate advice for a request using the cached instances", run: runAdvise},
+		"fetch":     {description: "fetch instances from AWS and store them in the cache", run: runFetch},
+		"score":     {description: "score existing advice against the cached instances", run: runScore},
+		"instances": {description: "query the cached instances", run: runInstances},
+		"cache":     {description: "list, inspect, invalidate or purge cached files", run: runCache},
+		"diff":      {description: "compare two pieces of advice", run: runDiff},
+		"batch":     {description: "generate and compare advice for several scenarios", run: runBatch},
+	}
+}
+
+// runCommand runs the subcommand named by the first argument. The API is
+// served when no subcommand is given, so that flags alone behave as before
+// subcommands were introduced.
+func runCommand(args []string) error {
+	if len(args) == 0 || strings.HasPrefix(args[0], "-") {
+		return runServe(args)
+	}
+
+	commands := getCommands()
+	cmd, ok := commands[args[0]]
+	if !ok {
+		printUsage(commands)
+		return fmt.Errorf("unknown command %s", args[0])
+	}
+
+	return cmd.run(args[1:])
+}
+
+func printUsage(commands map[string]command) {
+	names := []string{}
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fmt.Fprintf(os.Stderr, "Usage: %s <command> [flags]\n\nCommands:\n", os.Args[0])
+	for _, name := range names {
+		fmt.Fprintf(os.Stderr, "  %-10s %s\n", name, commands[name].description)
+	}
+	fmt.Fprintf(os.Stderr, "\nRun %s <command> -h for a command's flags.\n", os.Args[0])
+}
@@ -0,0 +1,124 @@
+package main
+
+import (
+	"aws-blended-instances-advisor/api/schema"
+	"aws-blended-instances-advisor/config"
+	"aws-blended-instances-advisor/diff"
+	"aws-blended-instances-advisor/render"
+	"aws-blended-instances-advisor/utils"
+	"context"
+	"errors"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+func runDiff(args []string) error {
+	fs, clf := newFlagSet("diff", "diff (-before advice.json | -before-as-of time) (-after advice.json | -f request.json) [flags]")
+	beforeFilepath := fs.String("before", "", "the path to the advice JSON file to compare from")
+	afterFilepath := fs.String("after", "", "the path to the advice JSON file to compare to")
+	requestFilepath := fs.String("f", "", "the path to an advise request JSON file to generate the advice to compare to")
+	format := fs.String("o", string(render.JsonFormat), "the output format (json, table or markdown)")
+	asOfValue := fs.String("as-of", "", "generate the advice to compare to using the instances snapshot at this RFC 3339 time")
+	beforeAsOfValue := fs.String("before-as-of", "", "generate the advice to compare from using the instances snapshot at this RFC 3339 time")
+	fs.Parse(args)
+
+	if (*beforeFilepath == "") == (*beforeAsOfValue == "") || (*afterFilepath == "") == (*requestFilepath == "") {
+		fs.Usage()
+		return errors.New("one of a before advice file or time, and one of an after advice file or a request file, must be given")
+	}
+	if *beforeAsOfValue != "" && *requestFilepath == "" {
+		fs.Usage()
+		return errors.New("a request file must be given to generate the before advice")
+	}
+	outputFormat, err := render.NewFormat(*format)
+	if err != nil {
+		return err
+	}
+	asOf, err := parseAsOf(*asOfValue)
+	if err != nil {
+		return err
+	}
+	beforeAsOf, err := parseAsOf(*beforeAsOfValue)
+	if err != nil {
+		return err
+	}
+
+	logger, syncLogger := createCliLogger(clf.DebugMode)
+	defer syncLogger()
+
+	before := &schema.Advice{}
+	if *beforeFilepath != "" {
+		err = readJsonInput(*beforeFilepath, before)
+		if err != nil {
+			return utils.PrependToError(err, "could not read before advice")
+		}
+	}
+
+	after := &schema.Advice{}
+	if *afterFilepath != "" {
+		err = readJsonInput(*afterFilepath, after)
+		if err != nil {
+			return utils.PrependToError(err, "could not read after advice")
+		}
+	}
+
+	if *requestFilepath != "" {
+		var req schema.AdviseRequest
+		err = readJsonInput(*requestFilepath, &req)
+		if err != nil {
+			return utils.PrependToError(err, "could not read request")
+		}
+		if asOf != nil {
+			req.AsOf = asOf
+		}
+		cfg := parseAndLogConfig(clf.ConfigFilepath, logger)
+		err = applyConfigDefaults(&req, cfg)
+		if err != nil {
+			return utils.PrependToError(err, "invalid request")
+		}
+		err = req.Validate()
+		if err != nil {
+			return utils.PrependToError(err, "invalid request")
+		}
+		schema.OrderServicesByDecreasingMemory(req.Services)
+
+		ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGTERM, syscall.SIGINT)
+		defer stop()
+
+		if beforeAsOf != nil {
+			before, err = adviseAsOf(ctx, cfg, req, beforeAsOf, logger)
+			if err != nil {
+				return utils.PrependToError(err, "could not generate before advice")
+			}
+		}
+		if *afterFilepath == "" {
+			after, err = adviseAsOf(ctx, cfg, req, req.AsOf, logger)
+			if err != nil {
+				return utils.PrependToError(err, "could not generate after advice")
+			}
+		}
+	}
+
+	adviceDiff := diff.Compare(before, after)
+	return render.AdviceDiff(os.Stdout, &adviceDiff, outputFormat)
+}
+
+// adviseAsOf generates advice for req using the instances at asOf, or the
+// cached instances when asOf is nil.
+func adviseAsOf(
+	ctx context.Context,
+	cfg *config.Config,
+	req schema.AdviseRequest,
+	asOf *time.Time,
+	logger *zap.Logger,
+) (*schema.Advice, error) {
+	info, err := loadInstances(cfg, asOf, logger)
+	if err != nil {
+		return nil, err
+	}
+	return advise(ctx, *info, req.Advisor, req.Services, req.Options, nil, logger)
+}
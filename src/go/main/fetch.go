@@ -0,0 +1,52 @@
+package main
+
+import (
+	awsApi "aws-blended-instances-advisor/aws/api"
+	"aws-blended-instances-advisor/utils"
+	"fmt"
+	"os"
+)
+
+func runFetch(args []string) error {
+	fs, clf := newFlagSet("fetch", "fetch [flags]")
+	expiredOnly := fs.Bool("expired-only", false, "only fetch the regions' sources of instances which are no longer valid")
+	fs.Parse(args)
+
+	logger, syncLogger := createCliLogger(clf.DebugMode)
+	defer syncLogger()
+
+	config := parseAndLogConfig(clf.ConfigFilepath, logger)
+	cache := createCache(&config.CacheConfig, clf.ClearCache, logger)
+	defer cache.Close()
+
+	fetch := awsApi.FetchInstancesAndInfo
+	if *expiredOnly {
+		fetch = awsApi.RefreshInstancesAndInfo
+	}
+	info, err := fetch(&config.AwsApiConfig, &config.Credentials, cache, logger)
+	if err != nil {
+		return utils.PrependToError(err, "could not fetch instances")
+	}
+
+	snapshots, err := createSnapshotStore(&config.CacheConfig.Snapshots)
+	if err != nil {
+		return err
+	}
+	saveSnapshot(snapshots, info, getInstancesFetchDate(cache), logger)
+
+	onDemandCount, spotCount := 0, 0
+	for _, regionInfo := range info.RegionInfoMap {
+		onDemandCount += len(regionInfo.PermanentInstances)
+		spotCount += len(regionInfo.TransientInstances)
+	}
+
+	fmt.Fprintf(
+		os.Stdout,
+		"Fetched %d on-demand and %d spot instances across %d regions into %s\n",
+		onDemandCount,
+		spotCount,
+		len(info.RegionInfoMap),
+		config.CacheConfig.Dirpath,
+	)
+	return nil
+}
@@ -2,6 +2,8 @@ package main
 
 import (
 	"flag"
+	"fmt"
+	"os"
 )
 
 const DEFAULT_CONFIG_FILEPATH = "../../config.json"
@@ -13,18 +15,22 @@ type commandLineFlags struct {
 	ClearCache     bool   `json:"clearCache"`
 }
 
-func parseCommandLineFlags() commandLineFlags {
-	configFilepath := flag.String("c", DEFAULT_CONFIG_FILEPATH, "the relative path to the config file")
-	debugMode := flag.Bool("debug", false, "sets the program to debug mode")
-	prodMode := flag.Bool("prod", false, "sets the program to production mode")
-	clearCache := flag.Bool("clear-cache", false, "clears cached files and requests")
+// newFlagSet creates a FlagSet for a command with the flags shared by
+// all commands, which are set on the returned commandLineFlags once
+// the FlagSet has been parsed.
+func newFlagSet(name string, usage string) (*flag.FlagSet, *commandLineFlags) {
+	clf := &commandLineFlags{}
+	fs := flag.NewFlagSet(name, flag.ExitOnError)
 
-	flag.Parse()
+	fs.StringVar(&clf.ConfigFilepath, "c", DEFAULT_CONFIG_FILEPATH, "the path to the config file (JSON, YAML or TOML)")
+	fs.BoolVar(&clf.DebugMode, "debug", false, "sets the program to debug mode")
+	fs.BoolVar(&clf.ProductionMode, "prod", false, "sets the program to production mode")
+	fs.BoolVar(&clf.ClearCache, "clear-cache", false, "clears cached files and requests")
 
-	return commandLineFlags{
-		ConfigFilepath: *configFilepath,
-		DebugMode:      *debugMode,
-		ProductionMode: *prodMode,
-		ClearCache:     *clearCache,
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: %s %s\n\nFlags:\n", os.Args[0], usage)
+		fs.PrintDefaults()
 	}
+
+	return fs, clf
 }
@@ -0,0 +1,57 @@
+package main
+
+import (
+	awsTypes "aws-blended-instances-advisor/aws/types"
+	"aws-blended-instances-advisor/catalogue"
+	"aws-blended-instances-advisor/instances/filter"
+	"aws-blended-instances-advisor/render"
+	"aws-blended-instances-advisor/utils"
+	"os"
+	"strings"
+)
+
+func runInstances(args []string) error {
+	fs, clf := newFlagSet("instances", "instances [flags]")
+	query := catalogue.NewQuery()
+	regions := fs.String("region", "", "comma-separated regions to include (default all)")
+	market := fs.String("market", "", "the market to include, spot or onDemand (default both)")
+	fs.StringVar(&query.Filter.OperatingSystem, "os", "", "the operating system to include")
+	fs.StringVar(&query.Filter.Family, "family", "", "the instance family to include, such as m5")
+	fs.Float64Var(&query.Filter.MinMemory, "min-memory", 0, "the minimum memory in GB")
+	fs.IntVar(&query.Filter.MinVcpu, "min-vcpu", 0, "the minimum number of vCPUs")
+	fs.IntVar(&query.Filter.MaxVcpu, "max-vcpu", 0, "the maximum number of vCPUs (0 for no maximum)")
+	fs.Float64Var(&query.Filter.MinPrice, "min-price", 0, "the minimum price per hour")
+	fs.Float64Var(&query.Filter.MaxPrice, "max-price", 0, "the maximum price per hour (0 for no maximum)")
+	fs.StringVar(&query.SortBy, "sort", "", "the field to sort by: price, memory, vcpu, revocProb or region")
+	fs.BoolVar(&query.Descending, "desc", false, "sorts in descending order")
+	fs.IntVar(&query.Page, "page", query.Page, "the page of results to return")
+	fs.IntVar(&query.PageSize, "page-size", query.PageSize, "the number of results per page")
+	fs.Parse(args)
+
+	var err error
+	if *regions != "" {
+		query.Filter.Regions, err = awsTypes.NewRegions(strings.Split(*regions, ","))
+		if err != nil {
+			return err
+		}
+	}
+	query.Filter.Market, err = filter.NewMarket(*market)
+	if err != nil {
+		return err
+	}
+	err = query.Validate()
+	if err != nil {
+		return utils.PrependToError(err, "invalid query")
+	}
+
+	logger, syncLogger := createCliLogger(clf.DebugMode)
+	defer syncLogger()
+
+	cfg := parseAndLogConfig(clf.ConfigFilepath, logger)
+	info, err := loadCachedInstances(cfg, logger)
+	if err != nil {
+		return err
+	}
+
+	return render.Json(os.Stdout, catalogue.QueryInstances(*info, query))
+}
@@ -3,46 +3,26 @@ package main
 import (
 	"aws-blended-instances-advisor/advisor"
 	"aws-blended-instances-advisor/api/schema"
-	apiService "aws-blended-instances-advisor/api/service"
 	awsApi "aws-blended-instances-advisor/aws/api"
 	"aws-blended-instances-advisor/cache"
 	"aws-blended-instances-advisor/config"
+	instPkg "aws-blended-instances-advisor/instances"
 	"aws-blended-instances-advisor/utils"
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
+	"time"
 
 	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
 )
 
 func main() {
-	clf := parseCommandLineFlags()
-
-	logger, syncLogger := createLogger(clf.DebugMode)
-	defer syncLogger()
-
-	logCommandLineFlags(&clf, logger)
-
-	config := parseAndLogConfig(clf.ConfigFilepath, logger)
-	cache := createCache(config.CacheConfig.Dirpath, clf.ClearCache, logger)
-
-	instancesInfo, err := awsApi.GetInstancesAndInfo(
-		&config.AwsApiConfig,
-		&config.Credentials,
-		cache,
-		logger,
-	)
+	err := runCommand(os.Args[1:])
 	if err != nil {
-		logger.Error("Error fetching instances", zap.Error(err))
+		utils.StopProgramExecution(err, 1)
 	}
-
-	apiService.StartService(
-		&config.ApiConfig,
-		logger,
-		func(advisorInfo schema.Advisor, services []schema.Service, options schema.Options) (*schema.Advice, error) {
-			return advisor.New(advisorInfo).Advise(*instancesInfo, services, options, logger)
-		},
-	)
 }
 
 func createLogger(debugMode bool) (logger *zap.Logger, syncLogger func() error) {
@@ -62,6 +42,23 @@ func instantiateLogger(debugMode bool) (*zap.Logger, error) {
 	return zap.NewProduction()
 }
 
+// createCliLogger creates a logger for commands whose output is read by
+// people or scripts, which only logs warnings and errors unless debugging.
+func createCliLogger(debugMode bool) (logger *zap.Logger, syncLogger func() error) {
+	if debugMode {
+		return createLogger(debugMode)
+	}
+
+	cfg := zap.NewProductionConfig()
+	cfg.Level = zap.NewAtomicLevelAt(zapcore.WarnLevel)
+	logger, err := cfg.Build()
+	if err != nil {
+		err = utils.PrependToError(err, "failed to start logger")
+		utils.StopProgramExecution(err, 1)
+	}
+	return logger, logger.Sync
+}
+
 func parseAndLogConfig(configFilepath string, logger *zap.Logger) *config.Config {
 	config, absFilepath, err := parseConfig(configFilepath)
 	if err != nil {
@@ -76,13 +73,7 @@ func parseAndLogConfig(configFilepath string, logger *zap.Logger) *config.Config
 }
 
 func parseConfig(configFilepath string) (cfg *config.Config, absFilepath string, err error) {
-	cwd, err := utils.GetCallerPath()
-	if err != nil {
-		err = utils.PrependToError(err, "failed to fetch current working directory")
-		return
-	}
-
-	absFilepath, err = filepath.Abs(cwd + string(os.PathSeparator) + configFilepath)
+	absFilepath, err = filepath.Abs(configFilepath)
 	if err != nil {
 		err = utils.PrependToError(err, "failed to generate config filepath")
 		return
@@ -109,23 +100,71 @@ func logCommandLineFlags(clf *commandLineFlags, logger *zap.Logger) {
 	logger.Info("command line flags parsed", zap.Any("flags", clf))
 }
 
-func createCache(cacheFilepath string, useNewCache bool, logger *zap.Logger) *cache.Cache {
-	var c *cache.Cache
-	var err error
-
-	if useNewCache {
-		c, err = cache.New(cacheFilepath)
-	} else {
-		c, err = cache.ParseIfExistsElseNew(cacheFilepath)
-	}
-
+func createCache(cfg *config.CacheConfig, useNewCache bool, logger *zap.Logger) cache.Cache {
+	c, err := cache.Open(cfg.Dirpath, cfg.Options(), useNewCache)
 	if err != nil {
 		err = utils.PrependToError(
 			err,
-			fmt.Sprintf("failed to create cache from %s", cacheFilepath),
+			fmt.Sprintf("failed to create cache from %s", cfg.Dirpath),
 		)
 		utils.StopProgramExecution(err, 1)
 	}
 
 	return c
 }
+
+func getInstancesFetchDate(c cache.Cache) time.Time {
+	entry, err := c.GetEntry(awsApi.INSTANCES_CACHE_FILENAME)
+	if err != nil {
+		return time.Now()
+	}
+	return entry.SetDate
+}
+
+// getInstancesValidity returns when the cached instances were fetched and
+// until when they are valid, defaulting to being fetched and invalid now.
+func getInstancesValidity(c cache.Cache) (fetchedAt time.Time, validUntil time.Time) {
+	entry, err := c.GetEntry(awsApi.INSTANCES_CACHE_FILENAME)
+	if err != nil {
+		now := time.Now()
+		return now, now
+	}
+	return entry.SetDate, entry.InvalidationDate
+}
+
+// applyConfigDefaults fills in an AdviseRequest from the config's preset it
+// names and default weights, as is done for API requests.
+func applyConfigDefaults(req *schema.AdviseRequest, cfg *config.Config) error {
+	err := req.ApplyPreset(cfg.Advisor.Presets)
+	if err != nil {
+		return err
+	}
+	req.Advisor = withDefaultWeights(req.Advisor, cfg.Advisor.DefaultWeights)
+	return nil
+}
+
+// withDefaultWeights sets the weights of an Advisor which gives no weights
+// to the configured default weights.
+func withDefaultWeights(advisorInfo schema.Advisor, weights config.WeightsConfig) schema.Advisor {
+	if advisorInfo.Weights != (schema.AdvisorWeights{}) || weights.IsZero() {
+		return advisorInfo
+	}
+	advisorInfo.Weights = schema.AdvisorWeights{
+		Price:        weights.Price,
+		Availability: weights.Availability,
+		Performance:  weights.Performance,
+	}
+	return advisorInfo
+}
+
+func advise(
+	ctx context.Context,
+	info instPkg.GlobalInfo,
+	advisorInfo schema.Advisor,
+	services []schema.Service,
+	options schema.Options,
+	onProgress advisor.ProgressHandler,
+	logger *zap.Logger,
+) (*schema.Advice, error) {
+	return advisor.New(advisorInfo).Advise(ctx, info, services, options, onProgress, logger)
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"aws-blended-instances-advisor/advisor"
+	"aws-blended-instances-advisor/api/schema"
+	"aws-blended-instances-advisor/render"
+	"aws-blended-instances-advisor/utils"
+	"errors"
+	"os"
+)
+
+func runScore(args []string) error {
+	fs, clf := newFlagSet("score", "score -f request.json -a advice.json [flags]")
+	requestFilepath := fs.String("f", "", "the path to the advise request JSON file the advice was generated for")
+	adviceFilepath := fs.String("a", "", "the path to an advice JSON file (\"-\" for stdin)")
+	fs.Parse(args)
+
+	if *requestFilepath == "" || *adviceFilepath == "" {
+		fs.Usage()
+		return errors.New("both a request file and an advice file must be given")
+	}
+
+	logger, syncLogger := createCliLogger(clf.DebugMode)
+	defer syncLogger()
+
+	var req schema.AdviseRequest
+	err := readJsonInput(*requestFilepath, &req)
+	if err != nil {
+		return utils.PrependToError(err, "could not read request")
+	}
+	err = req.Validate()
+	if err != nil {
+		return utils.PrependToError(err, "invalid request")
+	}
+
+	var advice schema.Advice
+	err = readJsonInput(*adviceFilepath, &advice)
+	if err != nil {
+		return utils.PrependToError(err, "could not read advice")
+	}
+
+	cfg := parseAndLogConfig(clf.ConfigFilepath, logger)
+	info, err := loadCachedInstances(cfg, logger)
+	if err != nil {
+		return err
+	}
+
+	adv := advisor.New(req.Advisor)
+	scores := make(map[string]float64)
+	for region, regionAdvice := range advice {
+		regionAdvice := regionAdvice
+		scores[region] = adv.ScoreRegionAdvice(&regionAdvice, info.GlobalAggregates, req.Services, logger)
+	}
+
+	return render.Json(os.Stdout, scores)
+}
@@ -0,0 +1,131 @@
+package main
+
+import (
+	"aws-blended-instances-advisor/advisor"
+	"aws-blended-instances-advisor/api/schema"
+	apiService "aws-blended-instances-advisor/api/service"
+	awsApi "aws-blended-instances-advisor/aws/api"
+	"aws-blended-instances-advisor/cache"
+	"aws-blended-instances-advisor/catalogue"
+	configPkg "aws-blended-instances-advisor/config"
+	instPkg "aws-blended-instances-advisor/instances"
+	"aws-blended-instances-advisor/utils"
+	"context"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// How often the instance catalogue is checked for staleness
+const REVALIDATION_INTERVAL = 5 * time.Minute
+
+func runServe(args []string) error {
+	fs, clf := newFlagSet("serve", "serve [flags]")
+	fs.Parse(args)
+
+	logger, syncLogger := createLogger(clf.DebugMode)
+	defer syncLogger()
+
+	logCommandLineFlags(clf, logger)
+
+	config := parseAndLogConfig(clf.ConfigFilepath, logger)
+	instancesCache := createCache(&config.CacheConfig, clf.ClearCache, logger)
+	defer instancesCache.Close()
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGTERM, syscall.SIGINT)
+	defer stop()
+
+	live := configPkg.NewLive(config)
+	configFilepath, err := filepath.Abs(clf.ConfigFilepath)
+	if err != nil {
+		return utils.PrependToError(err, "failed to generate config filepath")
+	}
+	reload := make(chan os.Signal, 1)
+	signal.Notify(reload, syscall.SIGHUP)
+	defer signal.Stop(reload)
+	go configPkg.Watch(ctx, configFilepath, live, config, configPkg.WATCH_INTERVAL, reload, logger)
+
+	snapshots, err := createSnapshotStore(&config.CacheConfig.Snapshots)
+	if err != nil {
+		return err
+	}
+
+	instancesCatalogue := catalogue.New()
+	instancesCatalogue.SetMaxStaleness(time.Duration(config.CacheConfig.MaxStalenessHours) * time.Hour)
+	if snapshots != nil {
+		instancesCatalogue.SetHistory(snapshots)
+	}
+
+	instancesInfo, err := awsApi.GetInstancesAndInfoFromCache(instancesCache)
+	if err != nil {
+		logger.Info("no usable instances in cache", zap.String("reason", err.Error()))
+	} else {
+		fetchedAt, validUntil := getInstancesValidity(instancesCache)
+		instancesCatalogue.SetWithValidity(instancesInfo, fetchedAt, validUntil)
+		instancesInfo.Log("instances and info loaded from cache", logger)
+	}
+
+	go instancesCatalogue.Revalidate(
+		ctx,
+		func() (*instPkg.GlobalInfo, time.Time, time.Time, error) {
+			awsApiConfig := config.AwsApiConfig
+			awsApiConfig.Refresh = live.Refresh()
+			info, err := awsApi.RefreshInstancesAndInfo(
+				&awsApiConfig,
+				&config.Credentials,
+				instancesCache,
+				logger,
+			)
+			if err != nil {
+				return nil, time.Time{}, time.Time{}, err
+			}
+			fetchedAt, validUntil := getInstancesValidity(instancesCache)
+			if snapshots != nil {
+				saveSnapshot(snapshots, info, fetchedAt, logger)
+			}
+			return info, fetchedAt, validUntil, nil
+		},
+		REVALIDATION_INTERVAL,
+		logger,
+	)
+
+	if config.CacheConfig.EvictionIntervalMinutes > 0 {
+		go cache.RunEviction(
+			ctx,
+			instancesCache,
+			time.Duration(config.CacheConfig.EvictionIntervalMinutes)*time.Minute,
+			time.Duration(config.CacheConfig.EvictionGraceHours)*time.Hour,
+			config.CacheConfig.Options().MaxSizeBytes,
+			logger,
+		)
+	}
+
+	err = apiService.StartService(
+		ctx,
+		&config.ApiConfig,
+		live,
+		logger,
+		instancesCatalogue,
+		instancesCache,
+		func(
+			ctx context.Context,
+			info instPkg.GlobalInfo,
+			advisorInfo schema.Advisor,
+			services []schema.Service,
+			options schema.Options,
+			onProgress advisor.ProgressHandler,
+		) (*schema.Advice, error) {
+			advisorInfo = withDefaultWeights(advisorInfo, live.DefaultWeights())
+			return advise(ctx, info, advisorInfo, services, options, onProgress, logger)
+		},
+	)
+	if err != nil {
+		return utils.PrependToError(err, "API stopped")
+	}
+
+	return nil
+}
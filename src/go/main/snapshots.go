@@ -0,0 +1,82 @@
+package main
+
+import (
+	"aws-blended-instances-advisor/config"
+	instPkg "aws-blended-instances-advisor/instances"
+	"aws-blended-instances-advisor/snapshot"
+	"aws-blended-instances-advisor/utils"
+	"errors"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const HOURS_PER_DAY = 24
+
+// createSnapshotStore returns the snapshot.Store described by cfg, or nil if
+// snapshots are not enabled.
+func createSnapshotStore(cfg *config.SnapshotsConfig) (*snapshot.Store, error) {
+	if !cfg.Enabled() {
+		return nil, nil
+	}
+
+	retention := time.Duration(cfg.RetentionDays) * HOURS_PER_DAY * time.Hour
+	store, err := snapshot.NewStore(cfg.Dirpath, retention)
+	if err != nil {
+		return nil, utils.PrependToError(err, "could not create snapshot store")
+	}
+	return store, nil
+}
+
+// saveSnapshot saves info as a snapshot in store, logging rather than
+// returning any error as a failed snapshot should not stop instances being used.
+func saveSnapshot(store *snapshot.Store, info *instPkg.GlobalInfo, fetchedAt time.Time, logger *zap.Logger) {
+	if store == nil {
+		return
+	}
+
+	err := store.Save(info, fetchedAt)
+	if err != nil {
+		logger.Warn("failed to save instances snapshot", zap.Error(err))
+		return
+	}
+	logger.Info("instances snapshot saved", zap.Time("fetchedAt", fetchedAt))
+}
+
+// loadInstances returns the instances stored in the configured cache, or
+// those in the latest snapshot at or before asOf when asOf is not nil.
+func loadInstances(cfg *config.Config, asOf *time.Time, logger *zap.Logger) (*instPkg.GlobalInfo, error) {
+	if asOf == nil {
+		return loadCachedInstances(cfg, logger)
+	}
+
+	store, err := createSnapshotStore(&cfg.CacheConfig.Snapshots)
+	if err != nil {
+		return nil, err
+	}
+	if store == nil {
+		return nil, errors.New("snapshots are not enabled in config")
+	}
+
+	info, fetchedAt, err := store.Load(*asOf)
+	if err != nil {
+		return nil, utils.PrependToError(err, "could not load instances snapshot")
+	}
+	logger.Info("instances snapshot loaded", zap.Time("fetchedAt", fetchedAt))
+
+	return info, nil
+}
+
+// parseAsOf parses an RFC 3339 timestamp given on the command line,
+// returning nil when value is empty.
+func parseAsOf(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	asOf, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, utils.PrependToError(err, "invalid as-of time (expected RFC 3339)")
+	}
+	return &asOf, nil
+}
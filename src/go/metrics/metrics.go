@@ -0,0 +1,383 @@
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const (
+	labelValueSeparator = "\xff"
+)
+
+// DefaultBuckets are histogram buckets suitable for durations in seconds.
+var DefaultBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60}
+
+// A Registry holds metrics and writes them in the Prometheus text format.
+type Registry struct {
+	mu      sync.Mutex
+	metrics map[string]metric
+}
+
+type metric interface {
+	writeTo(w io.Writer) error
+}
+
+// DefaultRegistry is the Registry used by the New functions in this package.
+var DefaultRegistry = NewRegistry()
+
+// NewRegistry creates an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{metrics: make(map[string]metric)}
+}
+
+// Write writes all metrics in the Registry to w in the Prometheus text format,
+// ordered by metric name.
+func (r *Registry) Write(w io.Writer) error {
+	r.mu.Lock()
+	names := []string{}
+	for name := range r.metrics {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	metrics := []metric{}
+	for _, name := range names {
+		metrics = append(metrics, r.metrics[name])
+	}
+	r.mu.Unlock()
+
+	for _, m := range metrics {
+		err := m.writeTo(w)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *Registry) register(name string, m metric) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.metrics[name]; exists {
+		panic(fmt.Sprintf("metric registered more than once: %s", name))
+	}
+	r.metrics[name] = m
+}
+
+// A CounterVec is a set of counters, partitioned by label values, which
+// can only increase.
+type CounterVec struct {
+	desc
+	mu     sync.Mutex
+	values map[string]float64
+}
+
+// NewCounterVec creates a CounterVec and registers it with the DefaultRegistry.
+func NewCounterVec(name, help string, labelNames ...string) *CounterVec {
+	return DefaultRegistry.NewCounterVec(name, help, labelNames...)
+}
+
+// NewCounterVec creates a CounterVec and registers it with the Registry.
+func (r *Registry) NewCounterVec(name, help string, labelNames ...string) *CounterVec {
+	c := &CounterVec{
+		desc:   desc{name: name, help: help, kind: "counter", labelNames: labelNames},
+		values: make(map[string]float64),
+	}
+	r.register(name, c)
+	return c
+}
+
+// Inc increments the counter for the given label values by one.
+func (c *CounterVec) Inc(labelValues ...string) {
+	c.Add(1, labelValues...)
+}
+
+// Add increases the counter for the given label values by value,
+// which must not be negative.
+func (c *CounterVec) Add(value float64, labelValues ...string) {
+	if value < 0 {
+		panic("counter cannot decrease")
+	}
+	key := c.key(labelValues)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.values[key] += value
+}
+
+func (c *CounterVec) writeTo(w io.Writer) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.writeValues(w, c.values)
+}
+
+// A GaugeVec is a set of gauges, partitioned by label values, which
+// can increase and decrease.
+type GaugeVec struct {
+	desc
+	mu     sync.Mutex
+	values map[string]float64
+}
+
+// NewGaugeVec creates a GaugeVec and registers it with the DefaultRegistry.
+func NewGaugeVec(name, help string, labelNames ...string) *GaugeVec {
+	return DefaultRegistry.NewGaugeVec(name, help, labelNames...)
+}
+
+// NewGaugeVec creates a GaugeVec and registers it with the Registry.
+func (r *Registry) NewGaugeVec(name, help string, labelNames ...string) *GaugeVec {
+	g := &GaugeVec{
+		desc:   desc{name: name, help: help, kind: "gauge", labelNames: labelNames},
+		values: make(map[string]float64),
+	}
+	r.register(name, g)
+	return g
+}
+
+// Set sets the gauge for the given label values.
+func (g *GaugeVec) Set(value float64, labelValues ...string) {
+	key := g.key(labelValues)
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.values[key] = value
+}
+
+// Add adds value, which may be negative, to the gauge for the given label values.
+func (g *GaugeVec) Add(value float64, labelValues ...string) {
+	key := g.key(labelValues)
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.values[key] += value
+}
+
+func (g *GaugeVec) writeTo(w io.Writer) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.writeValues(w, g.values)
+}
+
+// A GaugeFunc is a single unlabelled gauge whose value is computed
+// each time metrics are written.
+type GaugeFunc struct {
+	desc
+	value func() float64
+}
+
+// NewGaugeFunc creates a GaugeFunc and registers it with the DefaultRegistry.
+func NewGaugeFunc(name, help string, value func() float64) *GaugeFunc {
+	return DefaultRegistry.NewGaugeFunc(name, help, value)
+}
+
+// NewGaugeFunc creates a GaugeFunc and registers it with the Registry.
+func (r *Registry) NewGaugeFunc(name, help string, value func() float64) *GaugeFunc {
+	g := &GaugeFunc{
+		desc:  desc{name: name, help: help, kind: "gauge"},
+		value: value,
+	}
+	r.register(name, g)
+	return g
+}
+
+func (g *GaugeFunc) writeTo(w io.Writer) error {
+	return g.writeValues(w, map[string]float64{"": g.value()})
+}
+
+// A HistogramVec is a set of histograms, partitioned by label values, which
+// count observations into cumulative buckets.
+type HistogramVec struct {
+	desc
+	buckets []float64
+	mu      sync.Mutex
+	values  map[string]*histogram
+}
+
+type histogram struct {
+	counts []uint64 // Per bucket, not cumulative
+	count  uint64
+	sum    float64
+}
+
+// NewHistogramVec creates a HistogramVec and registers it with the DefaultRegistry.
+func NewHistogramVec(name, help string, buckets []float64, labelNames ...string) *HistogramVec {
+	return DefaultRegistry.NewHistogramVec(name, help, buckets, labelNames...)
+}
+
+// NewHistogramVec creates a HistogramVec and registers it with the Registry.
+//
+// The given buckets are the inclusive upper bounds of each bucket, and are sorted
+// if not already in increasing order.
+func (r *Registry) NewHistogramVec(name, help string, buckets []float64, labelNames ...string) *HistogramVec {
+	sortedBuckets := append([]float64{}, buckets...)
+	sort.Float64s(sortedBuckets)
+
+	h := &HistogramVec{
+		desc:    desc{name: name, help: help, kind: "histogram", labelNames: labelNames},
+		buckets: sortedBuckets,
+		values:  make(map[string]*histogram),
+	}
+	r.register(name, h)
+	return h
+}
+
+// Observe adds an observation to the histogram for the given label values.
+func (h *HistogramVec) Observe(value float64, labelValues ...string) {
+	key := h.key(labelValues)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	hist, ok := h.values[key]
+	if !ok {
+		hist = &histogram{counts: make([]uint64, len(h.buckets))}
+		h.values[key] = hist
+	}
+
+	index := sort.SearchFloat64s(h.buckets, value)
+	if index < len(h.buckets) {
+		hist.counts[index] += 1
+	}
+	hist.count += 1
+	hist.sum += value
+}
+
+func (h *HistogramVec) writeTo(w io.Writer) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	err := h.writeHeader(w)
+	if err != nil {
+		return err
+	}
+
+	keys := []string{}
+	for key := range h.values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		hist := h.values[key]
+		labelValues := splitKey(key, len(h.labelNames))
+
+		cumulative := uint64(0)
+		for i, upperBound := range h.buckets {
+			cumulative += hist.counts[i]
+			labels := h.formatLabels(labelValues, "le", formatFloat(upperBound))
+			_, err = fmt.Fprintf(w, "%s_bucket%s %d\n", h.name, labels, cumulative)
+			if err != nil {
+				return err
+			}
+		}
+
+		_, err = fmt.Fprintf(w, "%s_bucket%s %d\n", h.name, h.formatLabels(labelValues, "le", "+Inf"), hist.count)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(w, "%s_sum%s %s\n", h.name, h.formatLabels(labelValues), formatFloat(hist.sum))
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(w, "%s_count%s %d\n", h.name, h.formatLabels(labelValues), hist.count)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type desc struct {
+	name       string
+	help       string
+	kind       string
+	labelNames []string
+}
+
+func (d *desc) key(labelValues []string) string {
+	if len(labelValues) != len(d.labelNames) {
+		panic(fmt.Sprintf(
+			"metric %s expects %d label values, got %d",
+			d.name,
+			len(d.labelNames),
+			len(labelValues),
+		))
+	}
+	return strings.Join(labelValues, labelValueSeparator)
+}
+
+func (d *desc) writeHeader(w io.Writer) error {
+	_, err := fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", d.name, escapeHelp(d.help), d.name, d.kind)
+	return err
+}
+
+func (d *desc) writeValues(w io.Writer, values map[string]float64) error {
+	err := d.writeHeader(w)
+	if err != nil {
+		return err
+	}
+
+	keys := []string{}
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		labels := d.formatLabels(splitKey(key, len(d.labelNames)))
+		_, err = fmt.Fprintf(w, "%s%s %s\n", d.name, labels, formatFloat(values[key]))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// formatLabels formats label values, followed by any extra label name and value
+// pairs, as a Prometheus label set.
+func (d *desc) formatLabels(labelValues []string, extra ...string) string {
+	pairs := []string{}
+	for i, name := range d.labelNames {
+		pairs = append(pairs, fmt.Sprintf("%s=\"%s\"", name, escapeLabelValue(labelValues[i])))
+	}
+	for i := 0; i+1 < len(extra); i += 2 {
+		pairs = append(pairs, fmt.Sprintf("%s=\"%s\"", extra[i], escapeLabelValue(extra[i+1])))
+	}
+
+	if len(pairs) == 0 {
+		return ""
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+func splitKey(key string, labelCount int) []string {
+	if labelCount == 0 {
+		return []string{}
+	}
+	return strings.Split(key, labelValueSeparator)
+}
+
+func formatFloat(value float64) string {
+	switch {
+	case math.IsInf(value, 1):
+		return "+Inf"
+	case math.IsInf(value, -1):
+		return "-Inf"
+	case math.IsNaN(value):
+		return "NaN"
+	}
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
+
+func escapeHelp(help string) string {
+	return strings.NewReplacer("\\", "\\\\", "\n", "\\n").Replace(help)
+}
+
+func escapeLabelValue(value string) string {
+	return strings.NewReplacer("\\", "\\\\", "\n", "\\n", "\"", "\\\"").Replace(value)
+}
@@ -0,0 +1,114 @@
+package metrics
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestCounterVec(t *testing.T) {
+	r := NewRegistry()
+	c := r.NewCounterVec("test_total", "A test counter.", "method", "status")
+
+	c.Inc("GET", "200")
+	c.Inc("GET", "200")
+	c.Add(3, "POST", "500")
+
+	expected := `# HELP test_total A test counter.
+# TYPE test_total counter
+test_total{method="GET",status="200"} 2
+test_total{method="POST",status="500"} 3
+`
+	var buf bytes.Buffer
+	err := r.Write(&buf)
+	if err != nil {
+		t.Fatalf("Failed to write metrics: %s", err.Error())
+	}
+	if buf.String() != expected {
+		t.Fatalf("Wrong output. Wanted:\n%s\nGot:\n%s", expected, buf.String())
+	}
+}
+
+func TestGaugeVecAndGaugeFunc(t *testing.T) {
+	r := NewRegistry()
+	g := r.NewGaugeVec("b_gauge", "A test gauge.", "region")
+	r.NewGaugeFunc("a_gauge", "A test gauge func.", func() float64 { return 1.5 })
+
+	g.Set(10, "us-east-1")
+	g.Add(-2.5, "us-east-1")
+	g.Set(1, "eu-west-1")
+
+	expected := `# HELP a_gauge A test gauge func.
+# TYPE a_gauge gauge
+a_gauge 1.5
+# HELP b_gauge A test gauge.
+# TYPE b_gauge gauge
+b_gauge{region="eu-west-1"} 1
+b_gauge{region="us-east-1"} 7.5
+`
+	var buf bytes.Buffer
+	err := r.Write(&buf)
+	if err != nil {
+		t.Fatalf("Failed to write metrics: %s", err.Error())
+	}
+	if buf.String() != expected {
+		t.Fatalf("Wrong output. Wanted:\n%s\nGot:\n%s", expected, buf.String())
+	}
+}
+
+func TestHistogramVec(t *testing.T) {
+	r := NewRegistry()
+	h := r.NewHistogramVec("test_seconds", "A test histogram.", []float64{1, 0.5}, "endpoint")
+
+	h.Observe(0.5, "/advise")
+	h.Observe(0.75, "/advise")
+	h.Observe(2, "/advise")
+
+	expected := `# HELP test_seconds A test histogram.
+# TYPE test_seconds histogram
+test_seconds_bucket{endpoint="/advise",le="0.5"} 1
+test_seconds_bucket{endpoint="/advise",le="1"} 2
+test_seconds_bucket{endpoint="/advise",le="+Inf"} 3
+test_seconds_sum{endpoint="/advise"} 3.25
+test_seconds_count{endpoint="/advise"} 3
+`
+	var buf bytes.Buffer
+	err := r.Write(&buf)
+	if err != nil {
+		t.Fatalf("Failed to write metrics: %s", err.Error())
+	}
+	if buf.String() != expected {
+		t.Fatalf("Wrong output. Wanted:\n%s\nGot:\n%s", expected, buf.String())
+	}
+}
+
+func TestLabelValuesAreEscaped(t *testing.T) {
+	r := NewRegistry()
+	c := r.NewCounterVec("test_total", "A test counter.", "file")
+
+	c.Inc("a\"b\\c\nd")
+
+	expected := `# HELP test_total A test counter.
+# TYPE test_total counter
+test_total{file="a\"b\\c\nd"} 1
+`
+	var buf bytes.Buffer
+	err := r.Write(&buf)
+	if err != nil {
+		t.Fatalf("Failed to write metrics: %s", err.Error())
+	}
+	if buf.String() != expected {
+		t.Fatalf("Wrong output. Wanted:\n%s\nGot:\n%s", expected, buf.String())
+	}
+}
+
+func TestRegisteringTwicePanics(t *testing.T) {
+	r := NewRegistry()
+	r.NewCounterVec("test_total", "A test counter.")
+
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("Expected panic when registering metric twice")
+		}
+	}()
+	r.NewCounterVec("test_total", "A test counter.")
+}
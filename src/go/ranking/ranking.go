@@ -0,0 +1,141 @@
+// Package ranking orders the regions of an Advice, so that the best region
+// for a deployment can be recommended.
+package ranking
+
+import (
+	"aws-blended-instances-advisor/api/schema"
+	"math"
+	"sort"
+)
+
+// Values are rounded to this precision before regions are compared, so that
+// rounding errors are left for later criteria to break while the order stays
+// transitive.
+const PRECISION = 1e-9
+
+// The criteria regions are ranked by when none are given
+var DEFAULT_CRITERIA = []schema.RankingCriterion{
+	schema.RankByScore,
+	schema.RankByPrice,
+}
+
+// Rank sets the Rank of each RegionAdvice in an Advice, from 1 for the best
+// region, comparing regions by each criterion in turn until one differs.
+//
+// DEFAULT_CRITERIA are used when no criteria are given, and regions which
+// tie on every criterion are ranked by region code.
+func Rank(advice *schema.Advice, criteria []schema.RankingCriterion) {
+	if len(criteria) == 0 {
+		criteria = DEFAULT_CRITERIA
+	}
+
+	regions := []string{}
+	keys := make(map[string][]float64)
+	for region, regionAdvice := range *advice {
+		regionAdvice := regionAdvice
+		regions = append(regions, region)
+		keys[region] = rankingKey(&regionAdvice, criteria)
+	}
+	sort.Strings(regions)
+
+	sort.SliceStable(regions, func(i, j int) bool {
+		a, b := keys[regions[i]], keys[regions[j]]
+		if index := firstDifference(a, b); index >= 0 {
+			return a[index] < b[index]
+		}
+		return false
+	})
+
+	for index, region := range regions {
+		regionAdvice := (*advice)[region]
+		regionAdvice.Rank = index + 1
+		(*advice)[region] = regionAdvice
+	}
+}
+
+// Summarise returns the ranked regions of an Advice in rank order, along
+// with the criterion which decided each region's rank against the next.
+//
+// The criteria should be those the Advice was ranked by.
+func Summarise(advice *schema.Advice, criteria []schema.RankingCriterion) []schema.RankedRegion {
+	if len(criteria) == 0 {
+		criteria = DEFAULT_CRITERIA
+	}
+
+	summary := []schema.RankedRegion{}
+	keys := [][]float64{}
+	for _, region := range Regions(advice) {
+		regionAdvice := (*advice)[region]
+		if regionAdvice.Rank == 0 {
+			break
+		}
+
+		summary = append(summary, schema.RankedRegion{
+			Region:       region,
+			Rank:         regionAdvice.Rank,
+			Score:        regionAdvice.Score,
+			PricePerHour: regionAdvice.PricePerHour(),
+		})
+		keys = append(keys, rankingKey(&regionAdvice, criteria))
+	}
+
+	for index := 0; index < len(summary)-1; index++ {
+		summary[index].DecidedBy = schema.RankByRegion
+		if criterion := firstDifference(keys[index], keys[index+1]); criterion >= 0 {
+			summary[index].DecidedBy = criteria[criterion]
+		}
+	}
+	return summary
+}
+
+// Regions returns the regions of an Advice in rank order, followed by any
+// unranked regions in order of region code.
+func Regions(advice *schema.Advice) []string {
+	regions := []string{}
+	for region := range *advice {
+		regions = append(regions, region)
+	}
+	sort.Strings(regions)
+
+	sort.SliceStable(regions, func(i, j int) bool {
+		a, b := (*advice)[regions[i]].Rank, (*advice)[regions[j]].Rank
+		if a == 0 || b == 0 {
+			return a != 0 && b == 0
+		}
+		return a < b
+	})
+	return regions
+}
+
+// rankingKey returns the value of each criterion for a RegionAdvice, in units
+// of PRECISION and negated where higher values rank first, so that regions
+// rank in ascending order of their keys.
+func rankingKey(advice *schema.RegionAdvice, criteria []schema.RankingCriterion) []float64 {
+	key := make([]float64, len(criteria))
+	for index, criterion := range criteria {
+		var value float64
+		switch criterion {
+		case schema.RankByScore:
+			value = -advice.Score
+		case schema.RankByPrice:
+			value = advice.PricePerHour()
+		case schema.RankByRevocations:
+			value = advice.ExpectedRevocations()
+		case schema.RankByInstances:
+			value = float64(len(advice.Instances))
+		}
+		key[index] = math.Round(value / PRECISION)
+	}
+	return key
+}
+
+// firstDifference returns the index of the first value which differs between
+// two keys, or -1 if they are equal.
+func firstDifference(a []float64, b []float64) int {
+	for index := range a {
+		if a[index] != b[index] {
+			return index
+		}
+	}
+	return -1
+}
@@ -0,0 +1,135 @@
+package ranking
+
+import (
+	"aws-blended-instances-advisor/api/schema"
+	"aws-blended-instances-advisor/utils"
+	"reflect"
+	"testing"
+)
+
+func createRegionAdvice(score float64, prices ...float64) schema.RegionAdvice {
+	advice := schema.RegionAdvice{Score: score}
+	for i, price := range prices {
+		advice.AddAssignment("svc", &schema.Instance{
+			Id:           string(rune('a' + i)),
+			PricePerHour: price,
+		})
+	}
+	return advice
+}
+
+func TestRank(t *testing.T) {
+	advice := schema.Advice{
+		"us-east-1":    createRegionAdvice(0.8, 0.2),
+		"eu-west-1":    createRegionAdvice(0.8, 0.1, 0.05),
+		"ap-south-1":   createRegionAdvice(0.5, 0.05),
+		"eu-central-1": createRegionAdvice(0.8, 0.1, 0.05),
+	}
+
+	tables := []struct {
+		name     string
+		criteria []schema.RankingCriterion
+		expected []string
+	}{
+		{
+			"default",
+			nil,
+			[]string{"eu-central-1", "eu-west-1", "us-east-1", "ap-south-1"},
+		},
+		{
+			"price first",
+			[]schema.RankingCriterion{schema.RankByPrice, schema.RankByScore},
+			[]string{"ap-south-1", "eu-central-1", "eu-west-1", "us-east-1"},
+		},
+		{
+			"instances then score",
+			[]schema.RankingCriterion{schema.RankByInstances, schema.RankByScore},
+			[]string{"us-east-1", "ap-south-1", "eu-central-1", "eu-west-1"},
+		},
+	}
+
+	for _, table := range tables {
+		Rank(&advice, table.criteria)
+
+		actual := Regions(&advice)
+		if !reflect.DeepEqual(actual, table.expected) {
+			t.Errorf("%s: expected order %v, got %v", table.name, table.expected, actual)
+		}
+		for index, region := range actual {
+			if advice[region].Rank != index+1 {
+				t.Errorf("%s: expected %s to have rank %d, got %d", table.name, region, index+1, advice[region].Rank)
+			}
+		}
+	}
+}
+
+func TestRegionsUnranked(t *testing.T) {
+	advice := schema.Advice{
+		"us-east-1":  createRegionAdvice(0.8),
+		"eu-west-1":  createRegionAdvice(0.5),
+		"ap-south-1": createRegionAdvice(0.1),
+	}
+
+	expected := []string{"ap-south-1", "eu-west-1", "us-east-1"}
+	actual := Regions(&advice)
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected order %v, got %v", expected, actual)
+	}
+}
+
+func TestRankRoundsValues(t *testing.T) {
+	// Each score is within 1e-9 of the next, so comparing them with a
+	// tolerance would rank the regions in a cycle
+	advice := schema.Advice{
+		"us-east-1":  createRegionAdvice(1.0, 0.1),
+		"eu-west-1":  createRegionAdvice(1.0+0.6e-9, 0.2),
+		"ap-south-1": createRegionAdvice(1.0+1.2e-9, 0.3),
+	}
+
+	Rank(&advice, nil)
+
+	expected := []string{"eu-west-1", "ap-south-1", "us-east-1"}
+	actual := Regions(&advice)
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected order %v, got %v", expected, actual)
+	}
+}
+
+func TestSummarise(t *testing.T) {
+	advice := schema.Advice{
+		"us-east-1":    createRegionAdvice(0.8, 0.2),
+		"eu-west-1":    createRegionAdvice(0.8, 0.1, 0.05),
+		"ap-south-1":   createRegionAdvice(0.5, 0.05),
+		"eu-central-1": createRegionAdvice(0.8, 0.1, 0.05),
+	}
+	criteria := []schema.RankingCriterion{schema.RankByScore, schema.RankByInstances}
+	Rank(&advice, criteria)
+
+	expected := []schema.RankedRegion{
+		{Region: "us-east-1", Rank: 1, Score: 0.8, PricePerHour: 0.2, DecidedBy: schema.RankByInstances},
+		{Region: "eu-central-1", Rank: 2, Score: 0.8, PricePerHour: 0.15, DecidedBy: schema.RankByRegion},
+		{Region: "eu-west-1", Rank: 3, Score: 0.8, PricePerHour: 0.15, DecidedBy: schema.RankByScore},
+		{Region: "ap-south-1", Rank: 4, Score: 0.5, PricePerHour: 0.05},
+	}
+	actual := Summarise(&advice, criteria)
+	if len(actual) != len(expected) {
+		t.Fatalf("expected %d regions, got %d", len(expected), len(actual))
+	}
+	for index, want := range expected {
+		got := actual[index]
+		if got.Region != want.Region || got.Rank != want.Rank || got.DecidedBy != want.DecidedBy ||
+			!utils.FloatsEqual(got.Score, want.Score) || !utils.FloatsEqual(got.PricePerHour, want.PricePerHour) {
+			t.Errorf("expected %+v at %d, got %+v", want, index, got)
+		}
+	}
+}
+
+func TestSummariseUnranked(t *testing.T) {
+	advice := schema.Advice{
+		"us-east-1": createRegionAdvice(0.8),
+	}
+
+	if summary := Summarise(&advice, nil); len(summary) != 0 {
+		t.Errorf("expected no ranked regions, got %v", summary)
+	}
+}
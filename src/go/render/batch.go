@@ -0,0 +1,106 @@
+package render
+
+import (
+	"aws-blended-instances-advisor/api/schema"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+)
+
+var batchColumns = []string{
+	"SCENARIO",
+	"REGION",
+	"SCORE",
+	"INSTANCES",
+	"PRICE ($/HOUR)",
+	"PRICE ($/MONTH)",
+	"EXPECTED REVOCATIONS",
+	"RANK",
+}
+
+// BatchAdvice writes a BatchAdviseResponse to w in the given Format. Table
+// and markdown formats show the comparison of scenarios and their errors,
+// while JSON includes each scenario's advice.
+func BatchAdvice(w io.Writer, resp *schema.BatchAdviseResponse, format Format) error {
+	switch format {
+	case TableFormat:
+		return BatchAdviceTable(w, resp)
+	case MarkdownFormat:
+		return BatchAdviceMarkdown(w, resp)
+	default:
+		return Json(w, resp)
+	}
+}
+
+// BatchAdviceTable writes the comparison of a BatchAdviseResponse to w as a
+// plain-text table, followed by any scenarios which failed.
+func BatchAdviceTable(w io.Writer, resp *schema.BatchAdviseResponse) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintln(tw, strings.Join(batchColumns, "\t"))
+	for _, comparison := range resp.Comparison {
+		fmt.Fprintln(tw, strings.Join(scenarioComparisonRow(comparison), "\t"))
+	}
+
+	if len(resp.Errors) > 0 {
+		fmt.Fprintln(tw)
+		fmt.Fprintln(tw, "SCENARIO\tERROR")
+		for _, scenario := range sortedErrorScenarios(resp) {
+			fmt.Fprintf(tw, "%s\t%s\n", scenario, resp.Errors[scenario])
+		}
+	}
+
+	return tw.Flush()
+}
+
+// BatchAdviceMarkdown writes the comparison of a BatchAdviseResponse to w as
+// a markdown report, followed by a section for any scenarios which failed.
+func BatchAdviceMarkdown(w io.Writer, resp *schema.BatchAdviseResponse) error {
+	fmt.Fprintln(w, "# Scenarios")
+	fmt.Fprintln(w)
+	writeMarkdownRow(w, batchColumns)
+	writeMarkdownSeparator(w, len(batchColumns))
+	for _, comparison := range resp.Comparison {
+		writeMarkdownRow(w, scenarioComparisonRow(comparison))
+	}
+
+	if len(resp.Errors) == 0 {
+		return nil
+	}
+
+	_, err := fmt.Fprint(w, "\n## Errors\n\n")
+	if err != nil {
+		return err
+	}
+	writeMarkdownRow(w, []string{"SCENARIO", "ERROR"})
+	writeMarkdownSeparator(w, 2)
+	for _, scenario := range sortedErrorScenarios(resp) {
+		writeMarkdownRow(w, []string{scenario, resp.Errors[scenario]})
+	}
+
+	return nil
+}
+
+func scenarioComparisonRow(comparison schema.ScenarioComparison) []string {
+	return []string{
+		comparison.Scenario,
+		comparison.Region,
+		fmt.Sprintf("%.4f", comparison.Score),
+		fmt.Sprintf("%d", comparison.InstanceCount),
+		formatPrice(comparison.PricePerHour),
+		formatPrice(comparison.PricePerHour * HOURS_PER_MONTH),
+		fmt.Sprintf("%.2f", comparison.ExpectedRevocations),
+		formatRank(comparison.Rank),
+	}
+}
+
+func sortedErrorScenarios(resp *schema.BatchAdviseResponse) []string {
+	scenarios := []string{}
+	for scenario := range resp.Errors {
+		scenarios = append(scenarios, scenario)
+	}
+	sort.Strings(scenarios)
+	return scenarios
+}
@@ -0,0 +1,153 @@
+package render
+
+import (
+	"aws-blended-instances-advisor/api/schema"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+)
+
+var diffSummaryColumns = []string{
+	"REGION",
+	"STATUS",
+	"SCORE BEFORE",
+	"SCORE AFTER",
+	"SCORE DELTA",
+	"PRICE BEFORE ($/HOUR)",
+	"PRICE AFTER ($/HOUR)",
+	"PRICE DELTA ($/HOUR)",
+}
+
+var diffChangeColumns = []string{
+	"REGION",
+	"SERVICE",
+	"STATUS",
+	"INSTANCE",
+	"MARKET",
+	"COUNT BEFORE",
+	"COUNT AFTER",
+	"PRICE BEFORE ($/HOUR)",
+	"PRICE AFTER ($/HOUR)",
+}
+
+// AdviceDiff writes an AdviceDiff to w in the given Format.
+func AdviceDiff(w io.Writer, diff *schema.AdviceDiff, format Format) error {
+	switch format {
+	case TableFormat:
+		return AdviceDiffTable(w, diff)
+	case MarkdownFormat:
+		return AdviceDiffMarkdown(w, diff)
+	default:
+		return Json(w, diff)
+	}
+}
+
+// AdviceDiffTable writes an AdviceDiff to w as two plain-text tables: a
+// summary of each region, followed by every changed instance type.
+func AdviceDiffTable(w io.Writer, diff *schema.AdviceDiff) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintln(tw, strings.Join(diffSummaryColumns, "\t"))
+	for _, region := range sortedDiffRegions(diff) {
+		fmt.Fprintln(tw, strings.Join(regionDiffSummaryRow(region, diff.Regions[region]), "\t"))
+	}
+
+	fmt.Fprintln(tw)
+	fmt.Fprintln(tw, strings.Join(diffChangeColumns, "\t"))
+	for _, region := range sortedDiffRegions(diff) {
+		for _, row := range regionDiffChangeRows(region, diff.Regions[region]) {
+			fmt.Fprintln(tw, strings.Join(row, "\t"))
+		}
+	}
+
+	return tw.Flush()
+}
+
+// AdviceDiffMarkdown writes an AdviceDiff to w as a markdown report, with a
+// summary of each region followed by a section for each changed region.
+func AdviceDiffMarkdown(w io.Writer, diff *schema.AdviceDiff) error {
+	regions := sortedDiffRegions(diff)
+
+	fmt.Fprintln(w, "# Advice changes")
+	fmt.Fprintln(w)
+	writeMarkdownRow(w, diffSummaryColumns)
+	writeMarkdownSeparator(w, len(diffSummaryColumns))
+	for _, region := range regions {
+		writeMarkdownRow(w, regionDiffSummaryRow(region, diff.Regions[region]))
+	}
+
+	for _, region := range regions {
+		rows := regionDiffChangeRows(region, diff.Regions[region])
+		if len(rows) == 0 {
+			continue
+		}
+
+		_, err := fmt.Fprintf(w, "\n## %s\n\n", region)
+		if err != nil {
+			return err
+		}
+		writeMarkdownRow(w, diffChangeColumns[1:])
+		writeMarkdownSeparator(w, len(diffChangeColumns)-1)
+		for _, row := range rows {
+			writeMarkdownRow(w, row[1:])
+		}
+	}
+
+	return nil
+}
+
+func regionDiffSummaryRow(region string, diff schema.RegionDiff) []string {
+	return []string{
+		region,
+		string(diff.Status),
+		fmt.Sprintf("%.4f", diff.ScoreBefore),
+		fmt.Sprintf("%.4f", diff.ScoreAfter),
+		fmt.Sprintf("%+.4f", diff.ScoreDelta),
+		formatPrice(diff.PriceBefore),
+		formatPrice(diff.PriceAfter),
+		fmt.Sprintf("%+.4f", diff.PriceDelta),
+	}
+}
+
+// regionDiffChangeRows returns a row for each instance type whose use by a
+// service changed within a region.
+func regionDiffChangeRows(region string, diff schema.RegionDiff) [][]string {
+	services := []string{}
+	for svc := range diff.Services {
+		services = append(services, svc)
+	}
+	sort.Strings(services)
+
+	rows := [][]string{}
+	for _, svc := range services {
+		for _, typeDiff := range diff.Services[svc].InstanceTypes {
+			if typeDiff.Status == schema.DiffUnchanged {
+				continue
+			}
+
+			rows = append(rows, []string{
+				region,
+				svc,
+				string(typeDiff.Status),
+				typeDiff.Name,
+				formatMarket(typeDiff.Spot),
+				fmt.Sprintf("%d", typeDiff.CountBefore),
+				fmt.Sprintf("%d", typeDiff.CountAfter),
+				formatPrice(typeDiff.PriceBefore),
+				formatPrice(typeDiff.PriceAfter),
+			})
+		}
+	}
+	return rows
+}
+
+func sortedDiffRegions(diff *schema.AdviceDiff) []string {
+	regions := []string{}
+	for region := range diff.Regions {
+		regions = append(regions, region)
+	}
+	sort.Strings(regions)
+	return regions
+}
@@ -0,0 +1,247 @@
+// Package render formats advice and other API responses for people to read.
+package render
+
+import (
+	"aws-blended-instances-advisor/api/schema"
+	"aws-blended-instances-advisor/ranking"
+	"aws-blended-instances-advisor/utils"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+)
+
+// A Format is a way of rendering advice.
+type Format string
+
+const (
+	JsonFormat     Format = "json"
+	TableFormat    Format = "table"
+	MarkdownFormat Format = "markdown"
+)
+
+const HOURS_PER_MONTH = 730
+
+// NewFormat converts a string into a Format, returning an error if
+// the string does not name a Format.
+func NewFormat(format string) (Format, error) {
+	switch Format(format) {
+	case JsonFormat, TableFormat, MarkdownFormat:
+		return Format(format), nil
+	default:
+		return "", fmt.Errorf("unknown format %s, expected one of json, table or markdown", format)
+	}
+}
+
+// ContentType returns the HTTP Content-Type of advice rendered in a Format.
+func (format Format) ContentType() string {
+	switch format {
+	case TableFormat:
+		return "text/plain; charset=utf-8"
+	case MarkdownFormat:
+		return "text/markdown; charset=utf-8"
+	default:
+		return "application/json"
+	}
+}
+
+// Advice writes an AdviseResponse to w in the given Format. Table and
+// markdown formats list its regions in rank order, while JSON also includes
+// the ranking summary.
+func Advice(w io.Writer, resp *schema.AdviseResponse, format Format) error {
+	switch format {
+	case TableFormat:
+		return AdviceTable(w, &resp.Regions)
+	case MarkdownFormat:
+		return AdviceMarkdown(w, &resp.Regions)
+	default:
+		return Json(w, resp)
+	}
+}
+
+// Json writes v to w as indented JSON.
+func Json(w io.Writer, v interface{}) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
+
+// AdviceTable writes advice to w as a plain-text table, with one row for each
+// Instance assigned to each service.
+func AdviceTable(w io.Writer, advice *schema.Advice) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, strings.Join(adviceColumns, "\t"))
+
+	for _, region := range sortedRegions(advice) {
+		regionAdvice := (*advice)[region]
+		for _, row := range regionAdviceRows(region, &regionAdvice) {
+			fmt.Fprintln(tw, strings.Join(row, "\t"))
+		}
+	}
+
+	return tw.Flush()
+}
+
+// AdviceMarkdown writes advice to w as a markdown report, with a summary of
+// each region's score and costs followed by a section for each region.
+func AdviceMarkdown(w io.Writer, advice *schema.Advice) error {
+	regions := sortedRegions(advice)
+
+	fmt.Fprintln(w, "# Advice")
+	fmt.Fprintln(w)
+	writeMarkdownRow(w, summaryColumns)
+	writeMarkdownSeparator(w, len(summaryColumns))
+	for _, region := range regions {
+		regionAdvice := (*advice)[region]
+		writeMarkdownRow(w, []string{
+			region,
+			fmt.Sprintf("%.4f", regionAdvice.Score),
+			fmt.Sprintf("%d", len(regionAdvice.Instances)),
+			formatPrice(regionAdvice.PricePerHour()),
+			formatPrice(regionAdvice.PricePerHour() * HOURS_PER_MONTH),
+			fmt.Sprintf("%.2f", regionAdvice.ExpectedRevocations()),
+			formatRank(regionAdvice.Rank),
+		})
+	}
+
+	for _, region := range regions {
+		regionAdvice := (*advice)[region]
+		fmt.Fprintf(w, "\n## %s\n\n", region)
+
+		writeMarkdownRow(w, adviceColumns[1:])
+		writeMarkdownSeparator(w, len(adviceColumns)-1)
+		for _, row := range regionAdviceRows(region, &regionAdvice) {
+			writeMarkdownRow(w, row[1:])
+		}
+
+		_, err := fmt.Fprintf(
+			w,
+			"\nTotal: $%s per hour, $%s per month\n",
+			formatPrice(regionAdvice.PricePerHour()),
+			formatPrice(regionAdvice.PricePerHour()*HOURS_PER_MONTH),
+		)
+		if err != nil {
+			return err
+		}
+
+		if projection := regionAdvice.Projection; projection != nil {
+			_, err = fmt.Fprintf(
+				w,
+				"\nScheduled: $%s per %s period, averaging $%s per hour and peaking at $%s per hour\n",
+				formatPrice(projection.PricePerPeriod),
+				projection.Period,
+				formatPrice(projection.AveragePricePerHour),
+				formatPrice(projection.PeakPricePerHour),
+			)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+var adviceColumns = []string{
+	"REGION",
+	"SERVICE",
+	"INSTANCE",
+	"AZ",
+	"MARKET",
+	"MEMORY (GB)",
+	"VCPU",
+	"PRICE ($/HOUR)",
+	"REVOC PROB",
+	"SHARED WITH",
+}
+
+var summaryColumns = []string{
+	"REGION",
+	"SCORE",
+	"INSTANCES",
+	"PRICE ($/HOUR)",
+	"PRICE ($/MONTH)",
+	"EXPECTED REVOCATIONS",
+	"RANK",
+}
+
+func regionAdviceRows(region string, advice *schema.RegionAdvice) [][]string {
+	services := []string{}
+	for service := range advice.Assignments.ServicesToInstances {
+		services = append(services, service)
+	}
+	sort.Strings(services)
+
+	rows := [][]string{}
+	for _, service := range services {
+		for _, inst := range advice.GetAssignedInstancesForService(service) {
+			rows = append(rows, []string{
+				region,
+				service,
+				inst.Name,
+				orDash(inst.AvailabilityZone),
+				formatMarket(inst.IsSpot()),
+				fmt.Sprintf("%g", inst.MemoryGb),
+				fmt.Sprintf("%d", inst.Vcpu),
+				formatPrice(inst.PricePerHour),
+				fmt.Sprintf("%.2f", inst.RevocationProbability),
+				orDash(strings.Join(getSharingServices(advice, inst.Id, service), ", ")),
+			})
+		}
+	}
+	return rows
+}
+
+// getSharingServices returns the services other than service which are
+// assigned to the Instance with the given ID.
+func getSharingServices(advice *schema.RegionAdvice, instanceId string, service string) []string {
+	sharing := []string{}
+	for _, other := range advice.Assignments.InstancesToServices[instanceId] {
+		if other != service && !utils.StringSliceContains(sharing, other) {
+			sharing = append(sharing, other)
+		}
+	}
+	sort.Strings(sharing)
+	return sharing
+}
+
+func writeMarkdownRow(w io.Writer, cells []string) {
+	fmt.Fprintf(w, "| %s |\n", strings.Join(cells, " | "))
+}
+
+func writeMarkdownSeparator(w io.Writer, columns int) {
+	fmt.Fprintf(w, "|%s\n", strings.Repeat(" --- |", columns))
+}
+
+func formatMarket(spot bool) string {
+	if spot {
+		return "spot"
+	}
+	return "on-demand"
+}
+
+func formatPrice(price float64) string {
+	return fmt.Sprintf("%.4f", price)
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
+func formatRank(rank int) string {
+	if rank == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%d", rank)
+}
+
+// sortedRegions returns the regions of advice with the best ranked first,
+// or in alphabetical order when they are unranked.
+func sortedRegions(advice *schema.Advice) []string {
+	return ranking.Regions(advice)
+}
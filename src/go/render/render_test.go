@@ -0,0 +1,213 @@
+package render
+
+import (
+	"aws-blended-instances-advisor/api/schema"
+	"aws-blended-instances-advisor/ranking"
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func createTestAdvice() *schema.Advice {
+	shared := &schema.Instance{Id: "1", Name: "r5.large", AvailabilityZone: "us-east-1a", MemoryGb: 16, Vcpu: 2, PricePerHour: 0.04, RevocationProbability: 0.05}
+
+	usAdvice := schema.RegionAdvice{Score: 0.5}
+	usAdvice.AddAssignment("web", &schema.Instance{Id: "0", Name: "m5.large", MemoryGb: 8, Vcpu: 2, PricePerHour: 0.096})
+	usAdvice.AddAssignment("db", shared)
+	usAdvice.AddAssignment("cache", shared)
+
+	euAdvice := schema.RegionAdvice{Score: 0.25}
+	euAdvice.AddAssignment("web", &schema.Instance{Id: "2", Name: "c5.large", MemoryGb: 4, Vcpu: 2, PricePerHour: 0.085})
+
+	return &schema.Advice{"us-east-1": usAdvice, "eu-west-1": euAdvice}
+}
+
+func TestNewFormat(t *testing.T) {
+	for _, format := range []string{"json", "table", "markdown"} {
+		if _, err := NewFormat(format); err != nil {
+			t.Fatalf("Unexpected error for format %s: %s", format, err.Error())
+		}
+	}
+	if _, err := NewFormat("yaml"); err == nil {
+		t.Fatalf("Expected error for unknown format")
+	}
+}
+
+func TestAdviceTable(t *testing.T) {
+	var buf bytes.Buffer
+	err := AdviceTable(&buf, createTestAdvice())
+	if err != nil {
+		t.Fatalf("Failed to render table: %s", err.Error())
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 5 {
+		t.Fatalf("Wrong number of lines. Wanted: 5, got: %d\n%s", len(lines), buf.String())
+	}
+
+	expected := [][]string{
+		{"REGION", "SERVICE", "INSTANCE", "AZ", "MARKET"},
+		{"eu-west-1", "web", "c5.large", "-", "on-demand", "4", "2", "0.0850", "0.00", "-"},
+		{"us-east-1", "cache", "r5.large", "us-east-1a", "spot", "16", "2", "0.0400", "0.05", "db"},
+		{"us-east-1", "db", "r5.large", "us-east-1a", "spot", "16", "2", "0.0400", "0.05", "cache"},
+		{"us-east-1", "web", "m5.large", "-", "on-demand", "8", "2", "0.0960", "0.00", "-"},
+	}
+	for i, fields := range expected {
+		got := strings.Fields(lines[i])
+		for j, field := range fields {
+			if got[j] != field {
+				t.Fatalf("Wrong field %d on line %d. Wanted: %s, got: %s\n%s", j, i, field, got[j], buf.String())
+			}
+		}
+	}
+}
+
+func TestAdviceMarkdown(t *testing.T) {
+	var buf bytes.Buffer
+	err := AdviceMarkdown(&buf, createTestAdvice())
+	if err != nil {
+		t.Fatalf("Failed to render markdown: %s", err.Error())
+	}
+
+	out := buf.String()
+	expectedInOrder := []string{
+		"| eu-west-1 | 0.2500 | 1 | 0.0850 | 62.0500 | 0.00 |",
+		"| us-east-1 | 0.5000 | 2 | 0.1360 | 99.2800 | 0.05 |",
+		"## eu-west-1",
+		"## us-east-1",
+		"| db | r5.large | us-east-1a | spot | 16 | 2 | 0.0400 | 0.05 | cache |",
+		"Total: $0.1360 per hour, $99.2800 per month",
+	}
+
+	lastIndex := -1
+	for _, expected := range expectedInOrder {
+		index := strings.Index(out, expected)
+		if index <= lastIndex {
+			t.Fatalf("Expected \"%s\" after previous lines in:\n%s", expected, out)
+		}
+		lastIndex = index
+	}
+}
+
+func TestAdviceMarkdownRanked(t *testing.T) {
+	advice := createTestAdvice()
+	ranking.Rank(advice, nil)
+
+	var buf bytes.Buffer
+	err := AdviceMarkdown(&buf, advice)
+	if err != nil {
+		t.Fatalf("Failed to render markdown: %s", err.Error())
+	}
+
+	out := buf.String()
+	expectedInOrder := []string{
+		"| us-east-1 | 0.5000 | 2 | 0.1360 | 99.2800 | 0.05 | 1 |",
+		"| eu-west-1 | 0.2500 | 1 | 0.0850 | 62.0500 | 0.00 | 2 |",
+		"## us-east-1",
+		"## eu-west-1",
+	}
+
+	lastIndex := -1
+	for _, expected := range expectedInOrder {
+		index := strings.Index(out, expected)
+		if index <= lastIndex {
+			t.Fatalf("Expected \"%s\" after previous lines in:\n%s", expected, out)
+		}
+		lastIndex = index
+	}
+}
+
+func TestAdviceMarkdownProjection(t *testing.T) {
+	advice := createTestAdvice()
+	usAdvice := (*advice)["us-east-1"]
+	usAdvice.Projection = &schema.CostProjection{
+		Period:              schema.DailyProfile,
+		PricePerPeriod:      2.4,
+		AveragePricePerHour: 0.1,
+		PeakPricePerHour:    0.136,
+	}
+	(*advice)["us-east-1"] = usAdvice
+
+	var buf bytes.Buffer
+	err := AdviceMarkdown(&buf, advice)
+	if err != nil {
+		t.Fatalf("Failed to render markdown: %s", err.Error())
+	}
+
+	out := buf.String()
+	expected := "Scheduled: $2.4000 per daily period, averaging $0.1000 per hour and peaking at $0.1360 per hour"
+	if strings.Count(out, "Scheduled:") != 1 || !strings.Contains(out, expected) {
+		t.Fatalf("Expected one projection \"%s\" in:\n%s", expected, out)
+	}
+}
+
+func TestAdviceDiffTable(t *testing.T) {
+	diff := &schema.AdviceDiff{
+		Regions: map[string]schema.RegionDiff{
+			"us-east-1": {
+				Status:     schema.DiffChanged,
+				PriceAfter: 0.1,
+				PriceDelta: 0.1,
+				Services: map[string]schema.ServiceDiff{
+					"web": {
+						Status: schema.DiffChanged,
+						InstanceTypes: []schema.InstanceTypeDiff{
+							{Status: schema.DiffUnchanged, Name: "m5.large", CountBefore: 1, CountAfter: 1},
+							{Status: schema.DiffAdded, Name: "c5.large", Spot: true, CountAfter: 1, PriceAfter: 0.1},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	err := AdviceDiffTable(&buf, diff)
+	if err != nil {
+		t.Fatalf("Failed to render diff table: %s", err.Error())
+	}
+
+	out := buf.String()
+	if strings.Contains(out, "m5.large") {
+		t.Fatalf("Unchanged instance type should not be rendered:\n%s", out)
+	}
+	if !strings.Contains(out, "+0.1000") {
+		t.Fatalf("Missing price delta:\n%s", out)
+	}
+
+	fields := strings.Fields(strings.Split(strings.TrimSpace(out), "\n")[4])
+	expected := []string{"us-east-1", "web", "added", "c5.large", "spot", "0", "1", "0.0000", "0.1000"}
+	for i, field := range expected {
+		if fields[i] != field {
+			t.Fatalf("Wrong field %d of change row. Wanted: %s, got: %s\n%s", i, field, fields[i], out)
+		}
+	}
+}
+
+func TestBatchAdviceTable(t *testing.T) {
+	resp := &schema.BatchAdviseResponse{
+		Comparison: []schema.ScenarioComparison{
+			{Scenario: "cheap", Region: "us-east-1", Score: 0.5, PricePerHour: 0.1, ExpectedRevocations: 0.25, InstanceCount: 2, Rank: 1},
+		},
+		Errors: map[string]string{"broken": "TEST ERROR"},
+	}
+
+	var buf bytes.Buffer
+	err := BatchAdviceTable(&buf, resp)
+	if err != nil {
+		t.Fatalf("Failed to render batch table: %s", err.Error())
+	}
+
+	out := buf.String()
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	fields := strings.Fields(lines[1])
+	expected := []string{"cheap", "us-east-1", "0.5000", "2", "0.1000", "73.0000", "0.25", "1"}
+	for i, field := range expected {
+		if fields[i] != field {
+			t.Fatalf("Wrong field %d of comparison row. Wanted: %s, got: %s\n%s", i, field, fields[i], out)
+		}
+	}
+	if !strings.Contains(lines[len(lines)-1], "broken") || !strings.Contains(lines[len(lines)-1], "TEST ERROR") {
+		t.Fatalf("Missing scenario error:\n%s", out)
+	}
+}
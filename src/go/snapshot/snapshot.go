@@ -0,0 +1,242 @@
+// Package snapshot stores timestamped, compressed copies of fetched instance
+// information, so that advice can be generated against past prices.
+package snapshot
+
+import (
+	instPkg "aws-blended-instances-advisor/instances"
+	"aws-blended-instances-advisor/utils"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	SNAPSHOT_FILENAME_PREFIX = "instances-"
+	SNAPSHOT_FILENAME_SUFFIX = ".json.gz"
+	SNAPSHOT_TIME_FORMAT     = "20060102T150405Z"
+)
+
+// ErrNoSnapshot is returned when no snapshot was taken at or before
+// a requested time.
+var ErrNoSnapshot = errors.New("no snapshot of instances exists at or before the requested time")
+
+// A Store saves and loads snapshots of instance information in a directory,
+// removing snapshots once they are older than its retention.
+type Store struct {
+	mu        sync.Mutex
+	dirpath   string
+	retention time.Duration // 0 to keep snapshots forever
+	now       func() time.Time
+}
+
+// A Snapshot describes a single stored copy of instance information.
+type Snapshot struct {
+	FetchedAt time.Time `json:"fetchedAt"`
+	Filepath  string    `json:"filepath"`
+	SizeBytes int64     `json:"sizeBytes"`
+}
+
+// NewStore creates a Store which keeps snapshots in dirpath for the given
+// retention, creating the directory if it does not exist.
+//
+// Returns an error if the directory could not be created.
+func NewStore(dirpath string, retention time.Duration) (*Store, error) {
+	err := os.MkdirAll(dirpath, utils.WRITE_PERMISSION_CODE)
+	if err != nil {
+		return nil, utils.PrependToError(err, "failed to create snapshot directory")
+	}
+
+	return &Store{
+		dirpath:   dirpath,
+		retention: retention,
+		now:       time.Now,
+	}, nil
+}
+
+// Save stores a snapshot of info fetched at fetchedAt, then removes snapshots
+// older than the Store's retention. Saving the same fetch twice has no effect.
+//
+// Returns an error if the snapshot could not be written.
+func (s *Store) Save(info *instPkg.GlobalInfo, fetchedAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	path := s.snapshotFilepath(fetchedAt)
+	exists, err := utils.FileExists(path)
+	if err != nil {
+		return err
+	}
+
+	if !exists {
+		var buf bytes.Buffer
+		writer := gzip.NewWriter(&buf)
+		err = json.NewEncoder(writer).Encode(info)
+		if err == nil {
+			err = writer.Close()
+		}
+		if err != nil {
+			return utils.PrependToError(err, "failed to compress snapshot")
+		}
+
+		err = utils.WriteBytesToFileAtomically(buf.Bytes(), path)
+		if err != nil {
+			return utils.PrependToError(err, "failed to write snapshot")
+		}
+	}
+
+	return s.prune()
+}
+
+// List returns every stored Snapshot, oldest first.
+//
+// Returns an error if the Store's directory could not be read.
+func (s *Store) List() ([]Snapshot, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.list()
+}
+
+// Load returns the instance information from the most recent snapshot taken
+// at or before asOf, along with when it was fetched.
+//
+// Returns ErrNoSnapshot if there is no such snapshot, or another error if
+// the snapshot could not be read.
+func (s *Store) Load(asOf time.Time) (*instPkg.GlobalInfo, time.Time, error) {
+	s.mu.Lock()
+	snapshots, err := s.list()
+	s.mu.Unlock()
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	var found *Snapshot
+	for i := range snapshots {
+		if snapshots[i].FetchedAt.After(asOf) {
+			break
+		}
+		found = &snapshots[i]
+	}
+	if found == nil {
+		return nil, time.Time{}, ErrNoSnapshot
+	}
+
+	info, err := readSnapshot(found.Filepath)
+	if err != nil {
+		return nil, time.Time{}, utils.PrependToError(
+			err,
+			fmt.Sprintf("failed to read snapshot %s", found.Filepath),
+		)
+	}
+
+	return info, found.FetchedAt, nil
+}
+
+func (s *Store) list() ([]Snapshot, error) {
+	entries, err := os.ReadDir(s.dirpath)
+	if err != nil {
+		return nil, utils.PrependToError(err, "failed to read snapshot directory")
+	}
+
+	snapshots := []Snapshot{}
+	for _, entry := range entries {
+		fetchedAt, ok := parseSnapshotFilename(entry.Name())
+		if !ok || entry.IsDir() {
+			continue
+		}
+
+		fileInfo, err := entry.Info()
+		if err != nil {
+			continue // Removed since listing
+		}
+
+		snapshots = append(snapshots, Snapshot{
+			FetchedAt: fetchedAt,
+			Filepath:  filepath.Join(s.dirpath, entry.Name()),
+			SizeBytes: fileInfo.Size(),
+		})
+	}
+
+	sort.Slice(snapshots, func(i, j int) bool {
+		return snapshots[i].FetchedAt.Before(snapshots[j].FetchedAt)
+	})
+	return snapshots, nil
+}
+
+// prune removes snapshots older than the Store's retention, always keeping
+// the most recent snapshot.
+func (s *Store) prune() error {
+	if s.retention == 0 {
+		return nil
+	}
+
+	snapshots, err := s.list()
+	if err != nil {
+		return err
+	}
+
+	cutoff := s.now().Add(-s.retention)
+	for i, snapshot := range snapshots {
+		if i == len(snapshots)-1 || !snapshot.FetchedAt.Before(cutoff) {
+			break
+		}
+		err = utils.DeleteFile(snapshot.Filepath)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return utils.PrependToError(err, "failed to remove expired snapshot")
+		}
+	}
+
+	return nil
+}
+
+func (s *Store) snapshotFilepath(fetchedAt time.Time) string {
+	name := SNAPSHOT_FILENAME_PREFIX + fetchedAt.UTC().Format(SNAPSHOT_TIME_FORMAT) + SNAPSHOT_FILENAME_SUFFIX
+	return filepath.Join(s.dirpath, name)
+}
+
+func parseSnapshotFilename(name string) (time.Time, bool) {
+	if !strings.HasPrefix(name, SNAPSHOT_FILENAME_PREFIX) || !strings.HasSuffix(name, SNAPSHOT_FILENAME_SUFFIX) {
+		return time.Time{}, false
+	}
+
+	timestamp := strings.TrimSuffix(strings.TrimPrefix(name, SNAPSHOT_FILENAME_PREFIX), SNAPSHOT_FILENAME_SUFFIX)
+	fetchedAt, err := time.Parse(SNAPSHOT_TIME_FORMAT, timestamp)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return fetchedAt, true
+}
+
+func readSnapshot(path string) (*instPkg.GlobalInfo, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	reader, err := gzip.NewReader(file)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	body, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	var info instPkg.GlobalInfo
+	err = json.Unmarshal(body, &info)
+	if err != nil {
+		return nil, err
+	}
+	return &info, nil
+}
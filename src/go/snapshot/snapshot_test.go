@@ -0,0 +1,118 @@
+package snapshot
+
+import (
+	awsTypes "aws-blended-instances-advisor/aws/types"
+	instPkg "aws-blended-instances-advisor/instances"
+	"testing"
+	"time"
+)
+
+func createTestInfo(price float64) *instPkg.GlobalInfo {
+	onDemand := &instPkg.Instance{Id: "0", Name: "m5.large", Region: awsTypes.UsEast1, PricePerHour: price}
+	spot := &instPkg.Instance{Id: "1", Name: "m5.large", Region: awsTypes.UsEast1, PricePerHour: price / 2}
+	info := instPkg.CreateGlobalInfo(
+		map[awsTypes.Region][]*instPkg.Instance{awsTypes.UsEast1: {onDemand}},
+		map[awsTypes.Region][]*instPkg.Instance{awsTypes.UsEast1: {spot}},
+		[]awsTypes.Region{awsTypes.UsEast1},
+	)
+	return &info
+}
+
+func getPrice(info *instPkg.GlobalInfo) float64 {
+	return info.RegionInfoMap[awsTypes.UsEast1].PermanentInstances[0].PricePerHour
+}
+
+func TestSaveAndLoad(t *testing.T) {
+	store, err := NewStore(t.TempDir(), 0)
+	if err != nil {
+		t.Fatalf("Failed to create store: %s", err.Error())
+	}
+
+	first := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+	second := first.Add(24 * time.Hour)
+
+	for _, snapshot := range []struct {
+		fetchedAt time.Time
+		price     float64
+	}{{first, 0.1}, {second, 0.2}, {second, 0.3}} {
+		err = store.Save(createTestInfo(snapshot.price), snapshot.fetchedAt)
+		if err != nil {
+			t.Fatalf("Failed to save snapshot: %s", err.Error())
+		}
+	}
+
+	snapshots, err := store.List()
+	if err != nil {
+		t.Fatalf("Failed to list snapshots: %s", err.Error())
+	}
+	if len(snapshots) != 2 {
+		t.Fatalf("Wrong number of snapshots. Wanted: 2, got: %d", len(snapshots))
+	}
+
+	tests := map[string]struct {
+		asOf              time.Time
+		expectedPrice     float64
+		expectedFetchedAt time.Time
+	}{
+		"exactly first": {asOf: first, expectedPrice: 0.1, expectedFetchedAt: first},
+		"between":       {asOf: first.Add(time.Hour), expectedPrice: 0.1, expectedFetchedAt: first},
+		"after second":  {asOf: second.Add(time.Hour), expectedPrice: 0.2, expectedFetchedAt: second},
+		"far in future": {asOf: second.Add(1000 * time.Hour), expectedPrice: 0.2, expectedFetchedAt: second},
+	}
+
+	for name, test := range tests {
+		info, fetchedAt, err := store.Load(test.asOf)
+		if err != nil {
+			t.Fatalf("Failed to load snapshot for \"%s\": %s", name, err.Error())
+		}
+		if price := getPrice(info); price != test.expectedPrice {
+			t.Fatalf("Wrong snapshot loaded for \"%s\". Wanted price: %f, got: %f", name, test.expectedPrice, price)
+		}
+		if !fetchedAt.Equal(test.expectedFetchedAt) {
+			t.Fatalf("Wrong fetch time for \"%s\". Wanted: %s, got: %s", name, test.expectedFetchedAt, fetchedAt)
+		}
+	}
+
+	_, _, err = store.Load(first.Add(-time.Hour))
+	if err != ErrNoSnapshot {
+		t.Fatalf("Expected ErrNoSnapshot before first snapshot, got: %v", err)
+	}
+}
+
+func TestRetention(t *testing.T) {
+	now := time.Date(2000, 1, 10, 0, 0, 0, 0, time.UTC)
+	store, err := NewStore(t.TempDir(), 48*time.Hour)
+	if err != nil {
+		t.Fatalf("Failed to create store: %s", err.Error())
+	}
+	store.now = func() time.Time { return now }
+
+	for days := 5; days >= 0; days -= 1 {
+		err = store.Save(createTestInfo(float64(days)), now.Add(-time.Duration(days)*24*time.Hour))
+		if err != nil {
+			t.Fatalf("Failed to save snapshot: %s", err.Error())
+		}
+	}
+
+	snapshots, err := store.List()
+	if err != nil {
+		t.Fatalf("Failed to list snapshots: %s", err.Error())
+	}
+	if len(snapshots) != 3 {
+		t.Fatalf("Wrong number of snapshots kept. Wanted: 3, got: %d", len(snapshots))
+	}
+
+	oldStore, err := NewStore(t.TempDir(), time.Hour)
+	if err != nil {
+		t.Fatalf("Failed to create store: %s", err.Error())
+	}
+	oldStore.now = func() time.Time { return now }
+	err = oldStore.Save(createTestInfo(1), now.Add(-100*time.Hour))
+	if err != nil {
+		t.Fatalf("Failed to save snapshot: %s", err.Error())
+	}
+	snapshots, _ = oldStore.List()
+	if len(snapshots) != 1 {
+		t.Fatalf("Most recent snapshot should be kept regardless of age")
+	}
+}
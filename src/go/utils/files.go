@@ -29,6 +29,48 @@ func WriteBytesToFile(data []byte, filepath string) error {
 	return os.WriteFile(filepath, data, WRITE_PERMISSION_CODE)
 }
 
+// WriteBytesToFileAtomically writes a byte array to the given filepath via a
+// temporary file in the same directory, so that readers see either the old
+// or the new content of the file and never a partial write. An existing
+// file keeps its permissions.
+//
+// An error is returned if one is encountered while writing to the file.
+func WriteBytesToFileAtomically(data []byte, path string) error {
+	tempFile, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	tempPath := tempFile.Name()
+
+	var mode os.FileMode = WRITE_PERMISSION_CODE
+	if info, statErr := os.Stat(path); statErr == nil {
+		mode = info.Mode().Perm()
+	}
+
+	_, err = tempFile.Write(data)
+	if err == nil {
+		err = tempFile.Sync()
+	}
+	closeErr := tempFile.Close()
+	if err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Chmod(tempPath, mode)
+	}
+	if err != nil {
+		os.Remove(tempPath)
+		return err
+	}
+
+	err = os.Rename(tempPath, path)
+	if err != nil {
+		os.Remove(tempPath)
+		return err
+	}
+	return nil
+}
+
 // WriteStringToFile writes a string to the given filepath,
 // returning an error if one is encountered while writing to
 // the file.
@@ -58,10 +100,6 @@ func FileExists(filepath string) (bool, error) {
 	return false, err
 }
 
-func GetCallerPath() (string, error) {
-	return os.Getwd()
-}
-
 // CreateFilepath forms a string filepath from a given set of components,
 // adding system-specific path separators where appropriate.
 //